@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/config"
+	"github.com/anthropics/pickle-go/apps/api/internal/outbox"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/internal/scheduler"
+	"github.com/anthropics/pickle-go/apps/api/internal/service"
+)
+
+// notificationRetention is how long read notifications are kept
+const notificationRetention = 90 * 24 * time.Hour
+
+// outboxRetention is how long delivered outbox messages are kept
+const outboxRetention = 7 * 24 * time.Hour
+
+// registerJobs registers the periodic background jobs
+// 註冊定期背景工作
+func registerJobs(
+	sched *scheduler.Scheduler,
+	cfg *config.Config,
+	eventRepo *repository.EventRepository,
+	notificationRepo *repository.NotificationRepository,
+	outboxRepo *repository.OutboxRepository,
+	dispatcher *outbox.Dispatcher,
+	seriesService *service.SeriesService,
+	waitlistService *service.WaitlistService,
+	reminderService *service.ReminderService,
+	digestService *service.DigestService,
+	deliveryService *service.DeliveryService,
+) {
+	// Deliver side effects recorded in the outbox
+	sched.MustRegister(scheduler.Job{
+		Name:    "outbox-dispatch",
+		Spec:    "@every 5s",
+		Timeout: time.Minute,
+		Run: func(ctx context.Context) error {
+			n, err := dispatcher.Dispatch(ctx)
+			if n > 0 {
+				log.Printf("Dispatched %d outbox messages", n)
+			}
+			return err
+		},
+	})
+
+	// Hand expired waitlist offers to the next person in line
+	sched.MustRegister(scheduler.Job{
+		Name:    "waitlist-offer-expiry",
+		Spec:    "@every 1m",
+		Timeout: 30 * time.Second,
+		Run: func(ctx context.Context) error {
+			n, err := waitlistService.ExpireOffers(ctx)
+			if n > 0 {
+				log.Printf("Expired %d waitlist offers", n)
+			}
+			return err
+		},
+	})
+
+	// Remind players before their events start
+	sched.MustRegister(scheduler.Job{
+		Name:       "event-reminders",
+		Spec:       "*/5 * * * *",
+		Timeout:    2 * time.Minute,
+		MaxRetries: 2,
+		Run: func(ctx context.Context) error {
+			n, err := reminderService.SendDue(ctx)
+			if n > 0 {
+				log.Printf("Sent %d event reminders", n)
+			}
+			return err
+		},
+	})
+
+	// Send daily and weekly notification digests
+	sched.MustRegister(scheduler.Job{
+		Name:       "notification-digest",
+		Spec:       "*/15 * * * *",
+		Timeout:    5 * time.Minute,
+		MaxRetries: 2,
+		Run: func(ctx context.Context) error {
+			n, err := digestService.SendDue(ctx)
+			if n > 0 {
+				log.Printf("Sent %d notification digests", n)
+			}
+			return err
+		},
+	})
+
+	// Push new notifications to LINE
+	sched.MustRegister(scheduler.Job{
+		Name:    "notification-delivery",
+		Spec:    "@every 15s",
+		Timeout: time.Minute,
+		Run: func(ctx context.Context) error {
+			n, err := deliveryService.DeliverPending(ctx)
+			if n > 0 {
+				log.Printf("Delivered %d notifications to LINE", n)
+			}
+			return err
+		},
+	})
+
+	// Move events that have ended to completed
+	sched.MustRegister(scheduler.Job{
+		Name:       "event-completion",
+		Spec:       "*/5 * * * *",
+		Timeout:    time.Minute,
+		MaxRetries: 2,
+		Run: func(ctx context.Context) error {
+			n, err := eventRepo.CompletePastEvents(ctx, cfg.EventTimezone)
+			if n > 0 {
+				log.Printf("Completed %d past events", n)
+			}
+			return err
+		},
+	})
+
+	// Keep recurring series materialized ahead of time
+	sched.MustRegister(scheduler.Job{
+		Name:       "series-materialization",
+		Spec:       "0 2 * * *",
+		Timeout:    10 * time.Minute,
+		MaxRetries: 3,
+		RetryDelay: time.Minute,
+		Run: func(ctx context.Context) error {
+			n, err := seriesService.MaterializeActive(ctx)
+			if n > 0 {
+				log.Printf("Materialized %d series occurrences", n)
+			}
+			return err
+		},
+	})
+
+	// Clean up old read notifications
+	sched.MustRegister(scheduler.Job{
+		Name:       "notification-cleanup",
+		Spec:       "30 3 * * *",
+		Timeout:    10 * time.Minute,
+		MaxRetries: 3,
+		RetryDelay: time.Minute,
+		Run: func(ctx context.Context) error {
+			n, err := notificationRepo.DeleteReadBefore(ctx, time.Now().Add(-notificationRetention))
+			if n > 0 {
+				log.Printf("Deleted %d old notifications", n)
+			}
+			return err
+		},
+	})
+
+	// Clean up delivered outbox messages; dead letters are kept for inspection
+	sched.MustRegister(scheduler.Job{
+		Name:       "outbox-cleanup",
+		Spec:       "45 3 * * *",
+		Timeout:    10 * time.Minute,
+		MaxRetries: 3,
+		RetryDelay: time.Minute,
+		Run: func(ctx context.Context) error {
+			n, err := outboxRepo.DeleteDoneBefore(ctx, time.Now().Add(-outboxRetention))
+			if n > 0 {
+				log.Printf("Deleted %d delivered outbox messages", n)
+			}
+			return err
+		},
+	})
+}
@@ -13,7 +13,14 @@ import (
 	"github.com/anthropics/pickle-go/apps/api/internal/database"
 	"github.com/anthropics/pickle-go/apps/api/internal/handler"
 	"github.com/anthropics/pickle-go/apps/api/internal/middleware"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/outbox"
+	"github.com/anthropics/pickle-go/apps/api/internal/realtime"
 	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/internal/scheduler"
+	"github.com/anthropics/pickle-go/apps/api/internal/service"
+	"github.com/anthropics/pickle-go/apps/api/pkg/checkin"
+	"github.com/anthropics/pickle-go/apps/api/pkg/invite"
 	"github.com/anthropics/pickle-go/apps/api/pkg/line"
 	"github.com/getsentry/sentry-go"
 	"github.com/gin-gonic/gin"
@@ -68,6 +75,37 @@ func main() {
 	eventRepo := repository.NewEventRepository(db)
 	registrationRepo := repository.NewRegistrationRepository(db)
 	notificationRepo := repository.NewNotificationRepository(db)
+	preferenceRepo := repository.NewPreferenceRepository(db)
+	seriesRepo := repository.NewSeriesRepository(db)
+	reminderRepo := repository.NewReminderRepository(db)
+	deliveryRepo := repository.NewDeliveryRepository(db)
+	outboxRepo := repository.NewOutboxRepository(db)
+	transferRepo := repository.NewTransferRepository(db)
+	eventRoleRepo := repository.NewEventRoleRepository(db)
+	blockRepo := repository.NewBlockRepository(db)
+	clubRepo := repository.NewClubRepository(db)
+
+	// Initialize services
+	seriesService := service.NewSeriesService(seriesRepo, eventRepo, registrationRepo, outboxRepo, txManager)
+	waitlistService := service.NewWaitlistService(registrationRepo, eventRepo, outboxRepo, txManager, cfg.WaitlistOfferWindow)
+	reminderService := service.NewReminderService(reminderRepo, outboxRepo, txManager, cfg.ReminderOffsets, cfg.EventTimezone)
+	digestService := service.NewDigestService(notificationRepo, preferenceRepo, txManager)
+	reliabilityService := service.NewReliabilityService(registrationRepo, model.ReliabilityPolicy{Window: cfg.ReliabilityWindow}, cfg.EventTimezone)
+
+	// Relay database changes to live streams
+	// 將資料庫變更即時推送給串流連線
+	streamCtx, stopStreams := context.WithCancel(context.Background())
+	defer stopStreams()
+	hub := realtime.NewHub()
+	go func() {
+		if err := realtime.Listen(streamCtx, cfg.DatabaseURL, hub); err != nil {
+			log.Printf("Realtime listener stopped: %v", err)
+		}
+	}()
+
+	// Initialize outbox dispatcher
+	dispatcher := outbox.NewDispatcher(outboxRepo, outbox.Options{})
+	dispatcher.Register(model.OutboxTopicNotification, outbox.NewNotificationHandler(notificationRepo))
 
 	// Initialize Line client
 	lineClient := line.NewClient(line.Config{
@@ -76,11 +114,41 @@ func main() {
 		RedirectURI:   cfg.LineRedirectURI,
 	})
 
+	// Initialize Line Messaging client for push notifications
+	lineMessagingClient := line.NewMessagingClient(line.MessagingConfig{
+		ChannelAccessToken: cfg.LineMessagingAccessToken,
+		ChannelSecret:      cfg.LineMessagingChannelSecret,
+		BaseURL:            cfg.LineMessagingBaseURL,
+	})
+	deliveryService := service.NewDeliveryService(deliveryRepo, eventRepo, lineMessagingClient, cfg.BaseURL)
+
+	// Event dates and times are wall-clock times in this timezone
+	eventLocation, err := time.LoadLocation(cfg.EventTimezone)
+	if err != nil {
+		log.Fatalf("Invalid event timezone %q: %v", cfg.EventTimezone, err)
+	}
+
+	// Invite links to invite-only events are signed so they need no lookup table
+	inviteSigner := invite.NewSigner(cfg.InviteSecret)
+
 	// Initialize handlers
-	authHandler := handler.NewAuthHandler(userRepo, lineClient)
-	userHandler := handler.NewUserHandler(userRepo, eventRepo, registrationRepo, notificationRepo)
-	eventHandler := handler.NewEventHandler(eventRepo, userRepo, registrationRepo)
-	registrationHandler := handler.NewRegistrationHandler(registrationRepo, eventRepo, notificationRepo, txManager)
+	authHandler := handler.NewAuthHandler(userRepo, lineClient, reliabilityService)
+	userHandler := handler.NewUserHandler(userRepo, eventRepo, registrationRepo)
+	notificationHandler := handler.NewNotificationHandler(notificationRepo, preferenceRepo, txManager, cfg.EventTimezone)
+	eventHandler := handler.NewEventHandler(eventRepo, eventRoleRepo, userRepo, registrationRepo, clubRepo, seriesRepo, outboxRepo, txManager, inviteSigner, cfg.BaseURL, eventLocation, cfg.DefaultCancelDeadline)
+	registrationHandler := handler.NewRegistrationHandler(registrationRepo, eventRepo, clubRepo, outboxRepo, txManager, reliabilityService, inviteSigner, cfg.WaitlistOfferWindow, eventLocation)
+	seriesHandler := handler.NewSeriesHandler(seriesRepo, seriesService)
+	lineWebhookHandler := handler.NewLineWebhookHandler(userRepo, lineMessagingClient)
+	streamHandler := handler.NewStreamHandler(hub, streamCtx.Done(), eventRepo, registrationRepo, notificationRepo)
+	checkInHandler := handler.NewCheckInHandler(registrationRepo, eventRepo, checkin.NewSigner(cfg.CheckInSecret), model.CheckInWindow{
+		OpensBefore: cfg.CheckInOpensBefore,
+		ClosesAfter: cfg.CheckInClosesAfter,
+	}, eventLocation)
+	attendanceHandler := handler.NewAttendanceHandler(registrationRepo, eventRepo, txManager, eventLocation)
+	eventRoleHandler := handler.NewEventRoleHandler(eventRoleRepo, eventRepo, userRepo, outboxRepo, txManager)
+	blockHandler := handler.NewBlockHandler(blockRepo, userRepo, eventRepo, registrationRepo, outboxRepo, txManager, cfg.WaitlistOfferWindow)
+	clubHandler := handler.NewClubHandler(clubRepo, eventRepo, userRepo, outboxRepo, txManager, eventLocation)
+	transferHandler := handler.NewTransferHandler(transferRepo, registrationRepo, eventRepo, userRepo, clubRepo, outboxRepo, txManager, reliabilityService, cfg.BaseURL, cfg.TransferLinkTTL, eventLocation)
 
 	// Initialize router
 	// 初始化路由器
@@ -134,7 +202,17 @@ func main() {
 			users.GET("/me", middleware.AuthRequired(), authHandler.GetCurrentUser)
 			users.GET("/me/events", middleware.AuthRequired(), userHandler.GetMyEvents)
 			users.GET("/me/registrations", middleware.AuthRequired(), userHandler.GetMyRegistrations)
-			users.GET("/me/notifications", middleware.AuthRequired(), userHandler.GetMyNotifications)
+			users.GET("/me/notifications", middleware.AuthRequired(), notificationHandler.ListNotifications)
+			users.GET("/me/notifications/unread-count", middleware.AuthRequired(), notificationHandler.GetUnreadCount)
+			users.POST("/me/notifications/read-all", middleware.AuthRequired(), notificationHandler.MarkAllAsRead)
+			users.POST("/me/notifications/:id/read", middleware.AuthRequired(), notificationHandler.MarkAsRead)
+			users.DELETE("/me/notifications/:id", middleware.AuthRequired(), notificationHandler.DeleteNotification)
+			users.GET("/me/stream", middleware.AuthRequired(), streamHandler.StreamMyUpdates)
+			users.GET("/me/notification-preferences", middleware.AuthRequired(), notificationHandler.GetPreferences)
+			users.PUT("/me/notification-preferences", middleware.AuthRequired(), notificationHandler.UpdatePreferences)
+			users.GET("/me/blocks", middleware.AuthRequired(), blockHandler.ListBlocks)
+			users.POST("/me/blocks", middleware.AuthRequired(), blockHandler.BlockUser)
+			users.DELETE("/me/blocks/:userId", middleware.AuthRequired(), blockHandler.UnblockUser)
 		}
 
 		// Event routes
@@ -143,14 +221,78 @@ func main() {
 			events.GET("", eventHandler.ListEvents)
 			events.GET("/by-code/:code", eventHandler.GetEventByCode)
 			events.GET("/:id", eventHandler.GetEvent)
+			events.GET("/:id/stream", streamHandler.StreamEvent)
 			events.POST("", middleware.AuthRequired(), eventHandler.CreateEvent)
 			events.PUT("/:id", middleware.AuthRequired(), eventHandler.UpdateEvent)
 			events.DELETE("/:id", middleware.AuthRequired(), eventHandler.DeleteEvent)
+			events.GET("/:id/invite", middleware.AuthRequired(), eventHandler.GetInviteLink)
 
 			// Registration routes
 			events.POST("/:id/register", middleware.AuthRequired(), registrationHandler.RegisterEvent)
+			events.POST("/:id/register/group", middleware.AuthRequired(), registrationHandler.RegisterGroup)
 			events.DELETE("/:id/register", middleware.AuthRequired(), registrationHandler.CancelRegistration)
-			events.GET("/:id/registrations", registrationHandler.GetEventRegistrations)
+			events.DELETE("/:id/register/guests/:guestId", middleware.AuthRequired(), registrationHandler.CancelGuest)
+			events.GET("/:id/registrations", middleware.OptionalAuth(), registrationHandler.GetEventRegistrations)
+			events.GET("/:id/registrations/pending", middleware.AuthRequired(), registrationHandler.GetPendingRegistrations)
+			events.POST("/:id/registrations/:registrationId/approve", middleware.AuthRequired(), registrationHandler.ApproveRegistration)
+			events.POST("/:id/registrations/:registrationId/reject", middleware.AuthRequired(), registrationHandler.RejectRegistration)
+			events.POST("/:id/registrations/:registrationId/remove", middleware.AuthRequired(), registrationHandler.RemoveRegistration)
+			events.POST("/:id/registrations/:registrationId/promote", middleware.AuthRequired(), registrationHandler.PromoteRegistration)
+			events.PUT("/:id/registrations/:registrationId/waitlist-position", middleware.AuthRequired(), registrationHandler.MoveWaitlistRegistration)
+			events.POST("/:id/offer/accept", middleware.AuthRequired(), registrationHandler.AcceptOffer)
+			events.POST("/:id/offer/decline", middleware.AuthRequired(), registrationHandler.DeclineOffer)
+
+			// Check-in routes
+			events.GET("/:id/check-in/code", middleware.AuthRequired(), checkInHandler.GetCheckInCode)
+			events.POST("/:id/check-in", middleware.AuthRequired(), checkInHandler.CheckIn)
+			events.DELETE("/:id/check-in/:registrationId", middleware.AuthRequired(), checkInHandler.UndoCheckIn)
+			events.PUT("/:id/attendance", middleware.AuthRequired(), attendanceHandler.MarkAttendance)
+
+			// Spot transfer routes
+			events.POST("/:id/transfer", middleware.AuthRequired(), transferHandler.CreateTransfer)
+			events.DELETE("/:id/transfer", middleware.AuthRequired(), transferHandler.RevokeTransfer)
+			events.GET("/:id/transfers", middleware.AuthRequired(), transferHandler.ListTransfers)
+			events.POST("/:id/transfers/:transferId/approve", middleware.AuthRequired(), transferHandler.ApproveTransfer)
+			events.POST("/:id/transfers/:transferId/reject", middleware.AuthRequired(), transferHandler.RejectTransfer)
+
+			// Co-host and check-in helper routes
+			events.GET("/:id/roles", middleware.AuthRequired(), eventRoleHandler.ListRoles)
+			events.POST("/:id/roles", middleware.AuthRequired(), eventRoleHandler.AssignRole)
+			events.POST("/:id/roles/accept", middleware.AuthRequired(), eventRoleHandler.AcceptRole)
+			events.DELETE("/:id/roles/:userId", middleware.AuthRequired(), eventRoleHandler.RemoveRole)
+		}
+
+		// Spot transfer links
+		transfers := v1.Group("/transfers")
+		{
+			transfers.GET("/:token", transferHandler.GetTransfer)
+			transfers.POST("/:token/accept", middleware.AuthRequired(), transferHandler.AcceptTransfer)
+		}
+
+		// Club routes
+		clubs := v1.Group("/clubs")
+		{
+			clubs.POST("", middleware.AuthRequired(), clubHandler.CreateClub)
+			clubs.GET("/:id", middleware.OptionalAuth(), clubHandler.GetClub)
+			clubs.PUT("/:id", middleware.AuthRequired(), clubHandler.UpdateClub)
+			clubs.POST("/:id/join", middleware.AuthRequired(), clubHandler.JoinClub)
+			clubs.GET("/:id/members", middleware.AuthRequired(), clubHandler.ListMembers)
+			clubs.POST("/:id/members", middleware.AuthRequired(), clubHandler.AddMember)
+			clubs.PUT("/:id/members/:userId", middleware.AuthRequired(), clubHandler.UpdateMember)
+			clubs.DELETE("/:id/members/:userId", middleware.AuthRequired(), clubHandler.RemoveMember)
+		}
+
+		// LINE Messaging API webhook
+		v1.POST("/line/webhook", lineWebhookHandler.HandleWebhook)
+
+		// Recurring event series routes
+		series := v1.Group("/series")
+		{
+			series.POST("", middleware.AuthRequired(), seriesHandler.CreateSeries)
+			series.GET("/:id", seriesHandler.GetSeries)
+			series.DELETE("/:id", middleware.AuthRequired(), seriesHandler.EndSeries)
+			series.PUT("/:id/occurrences/:eventId", middleware.AuthRequired(), seriesHandler.UpdateOccurrence)
+			series.DELETE("/:id/occurrences/:eventId", middleware.AuthRequired(), seriesHandler.CancelOccurrence)
 		}
 	}
 
@@ -162,6 +304,18 @@ func main() {
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	// Live streams end as soon as the server starts shutting down; other
+	// requests keep their own context and get the grace period below
+	srv.RegisterOnShutdown(stopStreams)
+
+	// Start background job scheduler
+	// 啟動背景排程
+	sched := scheduler.New(database.NewAdvisoryLock(db, "pickle-go:scheduler"), eventLocation)
+	registerJobs(sched, cfg, eventRepo, notificationRepo, outboxRepo, dispatcher, seriesService, waitlistService, reminderService, digestService, deliveryService)
+	if cfg.SchedulerEnabled {
+		sched.Start()
+		log.Println("Scheduler started")
+	}
 
 	// Start server in goroutine
 	go func() {
@@ -186,5 +340,10 @@ func main() {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 
+	// Wait for running jobs to finish and release the scheduler lock
+	if err := sched.Stop(ctx); err != nil {
+		log.Printf("Scheduler forced to stop: %v", err)
+	}
+
 	log.Println("Server exited")
 }
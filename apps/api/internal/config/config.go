@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -29,12 +30,53 @@ type Config struct {
 	LineChannelSecret string
 	LineRedirectURI   string
 
+	// Line Messaging API 推播設定
+	// Push notifications are disabled when the access token is empty
+	LineMessagingAccessToken   string
+	LineMessagingChannelSecret string
+	LineMessagingBaseURL       string
+
 	// CORS 跨域設定
 	CORSAllowedOrigins []string
 
 	// Application 應用程式設定
 	BaseURL string
 
+	// Scheduler 排程設定
+	// Background jobs run on whichever replica holds the scheduler lock
+	SchedulerEnabled bool
+	// Time zone event dates and times are expressed in
+	EventTimezone string
+
+	// Waitlist 候補設定
+	// How long a freed spot is held for the next waitlisted player (0 promotes immediately)
+	WaitlistOfferWindow time.Duration
+
+	// Reminders 提醒設定
+	// How long before an event starts reminders are sent
+	ReminderOffsets []time.Duration
+
+	// Check-in 報到設定
+	// Secret used to sign check-in QR codes
+	CheckInSecret string
+	// How long before and after an event starts the host can check players in
+	CheckInOpensBefore time.Duration
+	CheckInClosesAfter time.Duration
+
+	// Reliability 出席可靠度設定
+	// How far back attendance counts towards a player's reliability
+	ReliabilityWindow time.Duration
+	// Cancellation deadline for new events when the host does not set one (0 for none)
+	DefaultCancelDeadline time.Duration
+
+	// Spot transfer 名額轉讓設定
+	// How long a transfer link stays valid (never past the event start)
+	TransferLinkTTL time.Duration
+
+	// Invites 邀請設定
+	// Secret used to sign invite links to invite-only events
+	InviteSecret string
+
 	// Sentry 錯誤監控設定
 	SentryDSN         string
 	SentryEnvironment string
@@ -62,12 +104,36 @@ func Load() (*Config, error) {
 		LineRedirectURI:    getEnv("LINE_REDIRECT_URI", "http://localhost:3000/auth/callback"),
 		CORSAllowedOrigins: strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:3000"), ","),
 		BaseURL:            getEnv("BASE_URL", "http://localhost:3000"),
+		// Line Messaging API 設定
+		LineMessagingAccessToken:   getEnv("LINE_MESSAGING_ACCESS_TOKEN", ""),
+		LineMessagingChannelSecret: getEnv("LINE_MESSAGING_CHANNEL_SECRET", ""),
+		LineMessagingBaseURL:       getEnv("LINE_MESSAGING_BASE_URL", "https://api.line.me"),
+		// 排程設定
+		SchedulerEnabled: getEnv("SCHEDULER_ENABLED", "true") == "true",
+		EventTimezone:    getEnv("EVENT_TIMEZONE", "Asia/Taipei"),
+		// 候補設定
+		WaitlistOfferWindow: getDurationEnv("WAITLIST_OFFER_WINDOW", 2*time.Hour),
+		// 提醒設定
+		ReminderOffsets: getDurationListEnv("REMINDER_OFFSETS", []time.Duration{24 * time.Hour, 2 * time.Hour}),
+		// 報到設定
+		CheckInOpensBefore: getDurationEnv("CHECKIN_OPENS_BEFORE", time.Hour),
+		CheckInClosesAfter: getDurationEnv("CHECKIN_CLOSES_AFTER", 3*time.Hour),
+		// 出席可靠度設定
+		ReliabilityWindow:     getDurationEnv("RELIABILITY_WINDOW", 180*24*time.Hour),
+		DefaultCancelDeadline: getDurationEnv("DEFAULT_CANCEL_DEADLINE", 24*time.Hour),
+		// 名額轉讓設定
+		TransferLinkTTL: getDurationEnv("TRANSFER_LINK_TTL", 48*time.Hour),
 		// Sentry 設定
 		SentryDSN:         getEnv("SENTRY_DSN", ""),
 		SentryEnvironment: getEnv("SENTRY_ENVIRONMENT", env),
 		SentryRelease:     getEnv("SENTRY_RELEASE", "1.0.0"),
 	}
 
+	// Check-in codes are signed with the JWT secret unless a separate one is set
+	cfg.CheckInSecret = getEnv("CHECKIN_SECRET", cfg.JWTSecret)
+	// Invite links likewise
+	cfg.InviteSecret = getEnv("INVITE_SECRET", cfg.JWTSecret)
+
 	return cfg, nil
 }
 
@@ -89,3 +155,28 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
+func getDurationListEnv(key string, defaultValue []time.Duration) []time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var ds []time.Duration
+	for _, part := range strings.Split(value, ",") {
+		d, err := time.ParseDuration(strings.TrimSpace(part))
+		if err != nil || d <= 0 {
+			return defaultValue
+		}
+		ds = append(ds, d)
+	}
+	return ds
+}
@@ -23,6 +23,22 @@ type CreateEventRequest struct {
 	Capacity    int               `json:"capacity" binding:"required,min=4,max=20"`
 	SkillLevel  string            `json:"skill_level" binding:"required,oneof=beginner intermediate advanced expert any"`
 	Fee         int               `json:"fee" binding:"min=0,max=9999"`
+	MinReliability *int           `json:"min_reliability" binding:"omitempty,min=0,max=100"`
+	// CancelDeadlineHours defaults to the server setting when omitted; 0 sets no deadline
+	CancelDeadlineHours *int      `json:"cancel_deadline_hours" binding:"omitempty,min=0,max=168"`
+	TransferRequiresApproval bool `json:"transfer_requires_approval"`
+	// MaxGuests is how many guests each player may bring; a party never exceeds the minimum capacity
+	MaxGuests int `json:"max_guests" binding:"min=0,max=3"`
+	// RequiresApproval makes every registration wait for the host to approve it
+	RequiresApproval bool `json:"requires_approval"`
+	// Visibility defaults to public; unlisted and invite-only events stay off the map
+	Visibility string `json:"visibility" binding:"omitempty,oneof=public unlisted invite_only"`
+	// ClubID runs the event for a club the host owns or administers
+	ClubID string `json:"club_id" binding:"omitempty,uuid"`
+	// RegistrationAccess defaults to everyone; the other options need a club
+	RegistrationAccess string `json:"registration_access" binding:"omitempty,oneof=everyone members_only members_first"`
+	// MemberPriorityHours is how long before the start members_first events open to non-members
+	MemberPriorityHours *int `json:"member_priority_hours" binding:"omitempty,min=1,max=336"`
 }
 
 // LocationRequest represents location data in requests
@@ -36,15 +52,47 @@ type LocationRequest struct {
 
 // UpdateEventRequest represents the request body for updating an event
 type UpdateEventRequest struct {
-	Title       *string `json:"title"`
-	Description *string `json:"description"`
-	EventDate   *string `json:"event_date"`
-	StartTime   *string `json:"start_time"`
-	EndTime     *string `json:"end_time"`
-	Capacity    *int    `json:"capacity" binding:"omitempty,min=4,max=20"`
-	SkillLevel  *string `json:"skill_level" binding:"omitempty,oneof=beginner intermediate advanced expert any"`
-	Fee         *int    `json:"fee" binding:"omitempty,min=0,max=9999"`
-	Status      *string `json:"status" binding:"omitempty,oneof=open full cancelled"`
+	Title          *string `json:"title"`
+	Description    *string `json:"description"`
+	EventDate      *string `json:"event_date"`
+	StartTime      *string `json:"start_time"`
+	EndTime        *string `json:"end_time"`
+	Capacity       *int    `json:"capacity" binding:"omitempty,min=4,max=20"`
+	CapacityPolicy *string `json:"capacity_policy" binding:"omitempty,oneof=refuse demote"`
+	SkillLevel     *string `json:"skill_level" binding:"omitempty,oneof=beginner intermediate advanced expert any"`
+	Fee            *int    `json:"fee" binding:"omitempty,min=0,max=9999"`
+	Status         *string `json:"status" binding:"omitempty,oneof=open full cancelled"`
+	// MinReliability of 0 removes the requirement
+	MinReliability *int    `json:"min_reliability" binding:"omitempty,min=0,max=100"`
+	// CancelDeadlineHours of 0 removes the deadline
+	CancelDeadlineHours *int `json:"cancel_deadline_hours" binding:"omitempty,min=0,max=168"`
+	TransferRequiresApproval *bool `json:"transfer_requires_approval"`
+	// MaxGuests of 0 stops new registrations from bringing guests
+	MaxGuests *int `json:"max_guests" binding:"omitempty,min=0,max=3"`
+	// RequiresApproval only affects new registrations; pending requests stay pending
+	RequiresApproval *bool   `json:"requires_approval"`
+	Visibility       *string `json:"visibility" binding:"omitempty,oneof=public unlisted invite_only"`
+	// RegistrationAccess only affects new registrations; club members keep their spots either way
+	RegistrationAccess  *string `json:"registration_access" binding:"omitempty,oneof=everyone members_only members_first"`
+	MemberPriorityHours *int    `json:"member_priority_hours" binding:"omitempty,min=1,max=336"`
+}
+
+// RegisterEventRequest represents the optional request body for registering for an event
+type RegisterEventRequest struct {
+	// Guests are the names of friends without an account, each taking a seat
+	Guests []string `json:"guests" binding:"omitempty,dive,required,max=50"`
+	// SkillLevel is shown to the host when the event requires approval
+	SkillLevel *string `json:"skill_level" binding:"omitempty,oneof=beginner intermediate advanced expert"`
+	// InviteToken is required for invite-only events
+	InviteToken string `json:"invite_token"`
+}
+
+// RegisterGroupRequest represents the request body for registering a group for an event
+type RegisterGroupRequest struct {
+	// UserIDs are the players registered together with the caller, up to a foursome
+	UserIDs []string `json:"user_ids" binding:"required,min=1,max=3,dive,uuid"`
+	// InviteToken is required for invite-only events
+	InviteToken string `json:"invite_token"`
 }
 
 // ListEventsQuery represents query parameters for listing events
@@ -57,3 +105,137 @@ type ListEventsQuery struct {
 	Limit      int     `form:"limit" binding:"max=100"`
 	Offset     int     `form:"offset"`
 }
+
+// CreateSeriesRequest represents the request body for creating a recurring event series
+type CreateSeriesRequest struct {
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	StartTime   string            `json:"start_time" binding:"required"`
+	EndTime     string            `json:"end_time"`
+	Location    LocationRequest   `json:"location" binding:"required"`
+	Capacity    int               `json:"capacity" binding:"required,min=4,max=20"`
+	SkillLevel  string            `json:"skill_level" binding:"required,oneof=beginner intermediate advanced expert any"`
+	Fee         int               `json:"fee" binding:"min=0,max=9999"`
+	Recurrence  RecurrenceRequest `json:"recurrence" binding:"required"`
+}
+
+// RecurrenceRequest represents an RRULE-style recurrence rule in requests
+type RecurrenceRequest struct {
+	Frequency  string   `json:"frequency" binding:"required,oneof=weekly biweekly monthly"`
+	Weekdays   []int    `json:"weekdays" binding:"omitempty,dive,min=0,max=6"`
+	MonthDay   int      `json:"month_day" binding:"omitempty,min=1,max=31"`
+	StartsOn   string   `json:"starts_on" binding:"required"`
+	EndsOn     string   `json:"ends_on"`
+	Exceptions []string `json:"exceptions"`
+}
+
+// UpdateSeriesOccurrenceRequest represents the request body for editing a series occurrence
+type UpdateSeriesOccurrenceRequest struct {
+	Scope       string  `json:"scope" binding:"required,oneof=this future"`
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	StartTime   *string `json:"start_time"`
+	EndTime     *string `json:"end_time"`
+	Capacity    *int    `json:"capacity" binding:"omitempty,min=4,max=20"`
+	SkillLevel  *string `json:"skill_level" binding:"omitempty,oneof=beginner intermediate advanced expert any"`
+	Fee         *int    `json:"fee" binding:"omitempty,min=0,max=9999"`
+	// CapacityPolicy decides what happens when capacity drops below the players
+	// already confirmed: refuse (default) or demote the latest to the waitlist
+	CapacityPolicy *string `json:"capacity_policy" binding:"omitempty,oneof=refuse demote"`
+}
+
+// ListNotificationsQuery represents query parameters for listing notifications
+type ListNotificationsQuery struct {
+	Type   string `form:"type"` // comma-separated notification types
+	Unread bool   `form:"unread"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor string `form:"cursor"`
+}
+
+// UpdateNotificationPreferencesRequest represents the request body for updating
+// notification preferences. Omitted fields are left unchanged.
+type UpdateNotificationPreferencesRequest struct {
+	Timezone    *string                         `json:"timezone"`
+	QuietHours  *QuietHoursRequest              `json:"quiet_hours"`
+	Digest      *string                         `json:"digest" binding:"omitempty,oneof=off daily weekly"`
+	Preferences []NotificationPreferenceRequest `json:"preferences" binding:"dive"`
+}
+
+// QuietHoursRequest represents quiet hours in requests
+type QuietHoursRequest struct {
+	Enabled bool   `json:"enabled"`
+	Start   string `json:"start"` // HH:MM
+	End     string `json:"end"`   // HH:MM
+}
+
+// NotificationPreferenceRequest turns one notification type on or off for one channel
+type NotificationPreferenceRequest struct {
+	Type    string `json:"type" binding:"required"`
+	Channel string `json:"channel" binding:"required,oneof=in_app line"`
+	Enabled bool   `json:"enabled"`
+}
+
+// CheckInRequest represents the request body for checking a player in. The host
+// either scans the player's QR code or picks them from the list.
+type CheckInRequest struct {
+	Token          string `json:"token"`
+	RegistrationID string `json:"registration_id"`
+}
+
+// MarkAttendanceRequest represents the request body for marking who attended an event
+type MarkAttendanceRequest struct {
+	Attendance []AttendanceRequest `json:"attendance" binding:"required,min=1,dive"`
+}
+
+// AttendanceRequest marks one confirmed player as attended or no-show
+type AttendanceRequest struct {
+	RegistrationID string `json:"registration_id" binding:"required"`
+	Status         string `json:"status" binding:"required,oneof=attended no_show"`
+}
+
+// RemoveRegistrationRequest represents the optional request body for removing a player from an event
+type RemoveRegistrationRequest struct {
+	Reason string `json:"reason" binding:"max=200"`
+}
+
+// MoveWaitlistRequest represents the request body for moving a player within the waitlist
+type MoveWaitlistRequest struct {
+	Position int `json:"position" binding:"required,min=1"`
+}
+
+// AssignEventRoleRequest represents the request body for inviting someone to help run an event
+type AssignEventRoleRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+	Role   string `json:"role" binding:"required,oneof=co_host check_in_helper"`
+}
+
+// BlockUserRequest represents the request body for adding a player to the host's block list
+type BlockUserRequest struct {
+	UserID              string `json:"user_id" binding:"required,uuid"`
+	Reason              string `json:"reason" binding:"max=200"`
+	CancelRegistrations bool   `json:"cancel_registrations"`
+}
+
+// CreateClubRequest represents the request body for creating a club
+type CreateClubRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=1000"`
+}
+
+// UpdateClubRequest represents the request body for updating a club
+type UpdateClubRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Description *string `json:"description" binding:"omitempty,max=1000"`
+}
+
+// AddClubMemberRequest represents the request body for inviting a player to a
+// club or approving their join request
+type AddClubMemberRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+	Role   string `json:"role" binding:"omitempty,oneof=admin member"`
+}
+
+// UpdateClubMemberRequest represents the request body for changing a member's role
+type UpdateClubMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=owner admin member"`
+}
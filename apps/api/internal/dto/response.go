@@ -1,6 +1,10 @@
 package dto
 
-import "github.com/anthropics/pickle-go/apps/api/internal/model"
+import (
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+)
 
 // APIResponse represents a standard API response
 type APIResponse struct {
@@ -44,10 +48,11 @@ type AuthResponse struct {
 
 // UserResponse represents a user in API responses
 type UserResponse struct {
-	ID          string  `json:"id"`
-	DisplayName string  `json:"display_name"`
-	AvatarURL   *string `json:"avatar_url,omitempty"`
-	Email       *string `json:"email,omitempty"`
+	ID          string               `json:"id"`
+	DisplayName string               `json:"display_name"`
+	AvatarURL   *string              `json:"avatar_url,omitempty"`
+	Email       *string              `json:"email,omitempty"`
+	Reliability *ReliabilityResponse `json:"reliability,omitempty"`
 }
 
 // FromUser converts a model.User to UserResponse
@@ -77,6 +82,17 @@ type EventResponse struct {
 	SkillLevelLabel string          `json:"skill_level_label"`
 	Fee            int              `json:"fee"`
 	Status         string           `json:"status"`
+	MinReliability *int             `json:"min_reliability,omitempty"`
+	CancellationPolicy *CancellationPolicyResponse `json:"cancellation_policy,omitempty"`
+	TransferRequiresApproval bool   `json:"transfer_requires_approval"`
+	MaxGuests                int    `json:"max_guests"`
+	RequiresApproval         bool   `json:"requires_approval"`
+	Visibility               string `json:"visibility"`
+	ClubID                   *string `json:"club_id,omitempty"`
+	RegistrationAccess       string  `json:"registration_access"`
+	MemberPriority           *MemberPriorityResponse `json:"member_priority,omitempty"`
+	// CoHosts lists the co-hosts who have accepted; only set on single-event responses
+	CoHosts []model.UserProfile `json:"co_hosts,omitempty"`
 }
 
 // LocationResponse represents location data in responses
@@ -97,11 +113,29 @@ type EventListResponse struct {
 
 // RegistrationResponse represents a registration in API responses
 type RegistrationResponse struct {
-	ID               string  `json:"id"`
-	EventID          string  `json:"event_id"`
-	Status           string  `json:"status"`
-	WaitlistPosition *int    `json:"waitlist_position,omitempty"`
-	Message          string  `json:"message"`
+	ID               string        `json:"id"`
+	EventID          string        `json:"event_id"`
+	Status           string        `json:"status"`
+	WaitlistPosition *int          `json:"waitlist_position,omitempty"`
+	OfferExpiresAt   *string       `json:"offer_expires_at,omitempty"`
+	Guests           []model.Guest `json:"guests,omitempty"`
+	Message          string        `json:"message"`
+}
+
+// GroupRegistrationResponse represents a group registration in API responses
+type GroupRegistrationResponse struct {
+	GroupID          string                `json:"group_id"`
+	EventID          string                `json:"event_id"`
+	Status           string                `json:"status"`
+	WaitlistPosition *int                  `json:"waitlist_position,omitempty"`
+	Members          []GroupMemberResponse `json:"members"`
+	Message          string                `json:"message"`
+}
+
+// GroupMemberResponse represents one member's registration in a group registration
+type GroupMemberResponse struct {
+	RegistrationID string `json:"registration_id"`
+	UserID         string `json:"user_id"`
 }
 
 // CreateEventResponse represents the response for creating an event
@@ -109,3 +143,195 @@ type CreateEventResponse struct {
 	ID       string `json:"id"`
 	ShareURL string `json:"share_url"`
 }
+
+// SeriesResponse represents a recurring event series in API responses
+type SeriesResponse struct {
+	ID          string                     `json:"id"`
+	Title       *string                    `json:"title,omitempty"`
+	Description *string                    `json:"description,omitempty"`
+	StartTime   string                     `json:"start_time"`
+	EndTime     *string                    `json:"end_time,omitempty"`
+	Location    LocationResponse           `json:"location"`
+	Capacity    int                        `json:"capacity"`
+	SkillLevel  string                     `json:"skill_level"`
+	Fee         int                        `json:"fee"`
+	Frequency   string                     `json:"frequency"`
+	Weekdays    []int64                    `json:"weekdays"`
+	MonthDay    *int                       `json:"month_day,omitempty"`
+	StartsOn    string                     `json:"starts_on"`
+	EndsOn      *string                    `json:"ends_on,omitempty"`
+	Status      string                     `json:"status"`
+	Occurrences []SeriesOccurrenceResponse `json:"occurrences"`
+}
+
+// SeriesOccurrenceResponse represents a single occurrence of a series in API responses
+type SeriesOccurrenceResponse struct {
+	Date        string  `json:"date"`
+	EventID     *string `json:"event_id,omitempty"`
+	EventStatus *string `json:"event_status,omitempty"`
+	IsException bool    `json:"is_exception"`
+	IsDetached  bool    `json:"is_detached"`
+}
+
+// NotificationResponse represents a notification in API responses
+type NotificationResponse struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Title     string    `json:"title"`
+	Message   *string   `json:"message"`
+	EventID   *string   `json:"event_id"`
+	IsRead    bool      `json:"is_read"`
+	CreatedAt time.Time `json:"created_at"`
+	// DeliveredViaDigest is set once the notification was sent to LINE in a digest
+	DeliveredViaDigest bool `json:"delivered_via_digest"`
+}
+
+// NotificationListResponse represents a page of notifications
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	Total         int                    `json:"total"`
+	UnreadCount   int                    `json:"unread_count"`
+	HasMore       bool                   `json:"has_more"`
+	NextCursor    *string                `json:"next_cursor"`
+}
+
+// NotificationPreferencesResponse represents a user's notification preferences
+type NotificationPreferencesResponse struct {
+	Timezone   string                               `json:"timezone"`
+	QuietHours *QuietHoursResponse                  `json:"quiet_hours"`
+	Digest     string                               `json:"digest"`
+	Types      []NotificationTypePreferenceResponse `json:"types"`
+}
+
+// QuietHoursResponse represents quiet hours in responses
+type QuietHoursResponse struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// NotificationTypePreferenceResponse represents the channels one notification type is sent on
+type NotificationTypePreferenceResponse struct {
+	Type string `json:"type"`
+	// Urgent notifications are delivered during quiet hours
+	Urgent   bool            `json:"urgent"`
+	Channels map[string]bool `json:"channels"`
+}
+
+// EventStreamResponse represents the live state of an event sent on its stream
+type EventStreamResponse struct {
+	EventID        string `json:"event_id"`
+	Status         string `json:"status"`
+	Capacity       int    `json:"capacity"`
+	ConfirmedCount int    `json:"confirmed_count"`
+	WaitlistCount  int    `json:"waitlist_count"`
+}
+
+// RegistrationStreamResponse represents the live state of the current user's
+// registration sent on their stream
+type RegistrationStreamResponse struct {
+	EventID          string     `json:"event_id"`
+	Status           string     `json:"status"`
+	WaitlistPosition *int       `json:"waitlist_position"`
+	OfferExpiresAt   *time.Time `json:"offer_expires_at"`
+}
+
+// CheckInResponse represents a player's check-in
+type CheckInResponse struct {
+	RegistrationID string    `json:"registration_id"`
+	UserID         string    `json:"user_id"`
+	CheckedInAt    time.Time `json:"checked_in_at"`
+}
+
+// ReliabilityResponse represents how reliably a player turns up for spots they confirm
+type ReliabilityResponse struct {
+	Attended      int  `json:"attended"`
+	LateCancelled int  `json:"late_cancelled"`
+	NoShows       int  `json:"no_shows"`
+	Score         *int `json:"score"`
+}
+
+// FromReliability converts a model.Reliability to ReliabilityResponse
+func FromReliability(r model.Reliability) *ReliabilityResponse {
+	return &ReliabilityResponse{
+		Attended:      r.Attended,
+		LateCancelled: r.LateCancelled,
+		NoShows:       r.NoShows,
+		Score:         r.Score(),
+	}
+}
+
+// CancellationPolicyResponse represents when players can cancel without it counting as late
+type CancellationPolicyResponse struct {
+	DeadlineHours int       `json:"deadline_hours"`
+	Deadline      time.Time `json:"deadline"`
+}
+
+// FromCancellationPolicy converts an event's cancellation deadline to
+// CancellationPolicyResponse, or nil if the event has none. Event dates and
+// times are wall-clock times in loc.
+func FromCancellationPolicy(event *model.Event, loc *time.Location) *CancellationPolicyResponse {
+	deadline := event.CancelDeadline(loc)
+	if deadline == nil {
+		return nil
+	}
+	return &CancellationPolicyResponse{
+		DeadlineHours: *event.CancelDeadlineHours,
+		Deadline:      *deadline,
+	}
+}
+
+// MemberPriorityResponse represents when a members-first event opens to players
+// outside its club
+type MemberPriorityResponse struct {
+	Hours  int       `json:"hours"`
+	EndsAt time.Time `json:"ends_at"`
+}
+
+// FromMemberPriority converts an event's member priority window to
+// MemberPriorityResponse, or nil if the event has none. Event dates and times
+// are wall-clock times in loc.
+func FromMemberPriority(event *model.Event, loc *time.Location) *MemberPriorityResponse {
+	ends := event.MemberPriorityEnds(loc)
+	if ends == nil {
+		return nil
+	}
+	return &MemberPriorityResponse{
+		Hours:  *event.MemberPriorityHours,
+		EndsAt: *ends,
+	}
+}
+
+// ClubResponse represents a club page: the club, its upcoming events and the
+// viewer's membership, if any
+type ClubResponse struct {
+	Club           *model.Club       `json:"club"`
+	MemberCount    int               `json:"member_count"`
+	Membership     *model.ClubMember `json:"membership,omitempty"`
+	UpcomingEvents EventListResponse `json:"upcoming_events"`
+}
+
+// TransferLinkResponse represents a newly created spot transfer link. The link
+// is only returned once, to the player handing over their spot.
+type TransferLinkResponse struct {
+	ID               string    `json:"id"`
+	URL              string    `json:"url"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RequiresApproval bool      `json:"requires_approval"`
+}
+
+// InviteLinkResponse represents the invite link to an invite-only event
+type InviteLinkResponse struct {
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
+
+// TransferResponse represents a spot transfer as seen by the recipient
+type TransferResponse struct {
+	ID               string            `json:"id"`
+	EventID          string            `json:"event_id"`
+	FromUser         model.UserProfile `json:"from_user"`
+	Status           string            `json:"status"`
+	Expired          bool              `json:"expired"`
+	ExpiresAt        time.Time         `json:"expires_at"`
+	RequiresApproval bool              `json:"requires_approval"`
+}
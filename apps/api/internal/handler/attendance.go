@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/dto"
+	"github.com/anthropics/pickle-go/apps/api/internal/middleware"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// AttendanceHandler handles hosts recording who turned up to their events
+type AttendanceHandler struct {
+	registrationRepo *repository.RegistrationRepository
+	eventRepo        *repository.EventRepository
+	txManager        *database.TxManager
+	location         *time.Location
+}
+
+// NewAttendanceHandler creates a new AttendanceHandler. Event dates and times
+// are wall-clock times in location.
+func NewAttendanceHandler(registrationRepo *repository.RegistrationRepository, eventRepo *repository.EventRepository, txManager *database.TxManager, location *time.Location) *AttendanceHandler {
+	return &AttendanceHandler{
+		registrationRepo: registrationRepo,
+		eventRepo:        eventRepo,
+		txManager:        txManager,
+		location:         location,
+	}
+}
+
+// MarkAttendance marks confirmed players as attended or no-show once the event has started
+// PUT /api/v1/events/:id/attendance
+func (h *AttendanceHandler) MarkAttendance(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return
+	}
+
+	var req dto.MarkAttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	registrationIDs := make([]uuid.UUID, len(req.Attendance))
+	for i, item := range req.Attendance {
+		registrationIDs[i], err = uuid.Parse(item.RegistrationID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid registration ID"))
+			return
+		}
+	}
+
+	event, err := h.eventRepo.FindByID(c.Request.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch event"))
+		return
+	}
+	role, err := eventRole(c.Request.Context(), h.eventRepo, event, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to verify event role"))
+		return
+	}
+	if !role.CanCheckIn() {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "You are not the host of this event"))
+		return
+	}
+	if event.Status == model.EventStatusCancelled {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("EVENT_CANCELLED", "Event has been cancelled"))
+		return
+	}
+	if time.Now().Before(event.StartsAt(h.location)) {
+		c.JSON(http.StatusConflict, dto.ErrorResponse("EVENT_NOT_STARTED", "Attendance can be marked once the event has started"))
+		return
+	}
+
+	// Apply every mark or none
+	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		for i, item := range req.Attendance {
+			if err := h.registrationRepo.SetAttendanceTx(c.Request.Context(), tx, eventID, registrationIDs[i], model.Attendance(item.Status)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Confirmed registration not found for this event"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to mark attendance"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"message": "Attendance updated",
+		"updated": len(req.Attendance),
+	}))
+}
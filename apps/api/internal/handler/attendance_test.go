@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestMarkAttendance(t *testing.T) {
+	hostID, eventID, strangerID := uuid.New(), uuid.New(), uuid.New()
+	attendedID, noShowID := uuid.New(), uuid.New()
+	started := time.Now().UTC().Add(-2 * time.Hour).Truncate(time.Minute)
+
+	body := map[string]interface{}{
+		"attendance": []map[string]string{
+			{"registration_id": attendedID.String(), "status": "attended"},
+			{"registration_id": noShowID.String(), "status": "no_show"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		caller   uuid.UUID
+		start    time.Time
+		setup    func(mock sqlmock.Sqlmock)
+		wantCode int
+		wantErr  string
+	}{
+		{
+			name:   "marks every player",
+			caller: hostID,
+			start:  started,
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE registrations SET attendance").
+					WithArgs(eventID, attendedID, model.AttendanceAttended).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE registrations SET attendance").
+					WithArgs(eventID, noShowID, model.AttendanceNoShow).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "unknown registration rolls back",
+			caller: hostID,
+			start:  started,
+			setup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE registrations SET attendance").
+					WithArgs(eventID, attendedID, model.AttendanceAttended).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE registrations SET attendance").
+					WithArgs(eventID, noShowID, model.AttendanceNoShow).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantCode: http.StatusNotFound,
+			wantErr:  "NOT_FOUND",
+		},
+		{
+			name:   "not the host",
+			caller: strangerID,
+			start:  started,
+			setup: func(mock sqlmock.Sqlmock) {
+				expectEventRole(mock, eventID, strangerID, "")
+			},
+			wantCode: http.StatusForbidden,
+			wantErr:  "FORBIDDEN",
+		},
+		{
+			name:     "event not started",
+			caller:   hostID,
+			start:    time.Now().UTC().Add(48 * time.Hour),
+			wantCode: http.StatusConflict,
+			wantErr:  "EVENT_NOT_STARTED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			db := sqlx.NewDb(mockDB, "postgres")
+			defer db.Close()
+
+			h := NewAttendanceHandler(repository.NewRegistrationRepository(db), repository.NewEventRepository(db), database.NewTxManager(db), time.UTC)
+
+			now := time.Now()
+			mock.ExpectQuery("SELECT .* FROM events WHERE id").
+				WithArgs(eventID).
+				WillReturnRows(sqlmock.NewRows([]string{
+					"id", "host_id", "short_code", "title", "description", "event_date", "start_time", "end_time",
+					"location_name", "location_address", "latitude", "longitude", "google_place_id",
+					"capacity", "skill_level", "fee", "status", "created_at", "updated_at",
+				}).AddRow(
+					eventID, hostID, "abc123", nil, nil, tt.start, tt.start.Format("15:04"), nil,
+					"Test Location", nil, 25.033, 121.565, nil,
+					4, "beginner", 200, "open", now, now,
+				))
+			if tt.setup != nil {
+				tt.setup(mock)
+			}
+
+			router := gin.New()
+			router.PUT("/events/:id/attendance", createAuthContext(tt.caller.String(), "Host"), h.MarkAttendance)
+
+			req := httptest.NewRequest(http.MethodPut, "/events/"+eventID.String()+"/attendance", jsonBody(body))
+			req.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, recorder.Code, recorder.Body.String())
+			}
+			if tt.wantErr != "" {
+				if code := parseResponse(t, recorder).Error.Code; code != tt.wantErr {
+					t.Errorf("expected error %s, got %s", tt.wantErr, code)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/anthropics/pickle-go/apps/api/internal/middleware"
 	"github.com/anthropics/pickle-go/apps/api/internal/model"
 	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/internal/service"
 	"github.com/anthropics/pickle-go/apps/api/pkg/jwt"
 	"github.com/anthropics/pickle-go/apps/api/pkg/line"
 	"github.com/gin-gonic/gin"
@@ -24,15 +25,17 @@ import (
 
 // AuthHandler handles authentication related requests
 type AuthHandler struct {
-	userRepo   *repository.UserRepository
-	lineClient *line.Client
+	userRepo           *repository.UserRepository
+	lineClient         *line.Client
+	reliabilityService *service.ReliabilityService
 }
 
 // NewAuthHandler creates a new AuthHandler
-func NewAuthHandler(userRepo *repository.UserRepository, lineClient *line.Client) *AuthHandler {
+func NewAuthHandler(userRepo *repository.UserRepository, lineClient *line.Client, reliabilityService *service.ReliabilityService) *AuthHandler {
 	return &AuthHandler{
-		userRepo:   userRepo,
-		lineClient: lineClient,
+		userRepo:           userRepo,
+		lineClient:         lineClient,
+		reliabilityService: reliabilityService,
 	}
 }
 
@@ -176,6 +179,12 @@ func (h *AuthHandler) LineCallback(c *gin.Context) {
 		return
 	}
 
+	// Record whether the user can receive LINE push notifications; the webhook
+	// keeps this up to date afterwards
+	if friend, err := h.lineClient.GetFriendshipStatus(c.Request.Context(), tokenResp.AccessToken); err == nil {
+		h.userRepo.SetLineFriend(c.Request.Context(), profile.UserID, friend)
+	}
+
 	// Generate JWT tokens
 	accessToken, err := jwt.GenerateToken(user.ID.String(), user.DisplayName)
 	if err != nil {
@@ -283,7 +292,15 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse(dto.FromUser(user)))
+	reliability, err := h.reliabilityService.ForUser(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to get reliability"))
+		return
+	}
+
+	response := dto.FromUser(user)
+	response.Reliability = dto.FromReliability(reliability)
+	c.JSON(http.StatusOK, dto.SuccessResponse(response))
 }
 
 // Legacy handlers for backward compatibility (these will be replaced by injected handlers)
@@ -304,7 +304,7 @@ func TestLineCallback_Success(t *testing.T) {
 		ChannelSecret: "test-secret",
 		RedirectURI:   "http://localhost/callback",
 	})
-	handler := NewAuthHandler(nil, lineClient)
+	handler := NewAuthHandler(nil, lineClient, nil)
 
 	// Override with mock behavior using a custom test handler
 	w := httptest.NewRecorder()
@@ -332,7 +332,7 @@ func TestLineCallback_InvalidRequestBody(t *testing.T) {
 		ChannelSecret: "test-secret",
 		RedirectURI:   "http://localhost/callback",
 	})
-	handler := NewAuthHandler(nil, lineClient)
+	handler := NewAuthHandler(nil, lineClient, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -363,7 +363,7 @@ func TestLineCallback_MissingCode(t *testing.T) {
 		ChannelSecret: "test-secret",
 		RedirectURI:   "http://localhost/callback",
 	})
-	handler := NewAuthHandler(nil, lineClient)
+	handler := NewAuthHandler(nil, lineClient, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -389,7 +389,7 @@ func TestLineCallback_InvalidState(t *testing.T) {
 		ChannelSecret: "test-secret",
 		RedirectURI:   "http://localhost/callback",
 	})
-	handler := NewAuthHandler(nil, lineClient)
+	handler := NewAuthHandler(nil, lineClient, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -421,7 +421,7 @@ func TestLineCallback_ExpiredState(t *testing.T) {
 		ChannelSecret: "test-secret",
 		RedirectURI:   "http://localhost/callback",
 	})
-	handler := NewAuthHandler(nil, lineClient)
+	handler := NewAuthHandler(nil, lineClient, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -452,7 +452,7 @@ func TestLineCallback_ExpiredState(t *testing.T) {
 // =============================================================================
 
 func TestRefreshToken_InvalidRequestBody(t *testing.T) {
-	handler := NewAuthHandler(nil, nil)
+	handler := NewAuthHandler(nil, nil, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -475,7 +475,7 @@ func TestRefreshToken_InvalidRequestBody(t *testing.T) {
 }
 
 func TestRefreshToken_MissingToken(t *testing.T) {
-	handler := NewAuthHandler(nil, nil)
+	handler := NewAuthHandler(nil, nil, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -494,7 +494,7 @@ func TestRefreshToken_MissingToken(t *testing.T) {
 }
 
 func TestRefreshToken_InvalidToken(t *testing.T) {
-	handler := NewAuthHandler(nil, nil)
+	handler := NewAuthHandler(nil, nil, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -539,7 +539,7 @@ func TestRefreshToken_ExpiredToken(t *testing.T) {
 	token := jwtPkg.NewWithClaims(jwtPkg.SigningMethodHS256, claims)
 	expiredToken, _ := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
 
-	handler := NewAuthHandler(nil, nil)
+	handler := NewAuthHandler(nil, nil, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -570,7 +570,7 @@ func TestRefreshToken_InvalidUserID(t *testing.T) {
 	// Generate a refresh token with invalid UUID
 	refreshToken, _ := jwt.GenerateRefreshToken("not-a-valid-uuid")
 
-	handler := NewAuthHandler(nil, nil)
+	handler := NewAuthHandler(nil, nil, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -600,7 +600,7 @@ func TestRefreshToken_InvalidUserID(t *testing.T) {
 // =============================================================================
 
 func TestGetCurrentUser_NotAuthenticated(t *testing.T) {
-	handler := NewAuthHandler(nil, nil)
+	handler := NewAuthHandler(nil, nil, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -620,7 +620,7 @@ func TestGetCurrentUser_NotAuthenticated(t *testing.T) {
 }
 
 func TestGetCurrentUser_InvalidUserIDInToken(t *testing.T) {
-	handler := NewAuthHandler(nil, nil)
+	handler := NewAuthHandler(nil, nil, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -651,7 +651,7 @@ func TestGetCurrentUser_InvalidUserIDInToken(t *testing.T) {
 // =============================================================================
 
 func TestLogout_Success(t *testing.T) {
-	handler := NewAuthHandler(nil, nil)
+	handler := NewAuthHandler(nil, nil, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -964,7 +964,7 @@ func TestLineCallback_EmptyBody(t *testing.T) {
 		ChannelSecret: "test-secret",
 		RedirectURI:   "http://localhost/callback",
 	})
-	handler := NewAuthHandler(nil, lineClient)
+	handler := NewAuthHandler(nil, lineClient, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -981,7 +981,7 @@ func TestLineCallback_EmptyBody(t *testing.T) {
 }
 
 func TestRefreshToken_MalformedJWT(t *testing.T) {
-	handler := NewAuthHandler(nil, nil)
+	handler := NewAuthHandler(nil, nil, nil)
 
 	testCases := []struct {
 		name  string
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/dto"
+	"github.com/anthropics/pickle-go/apps/api/internal/middleware"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// BlockHandler handles the host's block list
+type BlockHandler struct {
+	blockRepo        *repository.BlockRepository
+	userRepo         *repository.UserRepository
+	eventRepo        *repository.EventRepository
+	registrationRepo *repository.RegistrationRepository
+	outboxRepo       *repository.OutboxRepository
+	txManager        *database.TxManager
+	offerWindow      time.Duration
+}
+
+// NewBlockHandler creates a new BlockHandler. offerWindow is how long seats freed
+// by cancelling a blocked user's registrations are held for the next waitlisted user.
+func NewBlockHandler(blockRepo *repository.BlockRepository, userRepo *repository.UserRepository, eventRepo *repository.EventRepository, registrationRepo *repository.RegistrationRepository, outboxRepo *repository.OutboxRepository, txManager *database.TxManager, offerWindow time.Duration) *BlockHandler {
+	return &BlockHandler{
+		blockRepo:        blockRepo,
+		userRepo:         userRepo,
+		eventRepo:        eventRepo,
+		registrationRepo: registrationRepo,
+		outboxRepo:       outboxRepo,
+		txManager:        txManager,
+		offerWindow:      offerWindow,
+	}
+}
+
+// BlockUser stops a player from registering for any of the current user's events,
+// optionally taking them off the upcoming events they are already registered for
+// POST /api/v1/users/me/blocks
+func (h *BlockHandler) BlockUser(c *gin.Context) {
+	hostID, ok := h.authUser(c)
+	if !ok {
+		return
+	}
+
+	var req dto.BlockUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid user ID"))
+		return
+	}
+	if userID == hostID {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "You cannot block yourself"))
+		return
+	}
+
+	if _, err := h.userRepo.FindByID(c.Request.Context(), userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("USER_NOT_FOUND", "User not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch user"))
+		return
+	}
+
+	block := &model.HostBlock{HostID: hostID, UserID: userID}
+	if reason := strings.TrimSpace(req.Reason); reason != "" {
+		block.Reason = &reason
+	}
+
+	cancelled := 0
+	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		if txErr := h.blockRepo.BlockTx(c.Request.Context(), tx, block); txErr != nil {
+			return txErr
+		}
+		if !req.CancelRegistrations {
+			return nil
+		}
+
+		registrations, txErr := h.registrationRepo.FindUpcomingByHostTx(c.Request.Context(), tx, hostID, userID)
+		if txErr != nil {
+			return txErr
+		}
+		for _, reg := range registrations {
+			_, promoted, txErr := h.registrationRepo.RemoveWithLock(c.Request.Context(), tx, reg.EventID, reg.ID, h.offerWindow)
+			if errors.Is(txErr, repository.ErrAlreadyCancelled) || errors.Is(txErr, repository.ErrEventNotOpen) {
+				// Cancelled or closed since it was looked up
+				continue
+			}
+			if txErr != nil {
+				return txErr
+			}
+			event, txErr := h.eventRepo.FindByIDTx(c.Request.Context(), tx, reg.EventID)
+			if txErr != nil {
+				return txErr
+			}
+			// The block reason is the host's private note, so it is not passed on
+			txErr = h.outboxRepo.EnqueueNotificationsTx(c.Request.Context(), tx,
+				model.NewRemovedByHostNotification(userID, reg.EventID, event.GetNotificationTitle(), ""))
+			if txErr != nil {
+				return txErr
+			}
+			if txErr := notifyPromotedTx(c.Request.Context(), tx, h.eventRepo, h.outboxRepo, reg.EventID, promoted); txErr != nil {
+				return txErr
+			}
+			cancelled++
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to block user"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(gin.H{
+		"block":                   block,
+		"cancelled_registrations": cancelled,
+	}))
+}
+
+// ListBlocks returns the players the current user has blocked
+// GET /api/v1/users/me/blocks
+func (h *BlockHandler) ListBlocks(c *gin.Context) {
+	hostID, ok := h.authUser(c)
+	if !ok {
+		return
+	}
+
+	blocks, err := h.blockRepo.FindByHostID(c.Request.Context(), hostID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch blocked users"))
+		return
+	}
+	if blocks == nil {
+		blocks = []model.HostBlockWithUser{}
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"blocks": blocks,
+	}))
+}
+
+// UnblockUser lets a blocked player register for the current user's events again.
+// Registrations cancelled by the block are not restored.
+// DELETE /api/v1/users/me/blocks/:userId
+func (h *BlockHandler) UnblockUser(c *gin.Context) {
+	hostID, ok := h.authUser(c)
+	if !ok {
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid user ID"))
+		return
+	}
+
+	if err := h.blockRepo.Unblock(c.Request.Context(), hostID, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "User is not blocked"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to unblock user"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"message": "User unblocked",
+	}))
+}
+
+// authUser reads the authenticated user, writing the error response if they are
+// missing or invalid
+func (h *BlockHandler) authUser(c *gin.Context) (uuid.UUID, bool) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return uuid.Nil, false
+	}
+	return userID, true
+}
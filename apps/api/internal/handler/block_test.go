@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// blockTestContext holds the block handler and its mocked database
+type blockTestContext struct {
+	handler *BlockHandler
+	mock    sqlmock.Sqlmock
+	db      *sqlx.DB
+}
+
+func setupBlockTestContext(t *testing.T) *blockTestContext {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+
+	db := sqlx.NewDb(mockDB, "postgres")
+	return &blockTestContext{
+		handler: NewBlockHandler(repository.NewBlockRepository(db), repository.NewUserRepository(db), repository.NewEventRepository(db),
+			repository.NewRegistrationRepository(db), repository.NewOutboxRepository(db), database.NewTxManager(db), 0),
+		mock: mock,
+		db:   db,
+	}
+}
+
+// expectNotBlocked mocks the host block check passing
+func expectNotBlocked(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("FROM host_blocks").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+}
+
+func (tc *blockTestContext) serve(method, path, route string, userID uuid.UUID, body interface{}, handle gin.HandlerFunc) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.Handle(method, route, createAuthContext(userID.String(), "Host"), handle)
+
+	req := httptest.NewRequest(method, path, jsonBody(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestBlockUser_CancelsUpcomingRegistrations(t *testing.T) {
+	tc := setupBlockTestContext(t)
+	defer tc.db.Close()
+
+	hostID, userID, eventID, regID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+
+	tc.mock.ExpectQuery("SELECT .* FROM users WHERE id").
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "line_user_id", "display_name"}).AddRow(userID, "U1", "Player"))
+	tc.mock.ExpectBegin()
+	tc.mock.ExpectQuery("INSERT INTO host_blocks .* ON CONFLICT").
+		WithArgs(hostID, userID, "No-show twice").
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(now))
+	tc.mock.ExpectQuery("SELECT r.\\* FROM registrations r").
+		WithArgs(hostID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status", "waitlist_position"}).
+			AddRow(regID, eventID, userID, model.RegistrationWaitlist, 1))
+	tc.mock.ExpectQuery("SELECT capacity, status FROM events WHERE id = .* FOR UPDATE").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(4, "full"))
+	tc.mock.ExpectQuery("SELECT \\* FROM registrations WHERE id = .* AND event_id = .* FOR UPDATE").
+		WithArgs(regID, eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status", "waitlist_position"}).
+			AddRow(regID, eventID, userID, model.RegistrationWaitlist, 1))
+	tc.mock.ExpectExec("UPDATE registrations SET status = 'cancelled'").
+		WithArgs(regID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	tc.mock.ExpectExec("SET waitlist_position = waitlist_position - 1").
+		WithArgs(eventID, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	tc.mock.ExpectQuery("SELECT .* FROM events WHERE id").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "host_id", "title", "event_date", "start_time", "status"}).
+			AddRow(eventID, hostID, nil, now.Add(24*time.Hour), "19:00", "full"))
+	tc.mock.ExpectExec("INSERT INTO outbox").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	tc.mock.ExpectCommit()
+
+	body := map[string]interface{}{"user_id": userID.String(), "reason": "No-show twice", "cancel_registrations": true}
+	recorder := tc.serve(http.MethodPost, "/users/me/blocks", "/users/me/blocks", hostID, body, tc.handler.BlockUser)
+
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, recorder.Code, recorder.Body.String())
+	}
+	data := parseResponse(t, recorder).Data.(map[string]interface{})
+	if data["cancelled_registrations"] != float64(1) {
+		t.Errorf("expected 1 cancelled registration, got %v", data["cancelled_registrations"])
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestBlockUser_Self(t *testing.T) {
+	tc := setupBlockTestContext(t)
+	defer tc.db.Close()
+
+	hostID := uuid.New()
+	body := map[string]string{"user_id": hostID.String()}
+	recorder := tc.serve(http.MethodPost, "/users/me/blocks", "/users/me/blocks", hostID, body, tc.handler.BlockUser)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d", http.StatusBadRequest, recorder.Code)
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUnblockUser_NotBlocked(t *testing.T) {
+	tc := setupBlockTestContext(t)
+	defer tc.db.Close()
+
+	hostID, userID := uuid.New(), uuid.New()
+	tc.mock.ExpectExec("DELETE FROM host_blocks").
+		WithArgs(hostID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	recorder := tc.serve(http.MethodDelete, "/users/me/blocks/"+userID.String(), "/users/me/blocks/:userId", hostID, nil, tc.handler.UnblockUser)
+
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d", http.StatusNotFound, recorder.Code)
+	}
+}
+
+func TestRegisterEvent_BlockedByHost(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	userID, eventID, hostID := uuid.New(), uuid.New(), uuid.New()
+
+	tc.expectVisibleEvent(eventID, hostID, model.VisibilityPublic)
+	tc.mock.ExpectBegin()
+	tc.mock.ExpectQuery("SELECT capacity, status, host_id FROM events WHERE id = .* FOR UPDATE").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "status", "host_id"}).AddRow(8, "open", hostID))
+	tc.mock.ExpectQuery("FROM host_blocks").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	tc.mock.ExpectRollback()
+
+	tc.router.POST("/events/:id/register", createAuthContext(userID.String(), "Test User"), tc.handler.RegisterEvent)
+
+	req := httptest.NewRequest(http.MethodPost, "/events/"+eventID.String()+"/register", nil)
+	recorder := httptest.NewRecorder()
+	tc.router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusForbidden, recorder.Code, recorder.Body.String())
+	}
+	if code := parseResponse(t, recorder).Error.Code; code != "BLOCKED" {
+		t.Errorf("expected BLOCKED, got %s", code)
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/dto"
+	"github.com/anthropics/pickle-go/apps/api/internal/middleware"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/pkg/checkin"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// checkInQRSize is the width and height of PNG check-in codes in pixels
+const checkInQRSize = 320
+
+// CheckInHandler handles attendance check-in at the court
+type CheckInHandler struct {
+	registrationRepo *repository.RegistrationRepository
+	eventRepo        *repository.EventRepository
+	signer           *checkin.Signer
+	window           model.CheckInWindow
+	location         *time.Location
+}
+
+// NewCheckInHandler creates a new CheckInHandler. Event dates and times are
+// wall-clock times in location.
+func NewCheckInHandler(registrationRepo *repository.RegistrationRepository, eventRepo *repository.EventRepository, signer *checkin.Signer, window model.CheckInWindow, location *time.Location) *CheckInHandler {
+	return &CheckInHandler{
+		registrationRepo: registrationRepo,
+		eventRepo:        eventRepo,
+		signer:           signer,
+		window:           window,
+		location:         location,
+	}
+}
+
+// GetCheckInCode returns the current user's check-in QR code for an event
+// GET /api/v1/events/:id/check-in/code?format=png|svg
+func (h *CheckInHandler) GetCheckInCode(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return
+	}
+
+	format := c.DefaultQuery("format", "png")
+	if format != "png" && format != "svg" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Format must be png or svg"))
+		return
+	}
+
+	reg, err := h.registrationRepo.FindByEventAndUser(c.Request.Context(), eventID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Registration not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch registration"))
+		return
+	}
+	if reg.Status != model.RegistrationConfirmed {
+		c.JSON(http.StatusConflict, dto.ErrorResponse("NOT_CONFIRMED", "Only confirmed players can check in"))
+		return
+	}
+
+	token := h.signer.Sign(reg.ID)
+	var image []byte
+	var contentType string
+	if format == "svg" {
+		image, err = checkin.QRCodeSVG(token)
+		contentType = "image/svg+xml"
+	} else {
+		image, err = checkin.QRCodePNG(token, checkInQRSize)
+		contentType = "image/png"
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to render check-in code"))
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	c.Data(http.StatusOK, contentType, image)
+}
+
+// CheckIn marks a confirmed player as arrived, from their scanned QR code or
+// the host tapping their name
+// POST /api/v1/events/:id/check-in
+func (h *CheckInHandler) CheckIn(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return
+	}
+
+	var req dto.CheckInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+	if (req.Token == "") == (req.RegistrationID == "") {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Provide either token or registration_id"))
+		return
+	}
+
+	var registrationID uuid.UUID
+	if req.Token != "" {
+		registrationID, err = h.signer.Verify(req.Token)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("INVALID_CHECK_IN_CODE", "Invalid check-in code"))
+			return
+		}
+	} else {
+		registrationID, err = uuid.Parse(req.RegistrationID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid registration ID"))
+			return
+		}
+	}
+
+	if !h.authorizeCheckIn(c, eventID, userID) {
+		return
+	}
+
+	reg, err := h.registrationRepo.FindByID(c.Request.Context(), registrationID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch registration"))
+		return
+	}
+	if reg == nil || reg.EventID != eventID {
+		// A valid code for another event was scanned
+		c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Registration not found for this event"))
+		return
+	}
+
+	checkedInAt, err := h.registrationRepo.CheckIn(c.Request.Context(), reg.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusConflict, dto.ErrorResponse("NOT_CONFIRMED", "Only confirmed players can check in"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to check in"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.CheckInResponse{
+		RegistrationID: reg.ID.String(),
+		UserID:         reg.UserID.String(),
+		CheckedInAt:    checkedInAt,
+	}))
+}
+
+// UndoCheckIn clears a check-in made by mistake
+// DELETE /api/v1/events/:id/check-in/:registrationId
+func (h *CheckInHandler) UndoCheckIn(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return
+	}
+
+	registrationID, err := uuid.Parse(c.Param("registrationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid registration ID"))
+		return
+	}
+
+	if !h.authorizeCheckIn(c, eventID, userID) {
+		return
+	}
+
+	reg, err := h.registrationRepo.FindByID(c.Request.Context(), registrationID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch registration"))
+		return
+	}
+	if reg == nil || reg.EventID != eventID {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Registration not found for this event"))
+		return
+	}
+
+	if err := h.registrationRepo.UndoCheckIn(c.Request.Context(), reg.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to undo check-in"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"message": "Check-in undone",
+	}))
+}
+
+// authorizeCheckIn checks the user may check players in for the event and
+// check-in is open, writing the error response if not
+func (h *CheckInHandler) authorizeCheckIn(c *gin.Context, eventID, userID uuid.UUID) bool {
+	event, err := h.eventRepo.FindByID(c.Request.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch event"))
+		return false
+	}
+	role, err := eventRole(c.Request.Context(), h.eventRepo, event, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to verify event role"))
+		return false
+	}
+	if !role.CanCheckIn() {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "Only the host and check-in helpers can check players in"))
+		return false
+	}
+	if event.Status == model.EventStatusCancelled {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("EVENT_CANCELLED", "Event has been cancelled"))
+		return false
+	}
+	if !h.window.IsOpen(event.StartsAt(h.location), time.Now()) {
+		c.JSON(http.StatusConflict, dto.ErrorResponse("CHECK_IN_CLOSED", "Check-in is not open for this event"))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/pkg/checkin"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// checkInTestContext holds the check-in handler and its mocked database
+type checkInTestContext struct {
+	handler *CheckInHandler
+	signer  *checkin.Signer
+	mock    sqlmock.Sqlmock
+	db      *sqlx.DB
+}
+
+func setupCheckInTestContext(t *testing.T) *checkInTestContext {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+
+	db := sqlx.NewDb(mockDB, "postgres")
+	signer := checkin.NewSigner("test-secret")
+	window := model.CheckInWindow{OpensBefore: time.Hour, ClosesAfter: 3 * time.Hour}
+
+	return &checkInTestContext{
+		handler: NewCheckInHandler(repository.NewRegistrationRepository(db), repository.NewEventRepository(db), signer, window, time.UTC),
+		signer:  signer,
+		mock:    mock,
+		db:      db,
+	}
+}
+
+// expectCheckInEvent mocks loading an event starting at start
+func (tc *checkInTestContext) expectCheckInEvent(eventID, hostID uuid.UUID, start time.Time) {
+	now := time.Now()
+	tc.mock.ExpectQuery("SELECT .* FROM events WHERE id").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "host_id", "short_code", "title", "description", "event_date", "start_time", "end_time",
+			"location_name", "location_address", "latitude", "longitude", "google_place_id",
+			"capacity", "skill_level", "fee", "status", "created_at", "updated_at",
+		}).AddRow(
+			eventID, hostID, "abc123", nil, nil, start, start.Format("15:04"), nil,
+			"Test Location", nil, 25.033, 121.565, nil,
+			4, "beginner", 200, "open", now, now,
+		))
+}
+
+// expectCheckInRegistration mocks loading a registration by ID
+func (tc *checkInTestContext) expectCheckInRegistration(regID, eventID, userID uuid.UUID, status model.RegistrationStatus) {
+	tc.mock.ExpectQuery("SELECT \\* FROM registrations WHERE id").
+		WithArgs(regID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "event_id", "user_id", "status", "waitlist_position", "registered_at",
+			"confirmed_at", "cancelled_at", "offer_expires_at", "checked_in_at",
+		}).AddRow(regID, eventID, userID, status, nil, time.Now(), nil, nil, nil, nil))
+}
+
+func (tc *checkInTestContext) checkIn(hostID, eventID uuid.UUID, body interface{}) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.POST("/events/:id/check-in", createAuthContext(hostID.String(), "Host"), tc.handler.CheckIn)
+
+	req := httptest.NewRequest(http.MethodPost, "/events/"+eventID.String()+"/check-in", jsonBody(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestCheckIn_WithToken(t *testing.T) {
+	tc := setupCheckInTestContext(t)
+	defer tc.db.Close()
+
+	hostID, eventID, regID, playerID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	tc.expectCheckInEvent(eventID, hostID, time.Now().UTC().Truncate(time.Minute))
+	tc.expectCheckInRegistration(regID, eventID, playerID, model.RegistrationConfirmed)
+	tc.mock.ExpectQuery("UPDATE registrations").
+		WithArgs(regID).
+		WillReturnRows(sqlmock.NewRows([]string{"checked_in_at"}).AddRow(time.Now()))
+
+	recorder := tc.checkIn(hostID, eventID, map[string]string{"token": tc.signer.Sign(regID)})
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	data := parseResponse(t, recorder).Data.(map[string]interface{})
+	if data["user_id"] != playerID.String() {
+		t.Errorf("expected user_id %s, got %v", playerID, data["user_id"])
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCheckIn_WithRegistrationID(t *testing.T) {
+	tc := setupCheckInTestContext(t)
+	defer tc.db.Close()
+
+	hostID, eventID, regID := uuid.New(), uuid.New(), uuid.New()
+	tc.expectCheckInEvent(eventID, hostID, time.Now().UTC().Add(30*time.Minute))
+	tc.expectCheckInRegistration(regID, eventID, uuid.New(), model.RegistrationConfirmed)
+	tc.mock.ExpectQuery("UPDATE registrations").
+		WithArgs(regID).
+		WillReturnRows(sqlmock.NewRows([]string{"checked_in_at"}).AddRow(time.Now()))
+
+	recorder := tc.checkIn(hostID, eventID, map[string]string{"registration_id": regID.String()})
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestCheckIn_Rejected(t *testing.T) {
+	hostID, eventID, regID := uuid.New(), uuid.New(), uuid.New()
+	strangerID, helperID := uuid.New(), uuid.New()
+	now := time.Now().UTC().Truncate(time.Minute)
+
+	tests := []struct {
+		name     string
+		caller   uuid.UUID
+		start    time.Time
+		body     map[string]string
+		setup    func(tc *checkInTestContext)
+		wantCode int
+		wantErr  string
+	}{
+		{
+			name:   "not the host",
+			caller: strangerID,
+			start:  now,
+			body:   map[string]string{"registration_id": regID.String()},
+			setup: func(tc *checkInTestContext) {
+				expectEventRole(tc.mock, eventID, strangerID, "")
+			},
+			wantCode: http.StatusForbidden,
+			wantErr:  "FORBIDDEN",
+		},
+		{
+			name:   "check-in helper reaches the registration",
+			caller: helperID,
+			start:  now,
+			body:   map[string]string{"registration_id": regID.String()},
+			setup: func(tc *checkInTestContext) {
+				expectEventRole(tc.mock, eventID, helperID, model.RoleCheckInHelper)
+				tc.expectCheckInRegistration(regID, uuid.New(), uuid.New(), model.RegistrationConfirmed)
+			},
+			wantCode: http.StatusNotFound,
+			wantErr:  "NOT_FOUND",
+		},
+		{
+			name:     "window not open yet",
+			caller:   hostID,
+			start:    now.Add(48 * time.Hour),
+			body:     map[string]string{"registration_id": regID.String()},
+			wantCode: http.StatusConflict,
+			wantErr:  "CHECK_IN_CLOSED",
+		},
+		{
+			name:     "window closed",
+			caller:   hostID,
+			start:    now.Add(-4 * time.Hour),
+			body:     map[string]string{"registration_id": regID.String()},
+			wantCode: http.StatusConflict,
+			wantErr:  "CHECK_IN_CLOSED",
+		},
+		{
+			name:   "registration for another event",
+			caller: hostID,
+			start:  now,
+			body:   map[string]string{"registration_id": regID.String()},
+			setup: func(tc *checkInTestContext) {
+				tc.expectCheckInRegistration(regID, uuid.New(), uuid.New(), model.RegistrationConfirmed)
+			},
+			wantCode: http.StatusNotFound,
+			wantErr:  "NOT_FOUND",
+		},
+		{
+			name:   "waitlisted player",
+			caller: hostID,
+			start:  now,
+			body:   map[string]string{"registration_id": regID.String()},
+			setup: func(tc *checkInTestContext) {
+				tc.expectCheckInRegistration(regID, eventID, uuid.New(), model.RegistrationWaitlist)
+				tc.mock.ExpectQuery("UPDATE registrations").
+					WithArgs(regID).
+					WillReturnRows(sqlmock.NewRows([]string{"checked_in_at"}))
+			},
+			wantCode: http.StatusConflict,
+			wantErr:  "NOT_CONFIRMED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := setupCheckInTestContext(t)
+			defer tc.db.Close()
+
+			tc.expectCheckInEvent(eventID, hostID, tt.start)
+			if tt.setup != nil {
+				tt.setup(tc)
+			}
+
+			recorder := tc.checkIn(tt.caller, eventID, tt.body)
+
+			if recorder.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, recorder.Code, recorder.Body.String())
+			}
+			if code := parseResponse(t, recorder).Error.Code; code != tt.wantErr {
+				t.Errorf("expected error %s, got %s", tt.wantErr, code)
+			}
+			if err := tc.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckIn_InvalidBody(t *testing.T) {
+	tc := setupCheckInTestContext(t)
+	defer tc.db.Close()
+
+	hostID, eventID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name    string
+		body    map[string]string
+		wantErr string
+	}{
+		{"neither field", map[string]string{}, "VALIDATION_ERROR"},
+		{"both fields", map[string]string{"token": "a.b", "registration_id": uuid.NewString()}, "VALIDATION_ERROR"},
+		{"forged token", map[string]string{"token": checkin.NewSigner("other-secret").Sign(uuid.New())}, "INVALID_CHECK_IN_CODE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tc.checkIn(hostID, eventID, tt.body)
+
+			if recorder.Code != http.StatusBadRequest {
+				t.Fatalf("expected status %d, got %d", http.StatusBadRequest, recorder.Code)
+			}
+			if code := parseResponse(t, recorder).Error.Code; code != tt.wantErr {
+				t.Errorf("expected error %s, got %s", tt.wantErr, code)
+			}
+		})
+	}
+}
+
+func TestGetCheckInCode(t *testing.T) {
+	tc := setupCheckInTestContext(t)
+	defer tc.db.Close()
+
+	userID, eventID, regID := uuid.New(), uuid.New(), uuid.New()
+	tc.mock.ExpectQuery("SELECT \\* FROM registrations WHERE event_id").
+		WithArgs(eventID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "event_id", "user_id", "status", "waitlist_position", "registered_at",
+			"confirmed_at", "cancelled_at", "offer_expires_at", "checked_in_at",
+		}).AddRow(regID, eventID, userID, model.RegistrationConfirmed, nil, time.Now(), nil, nil, nil, nil))
+
+	router := gin.New()
+	router.GET("/events/:id/check-in/code", createAuthContext(userID.String(), "Player"), tc.handler.GetCheckInCode)
+
+	req := httptest.NewRequest(http.MethodGet, "/events/"+eventID.String()+"/check-in/code?format=svg", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	if ct := recorder.Header().Get("Content-Type"); ct != "image/svg+xml" {
+		t.Errorf("expected image/svg+xml, got %q", ct)
+	}
+	if cc := recorder.Header().Get("Cache-Control"); cc != "private, no-store" {
+		t.Errorf("expected private, no-store, got %q", cc)
+	}
+}
@@ -0,0 +1,554 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/dto"
+	"github.com/anthropics/pickle-go/apps/api/internal/middleware"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// clubPageEvents is how many upcoming events the club page lists
+const clubPageEvents = 20
+
+// ClubHandler handles clubs and their members
+type ClubHandler struct {
+	clubRepo   *repository.ClubRepository
+	eventRepo  *repository.EventRepository
+	userRepo   *repository.UserRepository
+	outboxRepo *repository.OutboxRepository
+	txManager  *database.TxManager
+	location   *time.Location
+}
+
+// NewClubHandler creates a new ClubHandler. Event dates and times are
+// wall-clock times in location.
+func NewClubHandler(clubRepo *repository.ClubRepository, eventRepo *repository.EventRepository, userRepo *repository.UserRepository, outboxRepo *repository.OutboxRepository, txManager *database.TxManager, location *time.Location) *ClubHandler {
+	return &ClubHandler{
+		clubRepo:   clubRepo,
+		eventRepo:  eventRepo,
+		userRepo:   userRepo,
+		outboxRepo: outboxRepo,
+		txManager:  txManager,
+		location:   location,
+	}
+}
+
+// CreateClub creates a club with the current user as its owner
+// POST /api/v1/clubs
+func (h *ClubHandler) CreateClub(c *gin.Context) {
+	userID, ok := h.authUser(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateClubRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Club name cannot be blank"))
+		return
+	}
+
+	club := &model.Club{ID: uuid.New(), Name: name, CreatedBy: userID}
+	if description := strings.TrimSpace(req.Description); description != "" {
+		club.Description = &description
+	}
+
+	err := h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		return h.clubRepo.CreateTx(c.Request.Context(), tx, club)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to create club"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(club))
+}
+
+// GetClub returns a club page: the club, its member count, the viewer's
+// membership and the club's upcoming events
+// GET /api/v1/clubs/:id
+func (h *ClubHandler) GetClub(c *gin.Context) {
+	club, ok := h.findClub(c)
+	if !ok {
+		return
+	}
+
+	memberCount, err := h.clubRepo.CountMembers(c.Request.Context(), club.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch club"))
+		return
+	}
+
+	// Signed-in viewers see whether they belong to the club or are waiting to join
+	var membership *model.ClubMember
+	if claims, ok := middleware.GetAuthUser(c); ok {
+		if viewerID, err := uuid.Parse(claims.UserID); err == nil {
+			membership, err = h.clubRepo.FindMember(c.Request.Context(), club.ID, viewerID)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch membership"))
+				return
+			}
+		}
+	}
+
+	events, err := h.eventRepo.FindUpcomingByClubID(c.Request.Context(), club.ID, clubPageEvents, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch events"))
+		return
+	}
+	eventResponses := make([]dto.EventResponse, 0, len(events))
+	for i := range events {
+		eventResponses = append(eventResponses, eventSummaryResponse(c.Request.Context(), h.userRepo, &events[i], h.location))
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.ClubResponse{
+		Club:        club,
+		MemberCount: memberCount,
+		Membership:  membership,
+		UpcomingEvents: dto.EventListResponse{
+			Events:  eventResponses,
+			Total:   len(eventResponses),
+			HasMore: len(eventResponses) == clubPageEvents,
+		},
+	}))
+}
+
+// UpdateClub updates a club's name and description
+// PUT /api/v1/clubs/:id
+func (h *ClubHandler) UpdateClub(c *gin.Context) {
+	userID, ok := h.authUser(c)
+	if !ok {
+		return
+	}
+	club, ok := h.findClub(c)
+	if !ok {
+		return
+	}
+	if _, ok := h.authorizeManager(c, club.ID, userID); !ok {
+		return
+	}
+
+	var req dto.UpdateClubRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Club name cannot be blank"))
+			return
+		}
+		club.Name = name
+	}
+	if req.Description != nil {
+		club.Description = nil
+		if description := strings.TrimSpace(*req.Description); description != "" {
+			club.Description = &description
+		}
+	}
+
+	if err := h.clubRepo.Update(c.Request.Context(), club); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to update club"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(club))
+}
+
+// ListMembers returns a club's members. Owners and admins also see pending
+// invites and join requests.
+// GET /api/v1/clubs/:id/members
+func (h *ClubHandler) ListMembers(c *gin.Context) {
+	userID, ok := h.authUser(c)
+	if !ok {
+		return
+	}
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid club ID"))
+		return
+	}
+
+	member, err := h.clubRepo.FindMember(c.Request.Context(), clubID, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch membership"))
+		return
+	}
+	includePending := member != nil && member.CanManage()
+
+	members, err := h.clubRepo.FindMembers(c.Request.Context(), clubID, includePending)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch members"))
+		return
+	}
+	if members == nil {
+		members = []model.ClubMemberWithUser{}
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"members": members,
+	}))
+}
+
+// JoinClub asks to join a club, or accepts the current user's invite to it
+// POST /api/v1/clubs/:id/join
+func (h *ClubHandler) JoinClub(c *gin.Context) {
+	userID, ok := h.authUser(c)
+	if !ok {
+		return
+	}
+	club, ok := h.findClub(c)
+	if !ok {
+		return
+	}
+
+	existing, err := h.clubRepo.FindMember(c.Request.Context(), club.ID, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch membership"))
+		return
+	}
+	if existing != nil && existing.IsActive() {
+		c.JSON(http.StatusConflict, dto.ErrorResponse("ALREADY_MEMBER", "You are already a member of this club"))
+		return
+	}
+
+	member := &model.ClubMember{
+		ClubID: club.ID,
+		UserID: userID,
+		Role:   model.ClubRoleMember,
+		Status: model.ClubMemberRequested,
+	}
+	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		return h.clubRepo.JoinTx(c.Request.Context(), tx, member)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to join club"))
+		return
+	}
+
+	status := http.StatusAccepted
+	if member.IsActive() {
+		status = http.StatusOK
+	}
+	c.JSON(status, dto.SuccessResponse(member))
+}
+
+// AddMember invites a player to a club, or approves their request to join.
+// Only owners may make someone an admin.
+// POST /api/v1/clubs/:id/members
+func (h *ClubHandler) AddMember(c *gin.Context) {
+	userID, ok := h.authUser(c)
+	if !ok {
+		return
+	}
+	club, ok := h.findClub(c)
+	if !ok {
+		return
+	}
+	manager, ok := h.authorizeManager(c, club.ID, userID)
+	if !ok {
+		return
+	}
+
+	var req dto.AddClubMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+	targetID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid user ID"))
+		return
+	}
+	role := model.ClubRoleMember
+	if req.Role != "" {
+		role = model.ClubRole(req.Role)
+	}
+	if role == model.ClubRoleAdmin && manager.Role != model.ClubRoleOwner {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "Only club owners can add admins"))
+		return
+	}
+
+	if _, err := h.userRepo.FindByID(c.Request.Context(), targetID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("USER_NOT_FOUND", "User not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch user"))
+		return
+	}
+	existing, err := h.clubRepo.FindMember(c.Request.Context(), club.ID, targetID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch membership"))
+		return
+	}
+	if existing != nil && existing.IsActive() {
+		c.JSON(http.StatusConflict, dto.ErrorResponse("ALREADY_MEMBER", "User is already a member of this club"))
+		return
+	}
+
+	inviter, err := h.userRepo.FindByID(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch user"))
+		return
+	}
+
+	member := &model.ClubMember{
+		ClubID:    club.ID,
+		UserID:    targetID,
+		Role:      role,
+		Status:    model.ClubMemberInvited,
+		InvitedBy: &userID,
+	}
+	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		if txErr := h.clubRepo.JoinTx(c.Request.Context(), tx, member); txErr != nil {
+			return txErr
+		}
+		notification := model.NewClubInvitedNotification(targetID, club.Name, inviter.DisplayName)
+		if member.IsActive() {
+			notification = model.NewClubJoinedNotification(targetID, club.Name)
+		}
+		return h.outboxRepo.EnqueueNotificationsTx(c.Request.Context(), tx, notification)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to add member"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(member))
+}
+
+// UpdateMember changes a member's role. Only owners may change roles, and a
+// club always keeps at least one owner.
+// PUT /api/v1/clubs/:id/members/:userId
+func (h *ClubHandler) UpdateMember(c *gin.Context) {
+	userID, ok := h.authUser(c)
+	if !ok {
+		return
+	}
+	clubID, targetID, ok := h.parseMemberParams(c)
+	if !ok {
+		return
+	}
+	manager, ok := h.authorizeManager(c, clubID, userID)
+	if !ok {
+		return
+	}
+	if manager.Role != model.ClubRoleOwner {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "Only club owners can change roles"))
+		return
+	}
+
+	var req dto.UpdateClubMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+	role := model.ClubRole(req.Role)
+
+	target, ok := h.findMember(c, clubID, targetID)
+	if !ok {
+		return
+	}
+	if target.Role == model.ClubRoleOwner && role != model.ClubRoleOwner && !h.hasOtherOwner(c, clubID) {
+		return
+	}
+
+	if err := h.clubRepo.UpdateRole(c.Request.Context(), clubID, targetID, role); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "User is not a member of this club"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to update member"))
+		return
+	}
+	target.Role = role
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(target))
+}
+
+// RemoveMember takes a member out of a club, or withdraws an invite or join
+// request. Members may leave on their own; owners and admins may remove members,
+// and only owners may remove other owners and admins. A club always keeps at
+// least one owner.
+// DELETE /api/v1/clubs/:id/members/:userId
+func (h *ClubHandler) RemoveMember(c *gin.Context) {
+	userID, ok := h.authUser(c)
+	if !ok {
+		return
+	}
+	clubID, targetID, ok := h.parseMemberParams(c)
+	if !ok {
+		return
+	}
+
+	target, ok := h.findMember(c, clubID, targetID)
+	if !ok {
+		return
+	}
+	if targetID != userID {
+		manager, ok := h.authorizeManager(c, clubID, userID)
+		if !ok {
+			return
+		}
+		if target.Role.CanManage() && target.IsActive() && manager.Role != model.ClubRoleOwner {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "Only club owners can remove owners and admins"))
+			return
+		}
+	}
+	if target.Role == model.ClubRoleOwner && target.IsActive() && !h.hasOtherOwner(c, clubID) {
+		return
+	}
+
+	if err := h.clubRepo.RemoveMember(c.Request.Context(), clubID, targetID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "User is not a member of this club"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to remove member"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"message": "Member removed",
+	}))
+}
+
+// findClub loads the club named in the path, writing the error response if
+// it is missing or cannot be loaded
+func (h *ClubHandler) findClub(c *gin.Context) (*model.Club, bool) {
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid club ID"))
+		return nil, false
+	}
+
+	club, err := h.clubRepo.FindByID(c.Request.Context(), clubID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Club not found"))
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch club"))
+		return nil, false
+	}
+	return club, true
+}
+
+// findMember loads a user's membership of a club, writing the error response
+// if they have none
+func (h *ClubHandler) findMember(c *gin.Context, clubID, userID uuid.UUID) (*model.ClubMember, bool) {
+	member, err := h.clubRepo.FindMember(c.Request.Context(), clubID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "User is not a member of this club"))
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch membership"))
+		return nil, false
+	}
+	return member, true
+}
+
+// authorizeManager checks that the user is an active owner or admin of the
+// club, writing the error response if not
+func (h *ClubHandler) authorizeManager(c *gin.Context, clubID, userID uuid.UUID) (*model.ClubMember, bool) {
+	member, err := h.clubRepo.FindMember(c.Request.Context(), clubID, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to verify membership"))
+		return nil, false
+	}
+	if member == nil || !member.CanManage() {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "You are not an owner or admin of this club"))
+		return nil, false
+	}
+	return member, true
+}
+
+// hasOtherOwner checks that the club has an active owner besides the one being
+// demoted or removed, writing the error response if not
+func (h *ClubHandler) hasOtherOwner(c *gin.Context, clubID uuid.UUID) bool {
+	owners, err := h.clubRepo.CountOwners(c.Request.Context(), clubID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to count owners"))
+		return false
+	}
+	if owners <= 1 {
+		c.JSON(http.StatusConflict, dto.ErrorResponse("LAST_OWNER", "A club needs at least one owner; make someone else an owner first"))
+		return false
+	}
+	return true
+}
+
+// parseMemberParams reads the club and user IDs from the path, writing the error
+// response if either is invalid
+func (h *ClubHandler) parseMemberParams(c *gin.Context) (clubID, userID uuid.UUID, ok bool) {
+	clubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid club ID"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	userID, err = uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid user ID"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return clubID, userID, true
+}
+
+// authUser reads the authenticated user, writing the error response if they are
+// missing or invalid
+func (h *ClubHandler) authUser(c *gin.Context) (uuid.UUID, bool) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// checkClubAccess checks that every one of userIDs may register for the event
+// right now, writing the error response if the event is currently kept for its
+// club's members and any of them is not one
+func checkClubAccess(c *gin.Context, clubRepo *repository.ClubRepository, event *model.Event, userIDs []uuid.UUID, loc *time.Location) bool {
+	if !event.MembersOnlyAt(time.Now(), loc) {
+		return true
+	}
+
+	members, err := clubRepo.AreMembers(c.Request.Context(), *event.ClubID, userIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to check club membership"))
+		return false
+	}
+	if !members {
+		message := "This event is only open to club members"
+		if ends := event.MemberPriorityEnds(loc); ends != nil {
+			message = fmt.Sprintf("This event is only open to club members until %s", ends.Format("2006-01-02 15:04"))
+		}
+		c.JSON(http.StatusForbidden, dto.ErrorResponse("MEMBERS_ONLY", message))
+		return false
+	}
+	return true
+}
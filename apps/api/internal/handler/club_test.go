@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// clubTestContext holds the club handler and its mocked database
+type clubTestContext struct {
+	handler *ClubHandler
+	mock    sqlmock.Sqlmock
+	db      *sqlx.DB
+}
+
+func setupClubTestContext(t *testing.T) *clubTestContext {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+
+	db := sqlx.NewDb(mockDB, "postgres")
+	return &clubTestContext{
+		handler: NewClubHandler(repository.NewClubRepository(db), repository.NewEventRepository(db), repository.NewUserRepository(db),
+			repository.NewOutboxRepository(db), database.NewTxManager(db), time.UTC),
+		mock: mock,
+		db:   db,
+	}
+}
+
+var clubMemberColumns = []string{"club_id", "user_id", "role", "status", "invited_by", "created_at", "joined_at"}
+
+// expectClub mocks loading a club
+func (tc *clubTestContext) expectClub(clubID uuid.UUID) {
+	now := time.Now()
+	tc.mock.ExpectQuery("SELECT \\* FROM clubs WHERE id").
+		WithArgs(clubID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "created_by", "created_at", "updated_at"}).
+			AddRow(clubID, "Taipei Picklers", nil, uuid.New(), now, now))
+}
+
+// expectClubMember mocks looking up a user's membership of a club
+func (tc *clubTestContext) expectClubMember(clubID, userID uuid.UUID, role model.ClubRole, status model.ClubMemberStatus) {
+	tc.mock.ExpectQuery("SELECT \\* FROM club_members WHERE club_id").
+		WithArgs(clubID, userID).
+		WillReturnRows(sqlmock.NewRows(clubMemberColumns).AddRow(clubID, userID, role, status, nil, time.Now(), nil))
+}
+
+func (tc *clubTestContext) serve(method, path, route string, userID uuid.UUID, body interface{}, handle gin.HandlerFunc) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.Handle(method, route, createAuthContext(userID.String(), "Player"), handle)
+
+	req := httptest.NewRequest(method, path, jsonBody(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestJoinClub_AcceptsInvite(t *testing.T) {
+	tc := setupClubTestContext(t)
+	defer tc.db.Close()
+
+	clubID, userID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	tc.expectClub(clubID)
+	tc.expectClubMember(clubID, userID, model.ClubRoleAdmin, model.ClubMemberInvited)
+	tc.mock.ExpectBegin()
+	tc.mock.ExpectQuery("INSERT INTO club_members .* ON CONFLICT").
+		WithArgs(clubID, userID, model.ClubRoleMember, model.ClubMemberRequested, nil).
+		WillReturnRows(sqlmock.NewRows(clubMemberColumns).
+			AddRow(clubID, userID, model.ClubRoleAdmin, model.ClubMemberActive, uuid.New(), now, now))
+	tc.mock.ExpectCommit()
+
+	recorder := tc.serve(http.MethodPost, "/clubs/"+clubID.String()+"/join", "/clubs/:id/join", userID, nil, tc.handler.JoinClub)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	data := parseResponse(t, recorder).Data.(map[string]interface{})
+	if data["status"] != string(model.ClubMemberActive) || data["role"] != string(model.ClubRoleAdmin) {
+		t.Errorf("expected active admin, got %v %v", data["status"], data["role"])
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAddMember_AdminCannotAddAdmin(t *testing.T) {
+	tc := setupClubTestContext(t)
+	defer tc.db.Close()
+
+	clubID, adminID := uuid.New(), uuid.New()
+	tc.expectClub(clubID)
+	tc.expectClubMember(clubID, adminID, model.ClubRoleAdmin, model.ClubMemberActive)
+
+	body := map[string]string{"user_id": uuid.NewString(), "role": "admin"}
+	recorder := tc.serve(http.MethodPost, "/clubs/"+clubID.String()+"/members", "/clubs/:id/members", adminID, body, tc.handler.AddMember)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d", http.StatusForbidden, recorder.Code)
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRemoveMember_LastOwnerCannotLeave(t *testing.T) {
+	tc := setupClubTestContext(t)
+	defer tc.db.Close()
+
+	clubID, ownerID := uuid.New(), uuid.New()
+	tc.expectClubMember(clubID, ownerID, model.ClubRoleOwner, model.ClubMemberActive)
+	tc.mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM club_members WHERE club_id = .* AND role = 'owner'").
+		WithArgs(clubID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	path := "/clubs/" + clubID.String() + "/members/" + ownerID.String()
+	recorder := tc.serve(http.MethodDelete, path, "/clubs/:id/members/:userId", ownerID, nil, tc.handler.RemoveMember)
+
+	if recorder.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d", http.StatusConflict, recorder.Code)
+	}
+	if code := parseResponse(t, recorder).Error.Code; code != "LAST_OWNER" {
+		t.Errorf("expected LAST_OWNER, got %s", code)
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRegisterEvent_MembersOnly(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	userID, eventID, hostID, clubID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+
+	tc.mock.ExpectQuery("SELECT .* FROM events WHERE id").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "host_id", "short_code", "event_date", "start_time",
+			"location_name", "latitude", "longitude", "capacity", "skill_level", "fee", "status",
+			"visibility", "club_id", "registration_access", "created_at", "updated_at",
+		}).AddRow(
+			eventID, hostID, "abc123", now.Add(48*time.Hour), "20:00",
+			"Test Location", 25.033, 121.565, 8, "beginner", 200, "open",
+			"public", clubID, "members_only", now, now,
+		))
+	tc.mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM club_members WHERE club_id = .* AND user_id = ANY").
+		WithArgs(clubID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	tc.router.POST("/events/:id/register", createAuthContext(userID.String(), "Test User"), tc.handler.RegisterEvent)
+
+	req := httptest.NewRequest(http.MethodPost, "/events/"+eventID.String()+"/register", nil)
+	recorder := httptest.NewRecorder()
+	tc.router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusForbidden, recorder.Code, recorder.Body.String())
+	}
+	if code := parseResponse(t, recorder).Error.Code; code != "MEMBERS_ONLY" {
+		t.Errorf("expected MEMBERS_ONLY, got %s", code)
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
@@ -1,33 +1,70 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
 	"github.com/anthropics/pickle-go/apps/api/internal/dto"
 	"github.com/anthropics/pickle-go/apps/api/internal/middleware"
 	"github.com/anthropics/pickle-go/apps/api/internal/model"
 	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/pkg/invite"
 	"github.com/anthropics/pickle-go/apps/api/pkg/shortcode"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	// errStatusFollowsRegistrations is returned when a host tries to set open or full directly
+	errStatusFollowsRegistrations = errors.New("open and full follow registrations and cannot be set directly")
+
+	// errAccessNeedsClub is returned when an event without a club is kept for members
+	errAccessNeedsClub = errors.New("only club events can limit registration to members")
+
+	// errPriorityHoursRequired is returned when a members-first event has no priority window
+	errPriorityHoursRequired = errors.New("members-first events need member priority hours")
 )
 
 // EventHandler handles event-related requests
 type EventHandler struct {
 	eventRepo        *repository.EventRepository
+	roleRepo         *repository.EventRoleRepository
 	userRepo         *repository.UserRepository
 	registrationRepo *repository.RegistrationRepository
+	clubRepo         *repository.ClubRepository
+	seriesRepo       *repository.SeriesRepository
+	outboxRepo       *repository.OutboxRepository
+	txManager        *database.TxManager
+	invites          *invite.Signer
+	baseURL          string
+	location         *time.Location
+	// defaultCancelDeadline applies to new events that do not set their own
+	defaultCancelDeadline time.Duration
 }
 
-// NewEventHandler creates a new EventHandler
-func NewEventHandler(eventRepo *repository.EventRepository, userRepo *repository.UserRepository, registrationRepo *repository.RegistrationRepository) *EventHandler {
+// NewEventHandler creates a new EventHandler. Invite links point to the web app
+// at baseURL. Event dates and times are wall-clock times in location.
+func NewEventHandler(eventRepo *repository.EventRepository, roleRepo *repository.EventRoleRepository, userRepo *repository.UserRepository, registrationRepo *repository.RegistrationRepository, clubRepo *repository.ClubRepository, seriesRepo *repository.SeriesRepository, outboxRepo *repository.OutboxRepository, txManager *database.TxManager, invites *invite.Signer, baseURL string, location *time.Location, defaultCancelDeadline time.Duration) *EventHandler {
 	return &EventHandler{
-		eventRepo:        eventRepo,
-		userRepo:         userRepo,
-		registrationRepo: registrationRepo,
+		eventRepo:             eventRepo,
+		roleRepo:              roleRepo,
+		userRepo:              userRepo,
+		registrationRepo:      registrationRepo,
+		clubRepo:              clubRepo,
+		seriesRepo:            seriesRepo,
+		outboxRepo:            outboxRepo,
+		txManager:             txManager,
+		invites:               invites,
+		baseURL:               strings.TrimSuffix(baseURL, "/"),
+		location:              location,
+		defaultCancelDeadline: defaultCancelDeadline,
 	}
 }
 
@@ -66,36 +103,8 @@ func (h *EventHandler) ListEvents(c *gin.Context) {
 
 	// Convert to response format
 	eventResponses := make([]dto.EventResponse, 0, len(events))
-	for _, event := range events {
-		// Get host information
-		host, err := h.userRepo.FindByID(c.Request.Context(), event.HostID)
-		hostResponse := dto.UserResponse{}
-		if err == nil {
-			hostResponse = dto.FromUser(host)
-		}
-
-		eventResponses = append(eventResponses, dto.EventResponse{
-			ID:        event.ID.String(),
-			Host:      hostResponse,
-			Title:     event.Title,
-			EventDate: event.EventDate.Format("2006-01-02"),
-			StartTime: event.StartTime,
-			EndTime:   event.EndTime,
-			Location: dto.LocationResponse{
-				Name:          event.LocationName,
-				Address:       event.LocationAddress,
-				Lat:           event.Latitude,
-				Lng:           event.Longitude,
-				GooglePlaceID: event.GooglePlaceID,
-			},
-			Capacity:        event.Capacity,
-			ConfirmedCount:  event.ConfirmedCount,
-			WaitlistCount:   event.WaitlistCount,
-			SkillLevel:      string(event.SkillLevel),
-			SkillLevelLabel: event.GetSkillLevelLabel(),
-			Fee:             event.Fee,
-			Status:          string(event.Status),
-		})
+	for i := range events {
+		eventResponses = append(eventResponses, eventSummaryResponse(c.Request.Context(), h.userRepo, &events[i], h.location))
 	}
 
 	c.JSON(http.StatusOK, dto.SuccessResponse(dto.EventListResponse{
@@ -137,6 +146,9 @@ func (h *EventHandler) GetEvent(c *gin.Context) {
 		hostResponse = dto.FromUser(host)
 	}
 
+	// Get co-hosts; like the host, they are left out if they cannot be loaded
+	coHosts, _ := h.roleRepo.FindCoHosts(c.Request.Context(), event.ID)
+
 	c.JSON(http.StatusOK, dto.SuccessResponse(dto.EventResponse{
 		ID:        event.ID.String(),
 		Host:      hostResponse,
@@ -151,13 +163,23 @@ func (h *EventHandler) GetEvent(c *gin.Context) {
 			Lng:           event.Longitude,
 			GooglePlaceID: event.GooglePlaceID,
 		},
-		Capacity:        event.Capacity,
-		ConfirmedCount:  event.ConfirmedCount,
-		WaitlistCount:   event.WaitlistCount,
-		SkillLevel:      string(event.SkillLevel),
-		SkillLevelLabel: event.GetSkillLevelLabel(),
-		Fee:             event.Fee,
-		Status:          string(event.Status),
+		Capacity:                 event.Capacity,
+		ConfirmedCount:           event.ConfirmedCount,
+		WaitlistCount:            event.WaitlistCount,
+		SkillLevel:               string(event.SkillLevel),
+		SkillLevelLabel:          event.GetSkillLevelLabel(),
+		Fee:                      event.Fee,
+		Status:                   string(event.Status),
+		MinReliability:           event.MinReliability,
+		CancellationPolicy:       dto.FromCancellationPolicy(&event.Event, h.location),
+		TransferRequiresApproval: event.TransferApproval,
+		MaxGuests:                event.MaxGuests,
+		RequiresApproval:         event.RequiresApproval,
+		Visibility:               string(event.Visibility),
+		ClubID:                   clubIDString(event.ClubID),
+		RegistrationAccess:       string(event.RegistrationAccess),
+		MemberPriority:           dto.FromMemberPriority(&event.Event, h.location),
+		CoHosts:                  coHosts,
 	}))
 }
 
@@ -196,6 +218,9 @@ func (h *EventHandler) GetEventByCode(c *gin.Context) {
 		waitlistCount = eventWithCounts.WaitlistCount
 	}
 
+	// Get co-hosts; like the host, they are left out if they cannot be loaded
+	coHosts, _ := h.roleRepo.FindCoHosts(c.Request.Context(), event.ID)
+
 	c.JSON(http.StatusOK, dto.SuccessResponse(dto.EventResponse{
 		ID:        event.ID.String(),
 		Host:      hostResponse,
@@ -210,13 +235,23 @@ func (h *EventHandler) GetEventByCode(c *gin.Context) {
 			Lng:           event.Longitude,
 			GooglePlaceID: event.GooglePlaceID,
 		},
-		Capacity:        event.Capacity,
-		ConfirmedCount:  confirmedCount,
-		WaitlistCount:   waitlistCount,
-		SkillLevel:      string(event.SkillLevel),
-		SkillLevelLabel: event.GetSkillLevelLabel(),
-		Fee:             event.Fee,
-		Status:          string(event.Status),
+		Capacity:                 event.Capacity,
+		ConfirmedCount:           confirmedCount,
+		WaitlistCount:            waitlistCount,
+		SkillLevel:               string(event.SkillLevel),
+		SkillLevelLabel:          event.GetSkillLevelLabel(),
+		Fee:                      event.Fee,
+		Status:                   string(event.Status),
+		MinReliability:           event.MinReliability,
+		CancellationPolicy:       dto.FromCancellationPolicy(event, h.location),
+		TransferRequiresApproval: event.TransferApproval,
+		MaxGuests:                event.MaxGuests,
+		RequiresApproval:         event.RequiresApproval,
+		Visibility:               string(event.Visibility),
+		ClubID:                   clubIDString(event.ClubID),
+		RegistrationAccess:       string(event.RegistrationAccess),
+		MemberPriority:           dto.FromMemberPriority(event, h.location),
+		CoHosts:                  coHosts,
 	}))
 }
 
@@ -286,6 +321,53 @@ func (h *EventHandler) CreateEvent(c *gin.Context) {
 	if req.Location.GooglePlaceID != "" {
 		event.GooglePlaceID = &req.Location.GooglePlaceID
 	}
+	if req.MinReliability != nil && *req.MinReliability > 0 {
+		event.MinReliability = req.MinReliability
+	}
+	deadlineHours := int(h.defaultCancelDeadline / time.Hour)
+	if req.CancelDeadlineHours != nil {
+		deadlineHours = *req.CancelDeadlineHours
+	}
+	if deadlineHours > 0 {
+		event.CancelDeadlineHours = &deadlineHours
+	}
+	event.TransferApproval = req.TransferRequiresApproval
+	event.MaxGuests = req.MaxGuests
+	event.RequiresApproval = req.RequiresApproval
+	event.Visibility = model.VisibilityPublic
+	if req.Visibility != "" {
+		event.Visibility = model.EventVisibility(req.Visibility)
+	}
+
+	// Club events can only be created by the club's owners and admins
+	if req.ClubID != "" {
+		clubID, err := uuid.Parse(req.ClubID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid club ID"))
+			return
+		}
+		member, err := h.clubRepo.FindMember(c.Request.Context(), clubID, userID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to verify club membership"))
+			return
+		}
+		if member == nil || !member.CanManage() {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "You are not an owner or admin of this club"))
+			return
+		}
+		event.ClubID = &clubID
+	}
+	event.RegistrationAccess = model.AccessEveryone
+	if req.RegistrationAccess != "" {
+		event.RegistrationAccess = model.RegistrationAccess(req.RegistrationAccess)
+	}
+	if event.RegistrationAccess == model.AccessMembersFirst {
+		event.MemberPriorityHours = req.MemberPriorityHours
+	}
+	if err := checkRegistrationAccess(event); err != nil {
+		respondWithAccessError(c, err)
+		return
+	}
 
 	if err := h.eventRepo.Create(c.Request.Context(), event); err != nil {
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to create event"))
@@ -323,13 +405,17 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 		return
 	}
 
-	// Check if user is the host
-	isHost, err := h.eventRepo.IsHost(c.Request.Context(), eventID, userID)
+	// Hosts and co-hosts can manage the event
+	role, err := h.eventRepo.FindRole(c.Request.Context(), eventID, userID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to verify ownership"))
 		return
 	}
-	if !isHost {
+	if !role.CanManage() {
 		c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "You are not the host of this event"))
 		return
 	}
@@ -340,53 +426,165 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 		return
 	}
 
-	// Get existing event
-	event, err := h.eventRepo.FindByID(c.Request.Context(), eventID)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch event"))
-		return
-	}
-
-	// Update fields
-	if req.Title != nil {
-		event.Title = req.Title
-	}
-	if req.Description != nil {
-		event.Description = req.Description
-	}
+	var eventDate *time.Time
 	if req.EventDate != nil {
-		eventDate, err := time.Parse("2006-01-02", *req.EventDate)
+		d, err := time.Parse("2006-01-02", *req.EventDate)
 		if err != nil {
 			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event date format"))
 			return
 		}
-		event.EventDate = eventDate
-	}
-	if req.StartTime != nil {
-		event.StartTime = *req.StartTime
-	}
-	if req.EndTime != nil {
-		event.EndTime = req.EndTime
-	}
-	if req.Capacity != nil {
-		event.Capacity = *req.Capacity
-	}
-	if req.SkillLevel != nil {
-		event.SkillLevel = model.SkillLevel(*req.SkillLevel)
+		eventDate = &d
 	}
-	if req.Fee != nil {
-		event.Fee = *req.Fee
-	}
-	if req.Status != nil {
-		event.Status = model.EventStatus(*req.Status)
+
+	policy := model.CapacityPolicyRefuse
+	if req.CapacityPolicy != nil {
+		policy = model.CapacityPolicy(*req.CapacityPolicy)
 	}
 
-	if err := h.eventRepo.Update(c.Request.Context(), event); err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to update event"))
+	// Apply changes with the event row locked so status follows the state machine
+	// and capacity changes move registrations atomically
+	var event *model.Event
+	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		var txErr error
+		event, txErr = h.eventRepo.FindByIDForUpdate(c.Request.Context(), tx, eventID)
+		if txErr != nil {
+			return txErr
+		}
+		// Cancelled and completed events are history and cannot be edited
+		if event.Status.IsFinal() {
+			return repository.ErrEventNotOpen
+		}
+
+		// Validate the requested status before touching anything
+		var nextStatus *model.EventStatus
+		if req.Status != nil && model.EventStatus(*req.Status) != event.Status {
+			next := model.EventStatus(*req.Status)
+			if !event.Status.CanTransitionTo(next) {
+				return fmt.Errorf("%w: %s to %s", repository.ErrInvalidStatusTransition, event.Status, next)
+			}
+			if next != model.EventStatusCancelled {
+				return errStatusFollowsRegistrations
+			}
+			nextStatus = &next
+		}
+
+		capacityChanged := req.Capacity != nil && *req.Capacity != event.Capacity
+		before := *event
+
+		// Update fields
+		if req.Title != nil {
+			event.Title = req.Title
+		}
+		if req.Description != nil {
+			event.Description = req.Description
+		}
+		if eventDate != nil {
+			event.EventDate = *eventDate
+		}
+		if req.StartTime != nil {
+			event.StartTime = *req.StartTime
+		}
+		if req.EndTime != nil {
+			event.EndTime = req.EndTime
+		}
+		if req.Capacity != nil {
+			event.Capacity = *req.Capacity
+		}
+		if req.SkillLevel != nil {
+			event.SkillLevel = model.SkillLevel(*req.SkillLevel)
+		}
+		if req.Fee != nil {
+			event.Fee = *req.Fee
+		}
+		if req.MinReliability != nil {
+			event.MinReliability = req.MinReliability
+			if *req.MinReliability == 0 {
+				event.MinReliability = nil
+			}
+		}
+		if req.CancelDeadlineHours != nil {
+			event.CancelDeadlineHours = req.CancelDeadlineHours
+			if *req.CancelDeadlineHours == 0 {
+				event.CancelDeadlineHours = nil
+			}
+		}
+		if req.TransferRequiresApproval != nil {
+			event.TransferApproval = *req.TransferRequiresApproval
+		}
+		if req.MaxGuests != nil {
+			event.MaxGuests = *req.MaxGuests
+		}
+		if req.RequiresApproval != nil {
+			event.RequiresApproval = *req.RequiresApproval
+		}
+		if req.Visibility != nil {
+			event.Visibility = model.EventVisibility(*req.Visibility)
+		}
+		if req.RegistrationAccess != nil {
+			event.RegistrationAccess = model.RegistrationAccess(*req.RegistrationAccess)
+		}
+		if req.MemberPriorityHours != nil {
+			event.MemberPriorityHours = req.MemberPriorityHours
+		}
+		if event.RegistrationAccess != model.AccessMembersFirst {
+			event.MemberPriorityHours = nil
+		}
+		if txErr = checkRegistrationAccess(event); txErr != nil {
+			return txErr
+		}
+
+		if txErr = h.eventRepo.UpdateTx(c.Request.Context(), tx, event); txErr != nil {
+			return txErr
+		}
+		// A series occurrence edited on its own keeps its changes through later series-wide edits
+		if txErr = h.seriesRepo.MarkDetachedTx(c.Request.Context(), tx, eventID); txErr != nil {
+			return txErr
+		}
+
+		if nextStatus != nil {
+			if txErr = h.eventRepo.UpdateStatusTx(c.Request.Context(), tx, eventID, *nextStatus); txErr != nil {
+				return txErr
+			}
+			event.Status = *nextStatus
+			userIDs, txErr := h.registrationRepo.CancelAllByEventIDTx(c.Request.Context(), tx, eventID)
+			if txErr != nil {
+				return txErr
+			}
+			return h.outboxRepo.EnqueueNotificationsTx(c.Request.Context(), tx, model.NewEventCancelledNotifications(userIDs, event)...)
+		}
+
+		if capacityChanged {
+			capacityChange, txErr := h.registrationRepo.ResizeTx(c.Request.Context(), tx, eventID, event.Capacity, policy)
+			if txErr != nil {
+				return txErr
+			}
+			if txErr = h.notifyCapacityChangeTx(c.Request.Context(), tx, event, capacityChange); txErr != nil {
+				return txErr
+			}
+		}
+		if txErr = h.notifyUpdatedTx(c.Request.Context(), tx, &before, event); txErr != nil {
+			return txErr
+		}
+		return h.registrationRepo.SyncEventStatusTx(c.Request.Context(), tx, eventID)
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+		case errors.Is(err, repository.ErrEventNotOpen):
+			c.JSON(http.StatusConflict, dto.ErrorResponse("EVENT_CLOSED", "Cancelled and completed events cannot be edited"))
+		case errors.Is(err, repository.ErrInvalidStatusTransition):
+			c.JSON(http.StatusConflict, dto.ErrorResponse("INVALID_STATUS_TRANSITION", "Cannot change event status from "+string(event.Status)+" to "+*req.Status))
+		case errors.Is(err, repository.ErrCapacityBelowHeld):
+			c.JSON(http.StatusConflict, dto.ErrorResponse("CAPACITY_BELOW_CONFIRMED", "Capacity is below the number of confirmed players; use capacity_policy demote to move the latest confirmed players to the waitlist"))
+		case errors.Is(err, errStatusFollowsRegistrations):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Event status open and full are set automatically from registrations"))
+		case errors.Is(err, errAccessNeedsClub), errors.Is(err, errPriorityHoursRequired):
+			respondWithAccessError(c, err)
+		default:
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to update event"))
+		}
 		return
 	}
 
@@ -396,6 +594,27 @@ func (h *EventHandler) UpdateEvent(c *gin.Context) {
 	}))
 }
 
+// notifyCapacityChangeTx enqueues notifications for users who were promoted or
+// moved back to the waitlist by a capacity change
+func (h *EventHandler) notifyCapacityChangeTx(ctx context.Context, tx *sqlx.Tx, event *model.Event, change *repository.CapacityChange) error {
+	return h.outboxRepo.EnqueueNotificationsTx(ctx, tx, change.Notifications(event)...)
+}
+
+// notifyUpdatedTx enqueues a notification listing what changed for every
+// confirmed, offered and waitlisted player. Edits that are not material,
+// like a new description, notify no one.
+func (h *EventHandler) notifyUpdatedTx(ctx context.Context, tx *sqlx.Tx, before, after *model.Event) error {
+	changes := model.DiffEvent(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+	userIDs, err := h.registrationRepo.FindParticipantIDsTx(ctx, tx, after.ID)
+	if err != nil {
+		return err
+	}
+	return h.outboxRepo.EnqueueNotificationsTx(ctx, tx, model.NewEventUpdatedNotifications(userIDs, after, changes)...)
+}
+
 // DeleteEvent cancels an event
 // DELETE /api/v1/events/:id
 func (h *EventHandler) DeleteEvent(c *gin.Context) {
@@ -418,34 +637,178 @@ func (h *EventHandler) DeleteEvent(c *gin.Context) {
 		return
 	}
 
-	// Check if user is the host
-	isHost, err := h.eventRepo.IsHost(c.Request.Context(), eventID, userID)
+	// Hosts and co-hosts can manage the event
+	role, err := h.eventRepo.FindRole(c.Request.Context(), eventID, userID)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to verify ownership"))
 		return
 	}
-	if !isHost {
+	if !role.CanManage() {
 		c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "You are not the host of this event"))
 		return
 	}
 
-	// Update event status to cancelled
-	if err := h.eventRepo.UpdateStatus(c.Request.Context(), eventID, model.EventStatusCancelled); err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to cancel event"))
+	// Cancel the event and all registrations together, and let everyone registered know
+	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		if txErr := h.eventRepo.UpdateStatusTx(c.Request.Context(), tx, eventID, model.EventStatusCancelled); txErr != nil {
+			return txErr
+		}
+		userIDs, txErr := h.registrationRepo.CancelAllByEventIDTx(c.Request.Context(), tx, eventID)
+		if txErr != nil {
+			return txErr
+		}
+		event, txErr := h.eventRepo.FindByIDTx(c.Request.Context(), tx, eventID)
+		if txErr != nil {
+			return txErr
+		}
+		return h.outboxRepo.EnqueueNotificationsTx(c.Request.Context(), tx, model.NewEventCancelledNotifications(userIDs, event)...)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrInvalidStatusTransition):
+			c.JSON(http.StatusConflict, dto.ErrorResponse("INVALID_STATUS_TRANSITION", "Only open or full events can be cancelled"))
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+		default:
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to cancel event"))
+		}
 		return
 	}
 
-	// Cancel all registrations
-	if err := h.registrationRepo.CancelAllByEventID(c.Request.Context(), eventID); err != nil {
-		// Log error but don't fail the request
-		// TODO: Add proper logging
-	}
-
 	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
 		"message": "Event cancelled successfully",
 	}))
 }
 
+// GetInviteLink returns the link that lets players register for an invite-only event
+// GET /api/v1/events/:id/invite
+func (h *EventHandler) GetInviteLink(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return
+	}
+
+	event, err := h.eventRepo.FindByID(c.Request.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch event"))
+		return
+	}
+	role, err := eventRole(c.Request.Context(), h.eventRepo, event, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to verify event role"))
+		return
+	}
+	if !role.CanManage() {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "You are not the host of this event"))
+		return
+	}
+	if event.Visibility != model.VisibilityInviteOnly {
+		c.JSON(http.StatusConflict, dto.ErrorResponse("NOT_INVITE_ONLY", "Only invite-only events have invite links"))
+		return
+	}
+
+	token := h.invites.Sign(event.ID)
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.InviteLinkResponse{
+		Token: token,
+		URL:   h.baseURL + "/g/" + event.ShortCode + "?invite=" + token,
+	}))
+}
+
+// checkRegistrationAccess checks that an event's registration access fits the
+// event: members-only access needs a club and members-first access a window
+func checkRegistrationAccess(event *model.Event) error {
+	if event.RegistrationAccess != model.AccessEveryone && event.ClubID == nil {
+		return errAccessNeedsClub
+	}
+	if event.RegistrationAccess == model.AccessMembersFirst && event.MemberPriorityHours == nil {
+		return errPriorityHoursRequired
+	}
+	return nil
+}
+
+// respondWithAccessError maps a checkRegistrationAccess error to an API error response
+func respondWithAccessError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errAccessNeedsClub):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Only club events can limit registration to club members"))
+	default:
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Members-first events need member_priority_hours"))
+	}
+}
+
+// eventSummaryResponse converts an event with its registration counts to
+// EventResponse for event lists. The host is left out if they cannot be loaded.
+func eventSummaryResponse(ctx context.Context, userRepo *repository.UserRepository, event *model.EventSummary, loc *time.Location) dto.EventResponse {
+	host, err := userRepo.FindByID(ctx, event.HostID)
+	hostResponse := dto.UserResponse{}
+	if err == nil {
+		hostResponse = dto.FromUser(host)
+	}
+
+	return dto.EventResponse{
+		ID:        event.ID.String(),
+		Host:      hostResponse,
+		Title:     event.Title,
+		EventDate: event.EventDate.Format("2006-01-02"),
+		StartTime: event.StartTime,
+		EndTime:   event.EndTime,
+		Location: dto.LocationResponse{
+			Name:          event.LocationName,
+			Address:       event.LocationAddress,
+			Lat:           event.Latitude,
+			Lng:           event.Longitude,
+			GooglePlaceID: event.GooglePlaceID,
+		},
+		Capacity:                 event.Capacity,
+		ConfirmedCount:           event.ConfirmedCount,
+		WaitlistCount:            event.WaitlistCount,
+		SkillLevel:               string(event.SkillLevel),
+		SkillLevelLabel:          event.GetSkillLevelLabel(),
+		Fee:                      event.Fee,
+		Status:                   string(event.Status),
+		MinReliability:           event.MinReliability,
+		CancellationPolicy:       dto.FromCancellationPolicy(&event.Event, loc),
+		TransferRequiresApproval: event.TransferApproval,
+		MaxGuests:                event.MaxGuests,
+		RequiresApproval:         event.RequiresApproval,
+		Visibility:               string(event.Visibility),
+		ClubID:                   clubIDString(event.ClubID),
+		RegistrationAccess:       string(event.RegistrationAccess),
+		MemberPriority:           dto.FromMemberPriority(&event.Event, loc),
+	}
+}
+
+// clubIDString formats an event's club ID for responses, or returns nil if the
+// event does not belong to a club
+func clubIDString(clubID *uuid.UUID) *string {
+	if clubID == nil {
+		return nil
+	}
+	id := clubID.String()
+	return &id
+}
+
 // Legacy handlers for backward compatibility
 
 // ListEvents is the legacy handler
@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/dto"
+	"github.com/anthropics/pickle-go/apps/api/internal/middleware"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// EventRoleHandler handles co-hosts and check-in helpers
+type EventRoleHandler struct {
+	roleRepo   *repository.EventRoleRepository
+	eventRepo  *repository.EventRepository
+	userRepo   *repository.UserRepository
+	outboxRepo *repository.OutboxRepository
+	txManager  *database.TxManager
+}
+
+// NewEventRoleHandler creates a new EventRoleHandler
+func NewEventRoleHandler(roleRepo *repository.EventRoleRepository, eventRepo *repository.EventRepository, userRepo *repository.UserRepository, outboxRepo *repository.OutboxRepository, txManager *database.TxManager) *EventRoleHandler {
+	return &EventRoleHandler{
+		roleRepo:   roleRepo,
+		eventRepo:  eventRepo,
+		userRepo:   userRepo,
+		outboxRepo: outboxRepo,
+		txManager:  txManager,
+	}
+}
+
+// AssignRole invites a user to co-host the event or help with check-in
+// POST /api/v1/events/:id/roles
+func (h *EventRoleHandler) AssignRole(c *gin.Context) {
+	userID, eventID, ok := h.parseParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AssignEventRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+	inviteeID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid user ID"))
+		return
+	}
+
+	event, err := h.eventRepo.FindByID(c.Request.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch event"))
+		return
+	}
+	// Only the host, or a club event's owners and admins, hand out roles, so
+	// co-hosts cannot add more co-hosts
+	role, err := eventRole(c.Request.Context(), h.eventRepo, event, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to verify ownership"))
+		return
+	}
+	if role != model.RoleHost {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "Only the host can assign event roles"))
+		return
+	}
+	if inviteeID == event.HostID {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "The host already runs this event"))
+		return
+	}
+
+	if _, err := h.userRepo.FindByID(c.Request.Context(), inviteeID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("USER_NOT_FOUND", "User not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch user"))
+		return
+	}
+	host, err := h.userRepo.FindByID(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch user"))
+		return
+	}
+
+	assignment := &model.EventRoleAssignment{
+		ID:        uuid.New(),
+		EventID:   eventID,
+		UserID:    inviteeID,
+		Role:      model.EventRole(req.Role),
+		InvitedBy: userID,
+	}
+	// Record the invitation and let the invitee know together
+	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		if txErr := h.roleRepo.InviteTx(c.Request.Context(), tx, assignment); txErr != nil {
+			return txErr
+		}
+		if assignment.Status == model.EventRoleAccepted {
+			return nil
+		}
+		return h.outboxRepo.EnqueueNotificationsTx(c.Request.Context(), tx,
+			model.NewRoleInvitedNotification(inviteeID, eventID, event.GetNotificationTitle(), host.DisplayName, assignment.Role))
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to assign role"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(assignment))
+}
+
+// ListRoles returns everyone invited to help run the event
+// GET /api/v1/events/:id/roles
+func (h *EventRoleHandler) ListRoles(c *gin.Context) {
+	userID, eventID, ok := h.parseParams(c)
+	if !ok {
+		return
+	}
+
+	role, err := h.eventRepo.FindRole(c.Request.Context(), eventID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to verify ownership"))
+		return
+	}
+	if !role.CanManage() {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "You are not the host of this event"))
+		return
+	}
+
+	roles, err := h.roleRepo.FindByEventID(c.Request.Context(), eventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch event roles"))
+		return
+	}
+	if roles == nil {
+		roles = []model.EventRoleWithUser{}
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"roles": roles,
+	}))
+}
+
+// AcceptRole takes up the current user's role invitation
+// POST /api/v1/events/:id/roles/accept
+func (h *EventRoleHandler) AcceptRole(c *gin.Context) {
+	userID, eventID, ok := h.parseParams(c)
+	if !ok {
+		return
+	}
+
+	assignment, err := h.roleRepo.Accept(c.Request.Context(), eventID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NO_INVITATION", "No role invitation is waiting for you"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to accept role"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(assignment))
+}
+
+// RemoveRole takes a role away. The host can remove anyone; others can step down.
+// DELETE /api/v1/events/:id/roles/:userId
+func (h *EventRoleHandler) RemoveRole(c *gin.Context) {
+	userID, eventID, ok := h.parseParams(c)
+	if !ok {
+		return
+	}
+
+	targetID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid user ID"))
+		return
+	}
+
+	if targetID != userID {
+		role, err := h.eventRepo.FindRole(c.Request.Context(), eventID, userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+				return
+			}
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to verify ownership"))
+			return
+		}
+		if role != model.RoleHost {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "Only the host can remove event roles"))
+			return
+		}
+	}
+
+	if err := h.roleRepo.Delete(c.Request.Context(), eventID, targetID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event role not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to remove role"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"message": "Event role removed",
+	}))
+}
+
+// parseParams reads the authenticated user and the event ID from the path,
+// writing the error response if either is missing or invalid
+func (h *EventRoleHandler) parseParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, eventID, true
+}
+
+// eventRole returns the role a user holds on an event that has already been
+// loaded, only looking up event roles for users other than the host
+func eventRole(ctx context.Context, eventRepo *repository.EventRepository, event *model.Event, userID uuid.UUID) (model.EventRole, error) {
+	if event.HostID == userID {
+		return model.RoleHost, nil
+	}
+	return eventRepo.FindRole(ctx, event.ID, userID)
+}
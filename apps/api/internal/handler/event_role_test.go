@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// eventRoleTestContext holds the event role handler and its mocked database
+type eventRoleTestContext struct {
+	handler *EventRoleHandler
+	mock    sqlmock.Sqlmock
+	db      *sqlx.DB
+}
+
+func setupEventRoleTestContext(t *testing.T) *eventRoleTestContext {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+
+	db := sqlx.NewDb(mockDB, "postgres")
+	return &eventRoleTestContext{
+		handler: NewEventRoleHandler(repository.NewEventRoleRepository(db), repository.NewEventRepository(db),
+			repository.NewUserRepository(db), repository.NewOutboxRepository(db), database.NewTxManager(db)),
+		mock: mock,
+		db:   db,
+	}
+}
+
+// expectEventRole mocks looking up the role a user holds on an event
+func expectEventRole(mock sqlmock.Sqlmock, eventID, userID uuid.UUID, role model.EventRole) {
+	mock.ExpectQuery("SELECT CASE WHEN e.host_id").
+		WithArgs(eventID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow(string(role)))
+}
+
+// expectRoleEvent mocks loading an event hosted by hostID
+func (tc *eventRoleTestContext) expectRoleEvent(eventID, hostID uuid.UUID) {
+	now := time.Now()
+	tc.mock.ExpectQuery("SELECT .* FROM events WHERE id").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "host_id", "short_code", "title", "event_date", "start_time",
+			"location_name", "latitude", "longitude", "capacity", "skill_level", "fee", "status", "created_at", "updated_at",
+		}).AddRow(
+			eventID, hostID, "abc123", nil, now.Add(24*time.Hour), "19:00",
+			"Test Location", 25.033, 121.565, 4, "beginner", 200, "open", now, now,
+		))
+}
+
+func (tc *eventRoleTestContext) serve(method, path, route string, userID uuid.UUID, body interface{}, handle gin.HandlerFunc) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.Handle(method, route, createAuthContext(userID.String(), "Player"), handle)
+
+	req := httptest.NewRequest(method, path, jsonBody(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestAssignRole(t *testing.T) {
+	tc := setupEventRoleTestContext(t)
+	defer tc.db.Close()
+
+	hostID, eventID, inviteeID := uuid.New(), uuid.New(), uuid.New()
+
+	tc.expectRoleEvent(eventID, hostID)
+	for _, id := range []uuid.UUID{inviteeID, hostID} {
+		tc.mock.ExpectQuery("SELECT .* FROM users WHERE id").
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "line_user_id", "display_name"}).AddRow(id, "U"+id.String(), "Player"))
+	}
+	tc.mock.ExpectBegin()
+	tc.mock.ExpectQuery("INSERT INTO event_roles .* ON CONFLICT").
+		WithArgs(sqlmock.AnyArg(), eventID, inviteeID, model.RoleCoHost, hostID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "created_at", "accepted_at"}).
+			AddRow(uuid.New(), "invited", time.Now(), nil))
+	tc.mock.ExpectExec("INSERT INTO outbox").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	tc.mock.ExpectCommit()
+
+	body := map[string]string{"user_id": inviteeID.String(), "role": "co_host"}
+	recorder := tc.serve(http.MethodPost, "/events/"+eventID.String()+"/roles", "/events/:id/roles", hostID, body, tc.handler.AssignRole)
+
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, recorder.Code, recorder.Body.String())
+	}
+	data := parseResponse(t, recorder).Data.(map[string]interface{})
+	if data["status"] != string(model.EventRoleInvited) {
+		t.Errorf("expected invited, got %v", data["status"])
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAssignRole_CoHostCannotAssign(t *testing.T) {
+	tc := setupEventRoleTestContext(t)
+	defer tc.db.Close()
+
+	eventID, coHostID := uuid.New(), uuid.New()
+	tc.expectRoleEvent(eventID, uuid.New())
+	expectEventRole(tc.mock, eventID, coHostID, model.RoleCoHost)
+
+	body := map[string]string{"user_id": uuid.NewString(), "role": "check_in_helper"}
+	recorder := tc.serve(http.MethodPost, "/events/"+eventID.String()+"/roles", "/events/:id/roles", coHostID, body, tc.handler.AssignRole)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d", http.StatusForbidden, recorder.Code)
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAcceptRole_NoInvitation(t *testing.T) {
+	tc := setupEventRoleTestContext(t)
+	defer tc.db.Close()
+
+	eventID, userID := uuid.New(), uuid.New()
+	tc.mock.ExpectQuery("UPDATE event_roles SET status = 'accepted'").
+		WithArgs(eventID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	recorder := tc.serve(http.MethodPost, "/events/"+eventID.String()+"/roles/accept", "/events/:id/roles/accept", userID, nil, tc.handler.AcceptRole)
+
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected status %d, got %d", http.StatusNotFound, recorder.Code)
+	}
+	if code := parseResponse(t, recorder).Error.Code; code != "NO_INVITATION" {
+		t.Errorf("expected NO_INVITATION, got %s", code)
+	}
+}
+
+func TestRemoveRole_StepDown(t *testing.T) {
+	tc := setupEventRoleTestContext(t)
+	defer tc.db.Close()
+
+	eventID, helperID := uuid.New(), uuid.New()
+	tc.mock.ExpectExec("DELETE FROM event_roles").
+		WithArgs(eventID, helperID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	path := "/events/" + eventID.String() + "/roles/" + helperID.String()
+	recorder := tc.serve(http.MethodDelete, path, "/events/:id/roles/:userId", helperID, nil, tc.handler.RemoveRole)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRemoveRole_CoHostCannotRemoveOthers(t *testing.T) {
+	tc := setupEventRoleTestContext(t)
+	defer tc.db.Close()
+
+	eventID, coHostID := uuid.New(), uuid.New()
+	expectEventRole(tc.mock, eventID, coHostID, model.RoleCoHost)
+
+	path := "/events/" + eventID.String() + "/roles/" + uuid.NewString()
+	recorder := tc.serve(http.MethodDelete, path, "/events/:id/roles/:userId", coHostID, nil, tc.handler.RemoveRole)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d", http.StatusForbidden, recorder.Code)
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
@@ -10,13 +10,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
 	"github.com/anthropics/pickle-go/apps/api/internal/dto"
 	"github.com/anthropics/pickle-go/apps/api/internal/middleware"
 	"github.com/anthropics/pickle-go/apps/api/internal/model"
 	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/pkg/invite"
 	"github.com/anthropics/pickle-go/apps/api/pkg/jwt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 )
 
 // MockEventRepository is a mock implementation for testing
@@ -1135,3 +1139,106 @@ func parseInt(s string, defaultVal int) int {
 	}
 	return i
 }
+
+// newTestEventHandler creates an EventHandler backed by a mocked database
+func newTestEventHandler(db *sqlx.DB) *EventHandler {
+	return NewEventHandler(repository.NewEventRepository(db), repository.NewEventRoleRepository(db), repository.NewUserRepository(db),
+		repository.NewRegistrationRepository(db), repository.NewClubRepository(db), repository.NewSeriesRepository(db), repository.NewOutboxRepository(db),
+		database.NewTxManager(db), invite.NewSigner("test-secret"), "https://picklego.tw", time.UTC, 0)
+}
+
+func TestUpdateEvent_CompletedEvent(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+	db := sqlx.NewDb(mockDB, "postgres")
+	defer db.Close()
+
+	h := newTestEventHandler(db)
+
+	hostID, eventID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	expectEventRole(mock, eventID, hostID, model.RoleHost)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .* FROM events WHERE id = .* FOR UPDATE").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "host_id", "short_code", "event_date", "start_time",
+			"location_name", "latitude", "longitude", "capacity", "skill_level", "fee", "status", "created_at", "updated_at",
+		}).AddRow(
+			eventID, hostID, "abc123", now.Add(-48*time.Hour), "19:00",
+			"Test Location", 25.033, 121.565, 8, "beginner", 200, "completed", now, now,
+		))
+	mock.ExpectRollback()
+
+	router := gin.New()
+	router.PUT("/events/:id", createAuthContext(hostID.String(), "Host"), h.UpdateEvent)
+	req := httptest.NewRequest(http.MethodPut, "/events/"+eventID.String(), jsonBody(map[string]string{"title": "Rematch"}))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusConflict, recorder.Code, recorder.Body.String())
+	}
+	if code := parseResponse(t, recorder).Error.Code; code != "EVENT_CLOSED" {
+		t.Errorf("expected EVENT_CLOSED, got %s", code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdateEvent_DetachesSeriesOccurrence(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+	db := sqlx.NewDb(mockDB, "postgres")
+	defer db.Close()
+
+	h := newTestEventHandler(db)
+
+	hostID, eventID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	expectEventRole(mock, eventID, hostID, model.RoleHost)
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .* FROM events WHERE id = .* FOR UPDATE").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "host_id", "short_code", "event_date", "start_time",
+			"location_name", "latitude", "longitude", "capacity", "skill_level", "fee", "status",
+			"visibility", "registration_access", "created_at", "updated_at",
+		}).AddRow(
+			eventID, hostID, "abc123", now.Add(48*time.Hour), "19:00",
+			"Test Location", 25.033, 121.565, 8, "beginner", 200, "open",
+			"public", "everyone", now, now,
+		))
+	mock.ExpectQuery("UPDATE events SET").
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(now))
+	// Later series-wide edits must not overwrite this one-off change
+	mock.ExpectExec("UPDATE event_series_occurrences SET is_detached = TRUE WHERE event_id").
+		WithArgs(eventID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("WITH held AS").
+		WithArgs(eventID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	router := gin.New()
+	router.PUT("/events/:id", createAuthContext(hostID.String(), "Host"), h.UpdateEvent)
+	req := httptest.NewRequest(http.MethodPut, "/events/"+eventID.String(), jsonBody(map[string]string{"description": "Bring water"}))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/dto"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/pkg/line"
+	"github.com/gin-gonic/gin"
+)
+
+// maxWebhookBodySize bounds the webhook request body
+const maxWebhookBodySize = 1 << 20
+
+// LineWebhookHandler handles LINE Messaging API webhooks
+type LineWebhookHandler struct {
+	userRepo   *repository.UserRepository
+	lineClient *line.MessagingClient
+}
+
+// NewLineWebhookHandler creates a new LineWebhookHandler
+func NewLineWebhookHandler(userRepo *repository.UserRepository, lineClient *line.MessagingClient) *LineWebhookHandler {
+	return &LineWebhookHandler{
+		userRepo:   userRepo,
+		lineClient: lineClient,
+	}
+}
+
+// HandleWebhook keeps track of which users have added the bot as a friend
+// POST /api/v1/line/webhook
+func (h *LineWebhookHandler) HandleWebhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodySize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Failed to read request body"))
+		return
+	}
+
+	if !h.lineClient.VerifySignature(body, c.GetHeader("X-Line-Signature")) {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_SIGNATURE", "Invalid webhook signature"))
+		return
+	}
+
+	var req line.WebhookRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid webhook body"))
+		return
+	}
+
+	for _, event := range req.Events {
+		if event.Source.Type != "user" || event.Source.UserID == "" {
+			continue
+		}
+
+		var friend bool
+		switch event.Type {
+		case line.WebhookEventFollow:
+			friend = true
+		case line.WebhookEventUnfollow:
+			friend = false
+		default:
+			continue
+		}
+
+		err := h.userRepo.SetLineFriend(c.Request.Context(), event.Source.UserID, friend)
+		if errors.Is(err, repository.ErrNotFound) {
+			// Followed the bot before ever logging in; picked up on the next follow
+			continue
+		}
+		if err != nil {
+			// LINE redelivers failed webhooks, so let it try again
+			log.Printf("LINE webhook: failed to update friendship for %s: %v", event.Source.UserID, err)
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to process webhook"))
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(nil))
+}
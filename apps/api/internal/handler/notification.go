@@ -0,0 +1,293 @@
+package handler
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/dto"
+	"github.com/anthropics/pickle-go/apps/api/internal/middleware"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// defaultNotificationLimit is the page size when the client does not ask for one
+const defaultNotificationLimit = 20
+
+// errInvalidCursor is returned when a pagination cursor cannot be decoded
+var errInvalidCursor = errors.New("invalid cursor")
+
+// NotificationHandler handles the current user's notification inbox and preferences
+type NotificationHandler struct {
+	notificationRepo *repository.NotificationRepository
+	preferenceRepo   *repository.PreferenceRepository
+	txManager        *database.TxManager
+	defaultTimezone  string
+}
+
+// NewNotificationHandler creates a new NotificationHandler
+func NewNotificationHandler(notificationRepo *repository.NotificationRepository, preferenceRepo *repository.PreferenceRepository, txManager *database.TxManager, defaultTimezone string) *NotificationHandler {
+	return &NotificationHandler{
+		notificationRepo: notificationRepo,
+		preferenceRepo:   preferenceRepo,
+		txManager:        txManager,
+		defaultTimezone:  defaultTimezone,
+	}
+}
+
+// ListNotifications returns a page of the current user's notifications, newest first
+// GET /api/v1/users/me/notifications
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	var query dto.ListNotificationsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	filter := repository.NotificationFilter{
+		UnreadOnly: query.Unread,
+		Limit:      query.Limit,
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultNotificationLimit
+	}
+	for _, t := range strings.Split(query.Type, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			filter.Types = append(filter.Types, t)
+		}
+	}
+	if query.Cursor != "" {
+		cursor, err := decodeNotificationCursor(query.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid cursor"))
+			return
+		}
+		filter.After = cursor
+	}
+
+	// Fetch one extra to tell whether there is another page
+	pageSize := filter.Limit
+	filter.Limit++
+	notifications, err := h.notificationRepo.List(c.Request.Context(), userID, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to get notifications"))
+		return
+	}
+
+	unreadCount, err := h.notificationRepo.CountUnread(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to count unread notifications"))
+		return
+	}
+
+	hasMore := len(notifications) > pageSize
+	if hasMore {
+		notifications = notifications[:pageSize]
+	}
+
+	responses := make([]dto.NotificationResponse, 0, len(notifications))
+	for _, n := range notifications {
+		responses = append(responses, toNotificationResponse(&n))
+	}
+
+	var nextCursor *string
+	if hasMore {
+		last := notifications[len(notifications)-1]
+		cursor := encodeNotificationCursor(repository.NotificationCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+		nextCursor = &cursor
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.NotificationListResponse{
+		Notifications: responses,
+		Total:         len(responses),
+		UnreadCount:   unreadCount,
+		HasMore:       hasMore,
+		NextCursor:    nextCursor,
+	}))
+}
+
+// GetUnreadCount returns the number of unread notifications for the badge
+// GET /api/v1/users/me/notifications/unread-count
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	count, err := h.notificationRepo.CountUnread(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to count unread notifications"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"unread_count": count,
+	}))
+}
+
+// MarkAsRead marks one of the current user's notifications as read
+// POST /api/v1/users/me/notifications/:id/read
+func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	notificationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid notification ID"))
+		return
+	}
+
+	// Another user's notification is reported as not found so IDs cannot be probed
+	if err := h.notificationRepo.MarkAsRead(c.Request.Context(), notificationID, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Notification not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to mark notification as read"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"message": "Notification marked as read",
+	}))
+}
+
+// MarkAllAsRead marks all of the current user's notifications as read
+// POST /api/v1/users/me/notifications/read-all
+func (h *NotificationHandler) MarkAllAsRead(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	updated, err := h.notificationRepo.MarkAllAsRead(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to mark notifications as read"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"updated": updated,
+	}))
+}
+
+// DeleteNotification deletes one of the current user's notifications
+// DELETE /api/v1/users/me/notifications/:id
+func (h *NotificationHandler) DeleteNotification(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	notificationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid notification ID"))
+		return
+	}
+
+	if err := h.notificationRepo.Delete(c.Request.Context(), notificationID, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Notification not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to delete notification"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"message": "Notification deleted",
+	}))
+}
+
+// toNotificationResponse converts a notification to its API representation
+func toNotificationResponse(n *model.Notification) dto.NotificationResponse {
+	resp := dto.NotificationResponse{
+		ID:                 n.ID.String(),
+		Type:               n.Type,
+		Title:              n.Title,
+		Message:            n.Message,
+		IsRead:             n.IsRead,
+		CreatedAt:          n.CreatedAt,
+		DeliveredViaDigest: n.DigestID != nil,
+	}
+	if n.EventID != nil {
+		eventID := n.EventID.String()
+		resp.EventID = &eventID
+	}
+	return resp
+}
+
+// encodeNotificationCursor encodes a cursor as an opaque URL-safe string
+func encodeNotificationCursor(cursor repository.NotificationCursor) string {
+	raw := strconv.FormatInt(cursor.CreatedAt.UnixNano(), 10) + "_" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeNotificationCursor decodes a cursor produced by encodeNotificationCursor
+func decodeNotificationCursor(s string) (*repository.NotificationCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	nanos, id, ok := strings.Cut(string(raw), "_")
+	if !ok {
+		return nil, errInvalidCursor
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	cursorID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	return &repository.NotificationCursor{CreatedAt: time.Unix(0, n), ID: cursorID}, nil
+}
@@ -0,0 +1,307 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+func setupNotificationTest(t *testing.T, userID uuid.UUID) (*gin.Engine, sqlmock.Sqlmock, func()) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+	db := sqlx.NewDb(mockDB, "postgres")
+	h := NewNotificationHandler(repository.NewNotificationRepository(db), repository.NewPreferenceRepository(db), database.NewTxManager(db), "Asia/Taipei")
+
+	auth := createAuthContext(userID.String(), "Test User")
+	router := gin.New()
+	router.GET("/notifications", auth, h.ListNotifications)
+	router.GET("/notifications/unread-count", auth, h.GetUnreadCount)
+	router.POST("/notifications/read-all", auth, h.MarkAllAsRead)
+	router.POST("/notifications/:id/read", auth, h.MarkAsRead)
+	router.DELETE("/notifications/:id", auth, h.DeleteNotification)
+	router.GET("/notification-preferences", auth, h.GetPreferences)
+	router.PUT("/notification-preferences", auth, h.UpdatePreferences)
+	return router, mock, func() { db.Close() }
+}
+
+func TestListNotifications_CursorPagination(t *testing.T) {
+	userID := uuid.New()
+	router, mock, cleanup := setupNotificationTest(t, userID)
+	defer cleanup()
+
+	now := time.Now().Truncate(time.Microsecond)
+	newest, older := uuid.New(), uuid.New()
+	columns := []string{"id", "user_id", "event_id", "type", "title", "message", "is_read", "created_at"}
+
+	// Asked for 1, fetched 2: there is another page
+	mock.ExpectQuery(regexp.QuoteMeta(`AND type = ANY($2) ORDER BY created_at DESC, id DESC LIMIT $3`)).
+		WithArgs(userID, sqlmock.AnyArg(), 2).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(newest, userID, nil, model.NotificationEventUpdated, "Event details have changed", nil, false, now).
+			AddRow(older, userID, nil, model.NotificationEventUpdated, "Event details have changed", nil, false, now.Add(-time.Minute)))
+	mock.ExpectQuery("SELECT COUNT").WithArgs(userID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	req := httptest.NewRequest(http.MethodGet, "/notifications?type=event_updated&limit=1", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	data := parseResponse(t, recorder).Data.(map[string]interface{})
+	if data["has_more"] != true || len(data["notifications"].([]interface{})) != 1 {
+		t.Fatalf("expected one notification and another page, got %v", data)
+	}
+
+	// The cursor resumes after the last notification returned
+	cursor, err := decodeNotificationCursor(data["next_cursor"].(string))
+	if err != nil {
+		t.Fatalf("failed to decode cursor: %v", err)
+	}
+	if cursor.ID != newest || !cursor.CreatedAt.Equal(now) {
+		t.Errorf("expected cursor at %s %v, got %+v", newest, now, cursor)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`AND (created_at, id) < ($2, $3)`)).
+		WithArgs(userID, sqlmock.AnyArg(), newest, 21).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(older, userID, nil, model.NotificationEventUpdated, "Event details have changed", nil, false, now.Add(-time.Minute)))
+	mock.ExpectQuery("SELECT COUNT").WithArgs(userID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	req = httptest.NewRequest(http.MethodGet, "/notifications?cursor="+data["next_cursor"].(string), nil)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	data = parseResponse(t, recorder).Data.(map[string]interface{})
+	if data["has_more"] != false || data["next_cursor"] != nil {
+		t.Errorf("expected last page, got %v", data)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestListNotifications_InvalidCursor(t *testing.T) {
+	router, _, cleanup := setupNotificationTest(t, uuid.New())
+	defer cleanup()
+
+	req := httptest.NewRequest(http.MethodGet, "/notifications?cursor=not-a-cursor", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, recorder.Code)
+	}
+}
+
+func TestMarkAsRead_OtherUsersNotification(t *testing.T) {
+	userID := uuid.New()
+	router, mock, cleanup := setupNotificationTest(t, userID)
+	defer cleanup()
+
+	notificationID := uuid.New()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE notifications SET is_read = true WHERE id = $1 AND user_id = $2`)).
+		WithArgs(notificationID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	req := httptest.NewRequest(http.MethodPost, "/notifications/"+notificationID.String()+"/read", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, recorder.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestMarkAllAsRead(t *testing.T) {
+	userID := uuid.New()
+	router, mock, cleanup := setupNotificationTest(t, userID)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE notifications SET is_read = true WHERE user_id = $1 AND is_read = false`)).
+		WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	req := httptest.NewRequest(http.MethodPost, "/notifications/read-all", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+	data := parseResponse(t, recorder).Data.(map[string]interface{})
+	if data["updated"] != float64(3) {
+		t.Errorf("expected 3 updated, got %v", data["updated"])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDeleteNotification(t *testing.T) {
+	userID := uuid.New()
+	router, mock, cleanup := setupNotificationTest(t, userID)
+	defer cleanup()
+
+	notificationID := uuid.New()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM notifications WHERE id = $1 AND user_id = $2`)).
+		WithArgs(notificationID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	req := httptest.NewRequest(http.MethodDelete, "/notifications/"+notificationID.String(), nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetPreferences_Defaults(t *testing.T) {
+	userID := uuid.New()
+	router, mock, cleanup := setupNotificationTest(t, userID)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM notification_preferences`)).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "type", "channel", "enabled"}).
+			AddRow(userID, model.NotificationEventReminder, "line", false))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM notification_settings`)).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "timezone", "quiet_hours_start", "quiet_hours_end"}))
+
+	req := httptest.NewRequest(http.MethodGet, "/notification-preferences", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	data := parseResponse(t, recorder).Data.(map[string]interface{})
+	if data["timezone"] != "Asia/Taipei" || data["quiet_hours"] != nil || data["digest"] != "off" {
+		t.Errorf("expected default settings, got %v", data)
+	}
+	types := data["types"].([]interface{})
+	if len(types) != len(model.NotificationTypes) {
+		t.Fatalf("expected %d types, got %d", len(model.NotificationTypes), len(types))
+	}
+	for _, raw := range types {
+		pref := raw.(map[string]interface{})
+		channels := pref["channels"].(map[string]interface{})
+		wantLine := pref["type"] != model.NotificationEventReminder
+		if channels["in_app"] != true || channels["line"] != wantLine {
+			t.Errorf("unexpected channels for %v: %v", pref["type"], channels)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdatePreferences(t *testing.T) {
+	userID := uuid.New()
+	router, mock, cleanup := setupNotificationTest(t, userID)
+	defer cleanup()
+
+	settingsColumns := []string{"user_id", "timezone", "quiet_hours_start", "quiet_hours_end", "digest", "last_digest_at"}
+	prefColumns := []string{"user_id", "type", "channel", "enabled"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM notification_settings`)).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows(settingsColumns))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO notification_settings`)).
+		WithArgs(userID, "Asia/Taipei", "22:30", "07:00", model.DigestWeekly).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO notification_preferences`)).
+		WithArgs(userID, model.NotificationEventUpdated, "line", false).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM notification_preferences`)).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows(prefColumns).AddRow(userID, model.NotificationEventUpdated, "line", false))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM notification_settings`)).WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows(settingsColumns).AddRow(userID, "Asia/Taipei", "22:30:00", "07:00:00", "weekly", nil))
+
+	body := map[string]interface{}{
+		"quiet_hours": map[string]interface{}{"enabled": true, "start": "22:30", "end": "07:00"},
+		"digest":      "weekly",
+		"preferences": []map[string]interface{}{
+			{"type": model.NotificationEventUpdated, "channel": "line", "enabled": false},
+		},
+	}
+	req := httptest.NewRequest(http.MethodPut, "/notification-preferences", jsonBody(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	data := parseResponse(t, recorder).Data.(map[string]interface{})
+	quiet := data["quiet_hours"].(map[string]interface{})
+	if quiet["start"] != "22:30" || quiet["end"] != "07:00" {
+		t.Errorf("expected quiet hours 22:30-07:00, got %v", quiet)
+	}
+	if data["digest"] != "weekly" {
+		t.Errorf("expected weekly digest, got %v", data["digest"])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdatePreferences_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		body map[string]interface{}
+	}{
+		{"unknown type", map[string]interface{}{
+			"preferences": []map[string]interface{}{{"type": "marketing", "channel": "line", "enabled": false}},
+		}},
+		{"unknown channel", map[string]interface{}{
+			"preferences": []map[string]interface{}{{"type": model.NotificationEventUpdated, "channel": "email", "enabled": false}},
+		}},
+		{"bad quiet hours", map[string]interface{}{
+			"quiet_hours": map[string]interface{}{"enabled": true, "start": "10pm", "end": "07:00"},
+		}},
+		{"empty quiet hours", map[string]interface{}{
+			"quiet_hours": map[string]interface{}{"enabled": true, "start": "07:00", "end": "07:00"},
+		}},
+		{"bad timezone", map[string]interface{}{"timezone": "Mars/Olympus"}},
+		{"bad digest", map[string]interface{}{"digest": "hourly"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mock, cleanup := setupNotificationTest(t, uuid.New())
+			defer cleanup()
+
+			req := httptest.NewRequest(http.MethodPut, "/notification-preferences", jsonBody(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d: %s", http.StatusBadRequest, recorder.Code, recorder.Body.String())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unexpected queries: %v", err)
+			}
+		})
+	}
+}
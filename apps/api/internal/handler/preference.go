@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/dto"
+	"github.com/anthropics/pickle-go/apps/api/internal/middleware"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// GetPreferences returns the current user's notification preferences
+// GET /api/v1/users/me/notification-preferences
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	resp, err := h.loadPreferences(c, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to get notification preferences"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(resp))
+}
+
+// UpdatePreferences updates the current user's notification preferences
+// PUT /api/v1/users/me/notification-preferences
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	var req dto.UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	if req.Timezone != nil {
+		if _, err := time.LoadLocation(*req.Timezone); err != nil || *req.Timezone == "" {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid timezone"))
+			return
+		}
+	}
+	if req.QuietHours != nil && req.QuietHours.Enabled {
+		start, err1 := time.Parse("15:04", req.QuietHours.Start)
+		end, err2 := time.Parse("15:04", req.QuietHours.End)
+		if err1 != nil || err2 != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Quiet hours must be in HH:MM format"))
+			return
+		}
+		if start.Equal(end) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Quiet hours start and end must differ"))
+			return
+		}
+	}
+	for _, p := range req.Preferences {
+		if !model.IsNotificationType(p.Type) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Unknown notification type: "+p.Type))
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+
+	// Settings are saved whole, so start from what is stored
+	var settings *model.NotificationSettings
+	if req.Timezone != nil || req.QuietHours != nil || req.Digest != nil {
+		settings, err = h.preferenceRepo.FindSettings(ctx, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			settings = &model.NotificationSettings{UserID: userID, Timezone: h.defaultTimezone, Digest: model.DigestOff}
+		} else if err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to get notification preferences"))
+			return
+		}
+		if req.Timezone != nil {
+			settings.Timezone = *req.Timezone
+		}
+		if req.Digest != nil {
+			settings.Digest = model.DigestFrequency(*req.Digest)
+		}
+		if req.QuietHours != nil {
+			if req.QuietHours.Enabled {
+				settings.QuietHoursStart = &req.QuietHours.Start
+				settings.QuietHoursEnd = &req.QuietHours.End
+			} else {
+				settings.QuietHoursStart = nil
+				settings.QuietHoursEnd = nil
+			}
+		}
+	}
+
+	err = h.txManager.WithTx(ctx, func(tx *sqlx.Tx) error {
+		if settings != nil {
+			if err := h.preferenceRepo.UpsertSettingsTx(ctx, tx, settings); err != nil {
+				return err
+			}
+		}
+		for _, p := range req.Preferences {
+			pref := &model.NotificationPreference{
+				UserID:  userID,
+				Type:    p.Type,
+				Channel: model.DeliveryChannel(p.Channel),
+				Enabled: p.Enabled,
+			}
+			if err := h.preferenceRepo.UpsertTx(ctx, tx, pref); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to update notification preferences"))
+		return
+	}
+
+	resp, err := h.loadPreferences(c, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to get notification preferences"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(resp))
+}
+
+// loadPreferences builds the full preference listing for a user, filling in
+// defaults for every type and channel the user has not set
+func (h *NotificationHandler) loadPreferences(c *gin.Context, userID uuid.UUID) (*dto.NotificationPreferencesResponse, error) {
+	ctx := c.Request.Context()
+
+	prefs, err := h.preferenceRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.NotificationPreferencesResponse{Timezone: h.defaultTimezone, Digest: string(model.DigestOff)}
+	settings, err := h.preferenceRepo.FindSettings(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if settings != nil {
+		resp.Timezone = settings.Timezone
+		resp.Digest = string(settings.Digest)
+		if settings.QuietHoursStart != nil && settings.QuietHoursEnd != nil {
+			resp.QuietHours = &dto.QuietHoursResponse{
+				Start: clockLabel(*settings.QuietHoursStart),
+				End:   clockLabel(*settings.QuietHoursEnd),
+			}
+		}
+	}
+
+	set := make(map[string]map[string]bool)
+	for _, p := range prefs {
+		if set[p.Type] == nil {
+			set[p.Type] = make(map[string]bool)
+		}
+		set[p.Type][string(p.Channel)] = p.Enabled
+	}
+
+	resp.Types = make([]dto.NotificationTypePreferenceResponse, 0, len(model.NotificationTypes))
+	for _, t := range model.NotificationTypes {
+		channels := make(map[string]bool, len(model.NotificationChannels))
+		for _, ch := range model.NotificationChannels {
+			enabled, ok := set[t][string(ch)]
+			channels[string(ch)] = !ok || enabled
+		}
+		resp.Types = append(resp.Types, dto.NotificationTypePreferenceResponse{
+			Type:     t,
+			Urgent:   model.IsUrgentNotification(t),
+			Channels: channels,
+		})
+	}
+	return resp, nil
+}
+
+// clockLabel trims a TIME column value ("22:00:00") to HH:MM
+func clockLabel(s string) string {
+	if len(s) > 5 {
+		return s[:5]
+	}
+	return s
+}
@@ -1,16 +1,22 @@
 package handler
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/anthropics/pickle-go/apps/api/internal/database"
 	"github.com/anthropics/pickle-go/apps/api/internal/dto"
 	"github.com/anthropics/pickle-go/apps/api/internal/middleware"
 	"github.com/anthropics/pickle-go/apps/api/internal/model"
 	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/internal/service"
+	"github.com/anthropics/pickle-go/apps/api/pkg/invite"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -18,23 +24,37 @@ import (
 
 // RegistrationHandler handles registration-related requests
 type RegistrationHandler struct {
-	registrationRepo *repository.RegistrationRepository
-	eventRepo        *repository.EventRepository
-	notificationRepo *repository.NotificationRepository
-	txManager        *database.TxManager
+	registrationRepo   *repository.RegistrationRepository
+	eventRepo          *repository.EventRepository
+	clubRepo           *repository.ClubRepository
+	outboxRepo         *repository.OutboxRepository
+	txManager          *database.TxManager
+	reliabilityService *service.ReliabilityService
+	invites            *invite.Signer
+	offerWindow        time.Duration
+	location           *time.Location
 }
 
-// NewRegistrationHandler creates a new RegistrationHandler
-func NewRegistrationHandler(registrationRepo *repository.RegistrationRepository, eventRepo *repository.EventRepository, notificationRepo *repository.NotificationRepository, txManager *database.TxManager) *RegistrationHandler {
+// NewRegistrationHandler creates a new RegistrationHandler.
+// offerWindow is how long a freed spot is held for the next waitlisted user;
+// zero promotes waitlisted users immediately. invites verifies the invite
+// tokens invite-only events require. clubRepo checks club membership for events
+// kept for club members. Event dates and times are wall-clock times in location.
+func NewRegistrationHandler(registrationRepo *repository.RegistrationRepository, eventRepo *repository.EventRepository, clubRepo *repository.ClubRepository, outboxRepo *repository.OutboxRepository, txManager *database.TxManager, reliabilityService *service.ReliabilityService, invites *invite.Signer, offerWindow time.Duration, location *time.Location) *RegistrationHandler {
 	return &RegistrationHandler{
-		registrationRepo: registrationRepo,
-		eventRepo:        eventRepo,
-		notificationRepo: notificationRepo,
-		txManager:        txManager,
+		registrationRepo:   registrationRepo,
+		eventRepo:          eventRepo,
+		clubRepo:           clubRepo,
+		outboxRepo:         outboxRepo,
+		txManager:          txManager,
+		reliabilityService: reliabilityService,
+		invites:            invites,
+		offerWindow:        offerWindow,
+		location:           location,
 	}
 }
 
-// RegisterEvent registers the current user for an event
+// RegisterEvent registers the current user, and any guests they bring, for an event
 // POST /api/v1/events/:id/register
 func (h *RegistrationHandler) RegisterEvent(c *gin.Context) {
 	claims, ok := middleware.GetAuthUser(c)
@@ -56,6 +76,20 @@ func (h *RegistrationHandler) RegisterEvent(c *gin.Context) {
 		return
 	}
 
+	// The request body is optional; it only lists guests
+	var req dto.RegisterEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+	for i, name := range req.Guests {
+		req.Guests[i] = strings.TrimSpace(name)
+		if req.Guests[i] == "" {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Guest names cannot be blank"))
+			return
+		}
+	}
+
 	// Check if event exists first (outside transaction for fast fail)
 	event, err := h.eventRepo.FindByID(c.Request.Context(), eventID)
 	if err != nil {
@@ -67,11 +101,53 @@ func (h *RegistrationHandler) RegisterEvent(c *gin.Context) {
 		return
 	}
 
-	// Use transactional registration to prevent race conditions
+	// Invite-only events are closed to players without the host's invite
+	if event.Visibility == model.VisibilityInviteOnly && event.HostID != userID &&
+		h.invites.Verify(req.InviteToken, event.ID) != nil {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse("INVITE_REQUIRED", "This event is invite-only; ask the host for an invite link"))
+		return
+	}
+
+	// Club events may be kept for the club's members, for good or for a while
+	if !checkClubAccess(c, h.clubRepo, event, []uuid.UUID{userID}, h.location) {
+		return
+	}
+
+	// Enforce the host's minimum reliability, if any
+	if event.MinReliability != nil && event.HostID != userID {
+		reliability, err := h.reliabilityService.ForUser(c.Request.Context(), userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to check reliability"))
+			return
+		}
+		if !reliability.Meets(*event.MinReliability) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse("RELIABILITY_TOO_LOW",
+				fmt.Sprintf("This event requires a reliability score of at least %d", *event.MinReliability)))
+			return
+		}
+	}
+
+	if len(req.Guests) > event.MaxGuests {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("TOO_MANY_GUESTS",
+			fmt.Sprintf("This event allows at most %d guests per player", event.MaxGuests)))
+		return
+	}
+
+	// Use transactional registration to prevent race conditions; events that
+	// require approval only record a request for the host to review
 	var registration *model.Registration
 	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
 		var txErr error
-		registration, txErr = h.registrationRepo.RegisterWithLock(c.Request.Context(), tx, eventID, userID)
+		if event.RequiresApproval {
+			var skillLevel *model.SkillLevel
+			if req.SkillLevel != nil {
+				level := model.SkillLevel(*req.SkillLevel)
+				skillLevel = &level
+			}
+			registration, txErr = h.registrationRepo.RequestWithLock(c.Request.Context(), tx, eventID, userID, req.Guests, skillLevel)
+		} else {
+			registration, txErr = h.registrationRepo.RegisterWithLock(c.Request.Context(), tx, eventID, userID, req.Guests)
+		}
 		return txErr
 	})
 
@@ -81,6 +157,8 @@ func (h *RegistrationHandler) RegisterEvent(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, dto.ErrorResponse("EVENT_CLOSED", "This event is not open for registration"))
 		case errors.Is(err, repository.ErrHostCannotRegister):
 			c.JSON(http.StatusBadRequest, dto.ErrorResponse("HOST_CANNOT_REGISTER", "You cannot register for your own event"))
+		case errors.Is(err, repository.ErrBlocked):
+			c.JSON(http.StatusForbidden, dto.ErrorResponse("BLOCKED", "The host of this event is not accepting registrations from you"))
 		case errors.Is(err, repository.ErrAlreadyRegistered):
 			c.JSON(http.StatusBadRequest, dto.ErrorResponse("ALREADY_REGISTERED", "You are already registered for this event"))
 		case errors.Is(err, sql.ErrNoRows):
@@ -93,22 +171,169 @@ func (h *RegistrationHandler) RegisterEvent(c *gin.Context) {
 
 	// Build response message
 	var message string
-	if registration.Status == model.RegistrationConfirmed {
+	switch registration.Status {
+	case model.RegistrationConfirmed:
 		message = "報名成功！"
-	} else {
+	case model.RegistrationPending:
+		message = "已送出報名申請，等待主辦人審核"
+	default:
 		message = fmt.Sprintf("已加入候補（第 %d 位）", *registration.WaitlistPosition)
 	}
 
-	// Update event status to full if needed (outside transaction, non-critical)
-	if registration.Status == model.RegistrationWaitlist && event.Status != model.EventStatusFull {
-		h.eventRepo.UpdateStatus(c.Request.Context(), eventID, model.EventStatusFull)
-	}
-
 	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.RegistrationResponse{
 		ID:               registration.ID.String(),
 		EventID:          eventID.String(),
 		Status:           string(registration.Status),
 		WaitlistPosition: registration.WaitlistPosition,
+		Guests:           registration.Guests,
+		Message:          message,
+	}))
+}
+
+// RegisterGroup registers the current user together with up to three other players.
+// Either the whole group is confirmed or it joins the waitlist as one entry.
+// POST /api/v1/events/:id/register/group
+func (h *RegistrationHandler) RegisterGroup(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	eventIDStr := c.Param("id")
+	eventID, err := uuid.Parse(eventIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return
+	}
+
+	var req dto.RegisterGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	// The caller is always part of the group
+	userIDs := []uuid.UUID{userID}
+	seen := map[uuid.UUID]bool{userID: true}
+	for _, idStr := range req.UserIDs {
+		id, _ := uuid.Parse(idStr)
+		if seen[id] {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Each player can only be listed once, and you are included automatically"))
+			return
+		}
+		seen[id] = true
+		userIDs = append(userIDs, id)
+	}
+
+	event, err := h.eventRepo.FindByID(c.Request.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch event"))
+		return
+	}
+
+	// The organizer's invite covers the players they bring along
+	if event.Visibility == model.VisibilityInviteOnly && h.invites.Verify(req.InviteToken, event.ID) != nil {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse("INVITE_REQUIRED", "This event is invite-only; ask the host for an invite link"))
+		return
+	}
+
+	// Every member of the group has to belong to the club while it is members-only
+	if !checkClubAccess(c, h.clubRepo, event, userIDs, h.location) {
+		return
+	}
+
+	// The host reviews players one by one, so groups cannot skip the queue
+	if event.RequiresApproval {
+		c.JSON(http.StatusConflict, dto.ErrorResponse("APPROVAL_REQUIRED", "This event requires the host to approve each player; register individually"))
+		return
+	}
+
+	// Every member has to meet the host's minimum reliability, if any
+	if event.MinReliability != nil {
+		reliability, err := h.reliabilityService.ForUsers(c.Request.Context(), userIDs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to check reliability"))
+			return
+		}
+		for _, id := range userIDs {
+			if !reliability[id].Meets(*event.MinReliability) {
+				c.JSON(http.StatusForbidden, dto.ErrorResponse("RELIABILITY_TOO_LOW",
+					fmt.Sprintf("Every player in the group needs a reliability score of at least %d", *event.MinReliability)))
+				return
+			}
+		}
+	}
+
+	var registrations []model.Registration
+	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		var txErr error
+		registrations, txErr = h.registrationRepo.RegisterGroupWithLock(c.Request.Context(), tx, eventID, userID, userIDs)
+		if txErr != nil {
+			return txErr
+		}
+
+		// Let the other members know they have been registered
+		notifications := make([]*model.Notification, 0, len(registrations)-1)
+		for _, reg := range registrations {
+			if reg.UserID != userID {
+				notifications = append(notifications, model.NewGroupRegisteredNotification(
+					reg.UserID, eventID, event.GetNotificationTitle(), claims.DisplayName, reg.Status))
+			}
+		}
+		return h.outboxRepo.EnqueueNotificationsTx(c.Request.Context(), tx, notifications...)
+	})
+
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrEventNotOpen):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("EVENT_CLOSED", "This event is not open for registration"))
+		case errors.Is(err, repository.ErrHostCannotRegister):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("HOST_CANNOT_REGISTER", "The host cannot be registered for their own event"))
+		case errors.Is(err, repository.ErrBlocked):
+			c.JSON(http.StatusForbidden, dto.ErrorResponse("BLOCKED", "The host of this event is not accepting registrations from a player in the group"))
+		case errors.Is(err, repository.ErrAlreadyRegistered):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("ALREADY_REGISTERED", "A player in the group is already registered for this event"))
+		case errors.Is(err, repository.ErrNotFound):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Player not found"))
+		case errors.Is(err, sql.ErrNoRows):
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+		default:
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to create registration"))
+		}
+		return
+	}
+
+	first := registrations[0]
+	message := "報名成功！"
+	if first.Status == model.RegistrationWaitlist {
+		message = fmt.Sprintf("已加入候補（第 %d 位）", *first.WaitlistPosition)
+	}
+
+	members := make([]dto.GroupMemberResponse, len(registrations))
+	for i, reg := range registrations {
+		members[i] = dto.GroupMemberResponse{
+			RegistrationID: reg.ID.String(),
+			UserID:         reg.UserID.String(),
+		}
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.GroupRegistrationResponse{
+		GroupID:          first.GroupID.String(),
+		EventID:          eventID.String(),
+		Status:           string(first.Status),
+		WaitlistPosition: first.WaitlistPosition,
+		Members:          members,
 		Message:          message,
 	}))
 }
@@ -146,12 +371,32 @@ func (h *RegistrationHandler) CancelRegistration(c *gin.Context) {
 		return
 	}
 
+	event, err := h.eventRepo.FindByID(c.Request.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch event"))
+		return
+	}
+
+	// Giving up a confirmed spot after the event's deadline is still allowed but flagged as late
+	deadline := event.CancelDeadline(h.location)
+	late := registration.Status == model.RegistrationConfirmed && deadline != nil && time.Now().After(*deadline)
+
 	// Use transactional cancel and promote to prevent race conditions
-	var promoted *model.Registration
 	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
-		var txErr error
-		promoted, txErr = h.registrationRepo.CancelAndPromote(c.Request.Context(), tx, registration.ID, eventID)
-		return txErr
+		promoted, txErr := h.registrationRepo.CancelAndPromote(c.Request.Context(), tx, registration.ID, eventID, h.offerWindow)
+		if txErr != nil {
+			return txErr
+		}
+		if late {
+			if txErr = h.registrationRepo.MarkLateCancelTx(c.Request.Context(), tx, registration.ID); txErr != nil {
+				return txErr
+			}
+		}
+		return notifyPromotedTx(c.Request.Context(), tx, h.eventRepo, h.outboxRepo, eventID, promoted)
 	})
 
 	if err != nil {
@@ -166,39 +411,182 @@ func (h *RegistrationHandler) CancelRegistration(c *gin.Context) {
 		return
 	}
 
-	// Send notification to promoted user (outside transaction, async-friendly)
-	if promoted != nil && h.notificationRepo != nil {
-		event, eventErr := h.eventRepo.FindByID(c.Request.Context(), eventID)
-		if eventErr == nil {
-			eventTitle := event.LocationName
-			if event.Title != nil && *event.Title != "" {
-				eventTitle = *event.Title
-			}
-			eventTitle = fmt.Sprintf("%s @ %s", event.EventDate.Format("01/02"), eventTitle)
-			h.notificationRepo.CreateWaitlistPromotedNotification(
-				c.Request.Context(),
-				promoted.UserID,
-				eventID,
-				eventTitle,
-			)
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"message":           "Registration cancelled successfully",
+		"late_cancellation": late,
+	}))
+}
+
+// CancelGuest releases one guest seat on the current user's registration,
+// keeping the player and their other guests registered
+// DELETE /api/v1/events/:id/register/guests/:guestId
+func (h *RegistrationHandler) CancelGuest(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return
+	}
+
+	guestID, err := uuid.Parse(c.Param("guestId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid guest ID"))
+		return
+	}
+
+	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		promoted, txErr := h.registrationRepo.CancelGuestTx(c.Request.Context(), tx, eventID, userID, guestID, h.offerWindow)
+		if txErr != nil {
+			return txErr
+		}
+		return notifyPromotedTx(c.Request.Context(), tx, h.eventRepo, h.outboxRepo, eventID, promoted)
+	})
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Guest not found on your registration"))
+			return
 		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to cancel guest"))
+		return
 	}
 
-	// Update event status if needed (outside transaction, non-critical)
-	event, err := h.eventRepo.FindByID(c.Request.Context(), eventID)
-	if err == nil && event.Status == model.EventStatusFull {
-		confirmedCount, _ := h.registrationRepo.CountConfirmed(c.Request.Context(), eventID)
-		if confirmedCount < event.Capacity {
-			h.eventRepo.UpdateStatus(c.Request.Context(), eventID, model.EventStatusOpen)
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"message": "Guest cancelled",
+	}))
+}
+
+// AcceptOffer confirms the current user's pending waitlist offer
+// POST /api/v1/events/:id/offer/accept
+func (h *RegistrationHandler) AcceptOffer(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return
+	}
+
+	var registration *model.Registration
+	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		var txErr error
+		registration, txErr = h.registrationRepo.AcceptOffer(c.Request.Context(), tx, eventID, userID)
+		return txErr
+	})
+
+	if err != nil {
+		h.respondWithOfferError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.RegistrationResponse{
+		ID:      registration.ID.String(),
+		EventID: eventID.String(),
+		Status:  string(registration.Status),
+		Message: "報名成功！",
+	}))
+}
+
+// DeclineOffer declines the current user's pending waitlist offer and passes
+// the spot on to the next waitlisted user
+// POST /api/v1/events/:id/offer/decline
+func (h *RegistrationHandler) DeclineOffer(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return
+	}
+
+	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		next, txErr := h.registrationRepo.DeclineOffer(c.Request.Context(), tx, eventID, userID, h.offerWindow)
+		if txErr != nil {
+			return txErr
 		}
+		return notifyPromotedTx(c.Request.Context(), tx, h.eventRepo, h.outboxRepo, eventID, next)
+	})
+
+	if err != nil {
+		h.respondWithOfferError(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
-		"message": "Registration cancelled successfully",
+		"message": "Waitlist offer declined",
 	}))
 }
 
-// GetEventRegistrations returns all registrations for an event
+// respondWithOfferError maps waitlist offer errors to API responses
+func (h *RegistrationHandler) respondWithOfferError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNoOffer):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("NO_OFFER", "You have no pending waitlist offer for this event"))
+	case errors.Is(err, repository.ErrOfferExpired):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("OFFER_EXPIRED", "Your waitlist offer has expired"))
+	case errors.Is(err, sql.ErrNoRows):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "You are not registered for this event"))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to update waitlist offer"))
+	}
+}
+
+// notifyPromotedTx enqueues notifications telling waitlisted users that a spot
+// was offered to them or that they were promoted, so they are sent if and only if
+// the transaction commits
+func notifyPromotedTx(ctx context.Context, tx *sqlx.Tx, eventRepo *repository.EventRepository, outboxRepo *repository.OutboxRepository, eventID uuid.UUID, regs []model.Registration) error {
+	if len(regs) == 0 {
+		return nil
+	}
+	event, err := eventRepo.FindByIDTx(ctx, tx, eventID)
+	if err != nil {
+		return err
+	}
+	notifications := make([]*model.Notification, len(regs))
+	for i, reg := range regs {
+		if reg.Status == model.RegistrationOffered && reg.OfferExpiresAt != nil {
+			notifications[i] = model.NewWaitlistOfferNotification(reg.UserID, eventID, event.GetNotificationTitle(), *reg.OfferExpiresAt)
+		} else {
+			notifications[i] = model.NewWaitlistPromotedNotification(reg.UserID, eventID, event.GetNotificationTitle())
+		}
+	}
+	return outboxRepo.EnqueueNotificationsTx(ctx, tx, notifications...)
+}
+
+// GetEventRegistrations returns all registrations for an event. The players
+// registered for unlisted and invite-only events are only shown to the host and
+// to other players taking part; everyone else just sees the counts.
 // GET /api/v1/events/:id/registrations
 func (h *RegistrationHandler) GetEventRegistrations(c *gin.Context) {
 	eventIDStr := c.Param("id")
@@ -208,14 +596,13 @@ func (h *RegistrationHandler) GetEventRegistrations(c *gin.Context) {
 		return
 	}
 
-	// Check if event exists
-	exists, err := h.eventRepo.Exists(c.Request.Context(), eventID)
+	event, err := h.eventRepo.FindByID(c.Request.Context(), eventID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to check event"))
-		return
-	}
-	if !exists {
-		c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch event"))
 		return
 	}
 
@@ -226,8 +613,70 @@ func (h *RegistrationHandler) GetEventRegistrations(c *gin.Context) {
 		return
 	}
 
-	// Separate confirmed and waitlisted
+	// Counts are in seats, so guests are included
+	stats, err := h.registrationRepo.GetRegistrationStats(c.Request.Context(), eventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch registration stats"))
+		return
+	}
+
+	// Co-hosts get the same view as the host
+	viewerID, signedIn := h.viewer(c)
+	host := false
+	if signedIn {
+		role, err := eventRole(c.Request.Context(), h.eventRepo, event, viewerID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to verify event role"))
+			return
+		}
+		host = role.CanManage()
+	}
+	hidden := event.IsPrivate() && !host
+	if hidden && signedIn {
+		for _, reg := range registrations {
+			if reg.UserID == viewerID {
+				hidden = false
+				break
+			}
+		}
+	}
+
+	// Guest names are only looked up when someone brought guests
+	var guests map[uuid.UUID][]model.Guest
+	for _, reg := range registrations {
+		if reg.GuestCount > 0 && !hidden {
+			guests, err = h.registrationRepo.FindGuestsByEventID(c.Request.Context(), eventID)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch guests"))
+				return
+			}
+			break
+		}
+	}
+
+	// Hosts also see attendance, each player's reliability and spots freed late
+	var reliability map[uuid.UUID]model.Reliability
+	var lateCancellations []model.RegistrationWithUser
+	if host {
+		userIDs := make([]uuid.UUID, len(registrations))
+		for i, reg := range registrations {
+			userIDs[i] = reg.UserID
+		}
+		reliability, err = h.reliabilityService.ForUsers(c.Request.Context(), userIDs)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch reliability"))
+			return
+		}
+		lateCancellations, err = h.registrationRepo.FindLateCancellations(c.Request.Context(), eventID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch late cancellations"))
+			return
+		}
+	}
+
+	// Separate confirmed, offered and waitlisted
 	var confirmed []gin.H
+	var offered []gin.H
 	var waitlist []gin.H
 
 	for _, reg := range registrations {
@@ -236,10 +685,26 @@ func (h *RegistrationHandler) GetEventRegistrations(c *gin.Context) {
 			"user":          reg.User,
 			"registered_at": reg.RegisteredAt,
 		}
+		if reliability != nil {
+			item["reliability"] = dto.FromReliability(reliability[reg.UserID])
+		}
+		if regGuests := guests[reg.ID]; len(regGuests) > 0 {
+			item["guests"] = regGuests
+		}
+		if reg.GroupID != nil {
+			item["group_id"] = reg.GroupID.String()
+		}
 
-		if reg.Status == model.RegistrationConfirmed {
+		switch reg.Status {
+		case model.RegistrationConfirmed:
+			item["checked_in_at"] = reg.CheckedInAt
+			if reliability != nil {
+				item["attendance"] = reg.Attendance
+			}
 			confirmed = append(confirmed, item)
-		} else if reg.Status == model.RegistrationWaitlist {
+		case model.RegistrationOffered:
+			offered = append(offered, item)
+		case model.RegistrationWaitlist:
 			item["waitlist_position"] = reg.WaitlistPosition
 			waitlist = append(waitlist, item)
 		}
@@ -248,18 +713,388 @@ func (h *RegistrationHandler) GetEventRegistrations(c *gin.Context) {
 	if confirmed == nil {
 		confirmed = []gin.H{}
 	}
+	if offered == nil {
+		offered = []gin.H{}
+	}
 	if waitlist == nil {
 		waitlist = []gin.H{}
 	}
 
-	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+	response := gin.H{
 		"confirmed":       confirmed,
+		"offered":         offered,
 		"waitlist":        waitlist,
-		"confirmed_count": len(confirmed),
-		"waitlist_count":  len(waitlist),
+		"confirmed_count": stats.ConfirmedCount,
+		"offered_count":   len(offered),
+		"waitlist_count":  stats.WaitlistCount,
+	}
+	if hidden {
+		response["confirmed"] = []gin.H{}
+		response["offered"] = []gin.H{}
+		response["waitlist"] = []gin.H{}
+		response["participants_hidden"] = true
+	}
+	if host {
+		late := make([]gin.H, len(lateCancellations))
+		for i, reg := range lateCancellations {
+			late[i] = gin.H{
+				"id":           reg.ID.String(),
+				"user":         reg.User,
+				"cancelled_at": reg.CancelledAt,
+			}
+		}
+		response["late_cancellations"] = late
+		response["late_cancellation_count"] = len(late)
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(response))
+}
+
+// GetPendingRegistrations returns the registration requests awaiting the host's
+// approval, with each applicant's stated skill level and reliability
+// GET /api/v1/events/:id/registrations/pending
+func (h *RegistrationHandler) GetPendingRegistrations(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return
+	}
+
+	if !h.authorizeHost(c, eventID) {
+		return
+	}
+
+	requests, err := h.registrationRepo.FindPendingByEventID(c.Request.Context(), eventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch registration requests"))
+		return
+	}
+
+	var guests map[uuid.UUID][]model.Guest
+	for _, reg := range requests {
+		if reg.GuestCount > 0 {
+			guests, err = h.registrationRepo.FindGuestsByEventID(c.Request.Context(), eventID)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch guests"))
+				return
+			}
+			break
+		}
+	}
+
+	userIDs := make([]uuid.UUID, len(requests))
+	for i, reg := range requests {
+		userIDs[i] = reg.UserID
+	}
+	reliability, err := h.reliabilityService.ForUsers(c.Request.Context(), userIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch reliability"))
+		return
+	}
+
+	pending := make([]gin.H, len(requests))
+	for i, reg := range requests {
+		item := gin.H{
+			"id":            reg.ID.String(),
+			"user":          reg.User,
+			"registered_at": reg.RegisteredAt,
+			"skill_level":   reg.SkillLevel,
+			"reliability":   dto.FromReliability(reliability[reg.UserID]),
+		}
+		if regGuests := guests[reg.ID]; len(regGuests) > 0 {
+			item["guests"] = regGuests
+		}
+		pending[i] = item
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"pending":       pending,
+		"pending_count": len(pending),
+	}))
+}
+
+// ApproveRegistration lets the host approve a registration request. The player is
+// confirmed if there are seats for their party, otherwise they join the waitlist.
+// POST /api/v1/events/:id/registrations/:registrationId/approve
+func (h *RegistrationHandler) ApproveRegistration(c *gin.Context) {
+	eventID, registrationID, ok := h.parseRequestParams(c)
+	if !ok {
+		return
+	}
+
+	if !h.authorizeHost(c, eventID) {
+		return
+	}
+
+	var registration *model.Registration
+	err := h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		var txErr error
+		registration, txErr = h.registrationRepo.ApproveWithLock(c.Request.Context(), tx, eventID, registrationID)
+		if txErr != nil {
+			return txErr
+		}
+		event, txErr := h.eventRepo.FindByIDTx(c.Request.Context(), tx, eventID)
+		if txErr != nil {
+			return txErr
+		}
+		return h.outboxRepo.EnqueueNotificationsTx(c.Request.Context(), tx, model.NewRequestApprovedNotification(
+			registration.UserID, eventID, event.GetNotificationTitle(), registration.Status, registration.WaitlistPosition))
+	})
+
+	if err != nil {
+		h.respondWithRequestError(c, err)
+		return
+	}
+
+	message := "已核准報名"
+	if registration.Status == model.RegistrationWaitlist {
+		message = fmt.Sprintf("已核准報名，名額已滿，已加入候補（第 %d 位）", *registration.WaitlistPosition)
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.RegistrationResponse{
+		ID:               registration.ID.String(),
+		EventID:          eventID.String(),
+		Status:           string(registration.Status),
+		WaitlistPosition: registration.WaitlistPosition,
+		Message:          message,
+	}))
+}
+
+// RejectRegistration lets the host turn down a registration request
+// POST /api/v1/events/:id/registrations/:registrationId/reject
+func (h *RegistrationHandler) RejectRegistration(c *gin.Context) {
+	eventID, registrationID, ok := h.parseRequestParams(c)
+	if !ok {
+		return
+	}
+
+	if !h.authorizeHost(c, eventID) {
+		return
+	}
+
+	err := h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		registration, txErr := h.registrationRepo.RejectTx(c.Request.Context(), tx, eventID, registrationID)
+		if txErr != nil {
+			return txErr
+		}
+		event, txErr := h.eventRepo.FindByIDTx(c.Request.Context(), tx, eventID)
+		if txErr != nil {
+			return txErr
+		}
+		return h.outboxRepo.EnqueueNotificationsTx(c.Request.Context(), tx, model.NewRequestRejectedNotification(
+			registration.UserID, eventID, event.GetNotificationTitle()))
+	})
+
+	if err != nil {
+		h.respondWithRequestError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"message": "Registration request rejected",
+	}))
+}
+
+// RemoveRegistration lets the host take a player off the event, for example one who
+// registered by mistake. The player is told why, and the freed seats go to the waitlist.
+// POST /api/v1/events/:id/registrations/:registrationId/remove
+func (h *RegistrationHandler) RemoveRegistration(c *gin.Context) {
+	eventID, registrationID, ok := h.parseRequestParams(c)
+	if !ok {
+		return
+	}
+
+	// The request body is optional; it only gives the reason
+	var req dto.RemoveRegistrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	if !h.authorizeHost(c, eventID) {
+		return
+	}
+
+	err := h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		registration, promoted, txErr := h.registrationRepo.RemoveWithLock(c.Request.Context(), tx, eventID, registrationID, h.offerWindow)
+		if txErr != nil {
+			return txErr
+		}
+		event, txErr := h.eventRepo.FindByIDTx(c.Request.Context(), tx, eventID)
+		if txErr != nil {
+			return txErr
+		}
+		txErr = h.outboxRepo.EnqueueNotificationsTx(c.Request.Context(), tx, model.NewRemovedByHostNotification(
+			registration.UserID, eventID, event.GetNotificationTitle(), strings.TrimSpace(req.Reason)))
+		if txErr != nil {
+			return txErr
+		}
+		return notifyPromotedTx(c.Request.Context(), tx, h.eventRepo, h.outboxRepo, eventID, promoted)
+	})
+
+	if err != nil {
+		h.respondWithRequestError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"message": "Registration removed",
+	}))
+}
+
+// PromoteRegistration lets the host confirm a waitlisted player ahead of their turn,
+// as long as their party fits in the free seats
+// POST /api/v1/events/:id/registrations/:registrationId/promote
+func (h *RegistrationHandler) PromoteRegistration(c *gin.Context) {
+	eventID, registrationID, ok := h.parseRequestParams(c)
+	if !ok {
+		return
+	}
+
+	if !h.authorizeHost(c, eventID) {
+		return
+	}
+
+	err := h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		promoted, txErr := h.registrationRepo.PromoteWithLock(c.Request.Context(), tx, eventID, registrationID)
+		if txErr != nil {
+			return txErr
+		}
+		return notifyPromotedTx(c.Request.Context(), tx, h.eventRepo, h.outboxRepo, eventID, promoted)
+	})
+
+	if err != nil {
+		h.respondWithRequestError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.RegistrationResponse{
+		ID:      registrationID.String(),
+		EventID: eventID.String(),
+		Status:  string(model.RegistrationConfirmed),
+		Message: "已將候補者轉為正取",
 	}))
 }
 
+// MoveWaitlistRegistration lets the host move a waitlisted player to another
+// position in the waitlist
+// PUT /api/v1/events/:id/registrations/:registrationId/waitlist-position
+func (h *RegistrationHandler) MoveWaitlistRegistration(c *gin.Context) {
+	eventID, registrationID, ok := h.parseRequestParams(c)
+	if !ok {
+		return
+	}
+
+	var req dto.MoveWaitlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	if !h.authorizeHost(c, eventID) {
+		return
+	}
+
+	var position int
+	err := h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		var txErr error
+		position, txErr = h.registrationRepo.MoveWaitlistWithLock(c.Request.Context(), tx, eventID, registrationID, req.Position)
+		return txErr
+	})
+
+	if err != nil {
+		h.respondWithRequestError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.RegistrationResponse{
+		ID:               registrationID.String(),
+		EventID:          eventID.String(),
+		Status:           string(model.RegistrationWaitlist),
+		WaitlistPosition: &position,
+		Message:          fmt.Sprintf("已移至候補第 %d 位", position),
+	}))
+}
+
+// parseRequestParams parses the event and registration IDs of a registration request
+func (h *RegistrationHandler) parseRequestParams(c *gin.Context) (eventID, registrationID uuid.UUID, ok bool) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	registrationID, err = uuid.Parse(c.Param("registrationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid registration ID"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return eventID, registrationID, true
+}
+
+// respondWithRequestError maps errors from the host acting on a registration to API responses
+func (h *RegistrationHandler) respondWithRequestError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrNotPending):
+		c.JSON(http.StatusConflict, dto.ErrorResponse("NOT_PENDING", "This registration is not awaiting approval"))
+	case errors.Is(err, repository.ErrNotWaitlisted):
+		c.JSON(http.StatusConflict, dto.ErrorResponse("NOT_WAITLISTED", "This registration is not on the waitlist"))
+	case errors.Is(err, repository.ErrNotEnoughSeats):
+		c.JSON(http.StatusConflict, dto.ErrorResponse("NOT_ENOUGH_SEATS", "There are not enough free seats for this party"))
+	case errors.Is(err, repository.ErrAlreadyCancelled):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("ALREADY_CANCELLED", "Registration is already cancelled"))
+	case errors.Is(err, repository.ErrEventNotOpen):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("EVENT_CLOSED", "This event is not open for registration"))
+	case errors.Is(err, sql.ErrNoRows):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Registration not found"))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to update registration"))
+	}
+}
+
+// authorizeHost checks that the authenticated caller hosts or co-hosts the
+// event and responds with an error if not
+func (h *RegistrationHandler) authorizeHost(c *gin.Context, eventID uuid.UUID) bool {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return false
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return false
+	}
+
+	role, err := h.eventRepo.FindRole(c.Request.Context(), eventID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to check event host"))
+		return false
+	}
+	if !role.CanManage() {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "You are not the host of this event"))
+		return false
+	}
+	return true
+}
+
+// viewer returns the optionally authenticated caller, if any
+func (h *RegistrationHandler) viewer(c *gin.Context) (uuid.UUID, bool) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		return uuid.Nil, false
+	}
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
 // Legacy handlers for backward compatibility
 
 // RegisterEvent is the legacy handler
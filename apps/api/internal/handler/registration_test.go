@@ -16,6 +16,8 @@ import (
 	"github.com/anthropics/pickle-go/apps/api/internal/middleware"
 	"github.com/anthropics/pickle-go/apps/api/internal/model"
 	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/internal/service"
+	"github.com/anthropics/pickle-go/apps/api/pkg/invite"
 	"github.com/anthropics/pickle-go/apps/api/pkg/jwt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -37,7 +39,7 @@ type testContext struct {
 	db           *sqlx.DB
 	regRepo      *repository.RegistrationRepository
 	eventRepo    *repository.EventRepository
-	notifRepo    *repository.NotificationRepository
+	outboxRepo   *repository.OutboxRepository
 	txManager    *database.TxManager
 }
 
@@ -50,10 +52,12 @@ func setupTestContext(t *testing.T) *testContext {
 	db := sqlx.NewDb(mockDB, "postgres")
 	regRepo := repository.NewRegistrationRepository(db)
 	eventRepo := repository.NewEventRepository(db)
-	notifRepo := repository.NewNotificationRepository(db)
+	outboxRepo := repository.NewOutboxRepository(db)
 	txManager := database.NewTxManager(db)
 
-	handler := NewRegistrationHandler(regRepo, eventRepo, notifRepo, txManager)
+	reliabilityService := service.NewReliabilityService(regRepo, model.ReliabilityPolicy{Window: 180 * 24 * time.Hour}, "UTC")
+
+	handler := NewRegistrationHandler(regRepo, eventRepo, repository.NewClubRepository(db), outboxRepo, txManager, reliabilityService, invite.NewSigner("test-secret"), 0, time.UTC)
 
 	router := gin.New()
 
@@ -64,7 +68,7 @@ func setupTestContext(t *testing.T) *testContext {
 		db:        db,
 		regRepo:   regRepo,
 		eventRepo: eventRepo,
-		notifRepo: notifRepo,
+		outboxRepo: outboxRepo,
 		txManager: txManager,
 	}
 }
@@ -131,6 +135,7 @@ func TestRegisterEvent_Success_Confirmed(t *testing.T) {
 	tc.mock.ExpectQuery("SELECT capacity, status, host_id FROM events WHERE id = .* FOR UPDATE").
 		WithArgs(eventID).
 		WillReturnRows(lockEventRows)
+	expectNotBlocked(tc.mock)
 
 	// Check existing registration
 	tc.mock.ExpectQuery("SELECT .* FROM registrations WHERE event_id = .* AND user_id").
@@ -139,7 +144,7 @@ func TestRegisterEvent_Success_Confirmed(t *testing.T) {
 
 	// Count confirmed
 	countRows := sqlmock.NewRows([]string{"count"}).AddRow(2)
-	tc.mock.ExpectQuery("SELECT COUNT.* FROM registrations WHERE event_id = .* AND status = 'confirmed'").
+	tc.mock.ExpectQuery("SELECT COALESCE\\(SUM\\(1 \\+ guest_count\\), 0\\) FROM registrations WHERE event_id = .* AND status IN \\('confirmed', 'offered'\\)").
 		WithArgs(eventID).
 		WillReturnRows(countRows)
 
@@ -149,6 +154,11 @@ func TestRegisterEvent_Success_Confirmed(t *testing.T) {
 		WithArgs(sqlmock.AnyArg(), eventID, userID, model.RegistrationConfirmed, nil).
 		WillReturnRows(insertRows)
 
+	// Recalculate open/full in the same transaction
+	tc.mock.ExpectExec("WITH held AS").
+		WithArgs(eventID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
 	tc.mock.ExpectCommit()
 
 	// Setup router
@@ -209,6 +219,7 @@ func TestRegisterEvent_Success_Waitlist(t *testing.T) {
 	tc.mock.ExpectQuery("SELECT capacity, status, host_id FROM events WHERE id = .* FOR UPDATE").
 		WithArgs(eventID).
 		WillReturnRows(lockEventRows)
+	expectNotBlocked(tc.mock)
 
 	// Check existing registration
 	tc.mock.ExpectQuery("SELECT .* FROM registrations WHERE event_id = .* AND user_id").
@@ -217,7 +228,7 @@ func TestRegisterEvent_Success_Waitlist(t *testing.T) {
 
 	// Count confirmed (event is full)
 	countRows := sqlmock.NewRows([]string{"count"}).AddRow(4)
-	tc.mock.ExpectQuery("SELECT COUNT.* FROM registrations WHERE event_id = .* AND status = 'confirmed'").
+	tc.mock.ExpectQuery("SELECT COALESCE\\(SUM\\(1 \\+ guest_count\\), 0\\) FROM registrations WHERE event_id = .* AND status IN \\('confirmed', 'offered'\\)").
 		WithArgs(eventID).
 		WillReturnRows(countRows)
 
@@ -233,6 +244,11 @@ func TestRegisterEvent_Success_Waitlist(t *testing.T) {
 		WithArgs(sqlmock.AnyArg(), eventID, userID, model.RegistrationWaitlist, 2).
 		WillReturnRows(insertRows)
 
+	// Recalculate open/full in the same transaction
+	tc.mock.ExpectExec("WITH held AS").
+		WithArgs(eventID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
 	tc.mock.ExpectCommit()
 
 	// Setup router
@@ -439,6 +455,7 @@ func TestRegisterEvent_AlreadyRegistered(t *testing.T) {
 	tc.mock.ExpectQuery("SELECT capacity, status, host_id FROM events WHERE id = .* FOR UPDATE").
 		WithArgs(eventID).
 		WillReturnRows(lockEventRows)
+	expectNotBlocked(tc.mock)
 
 	// Check existing registration - already registered
 	existingRows := sqlmock.NewRows([]string{
@@ -530,6 +547,219 @@ func TestRegisterEvent_EventClosed(t *testing.T) {
 	}
 }
 
+func TestRegisterEvent_ReliabilityTooLow(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	userID := uuid.New()
+	eventID := uuid.New()
+
+	now := time.Now()
+	eventRows := sqlmock.NewRows([]string{
+		"id", "host_id", "short_code", "title", "description", "event_date", "start_time", "end_time",
+		"location_name", "location_address", "latitude", "longitude", "google_place_id",
+		"capacity", "skill_level", "fee", "status", "min_reliability", "created_at", "updated_at",
+	}).AddRow(
+		eventID, uuid.New(), "abc123", nil, nil, now, "20:00", nil,
+		"Test Location", nil, 25.033, 121.565, nil,
+		4, "beginner", 200, "open", 80, now, now,
+	)
+	tc.mock.ExpectQuery("SELECT .* FROM events WHERE id").
+		WithArgs(eventID).
+		WillReturnRows(eventRows)
+
+	// 3 of 5 spots honoured
+	tc.mock.ExpectQuery("SELECT r.user_id").
+		WithArgs(sqlmock.AnyArg(), "UTC", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "attended", "late_cancelled", "no_shows"}).
+			AddRow(userID, 3, 1, 1))
+
+	tc.router.POST("/events/:id/register", createAuthContext(userID.String(), "Test User"), tc.handler.RegisterEvent)
+
+	req := httptest.NewRequest(http.MethodPost, "/events/"+eventID.String()+"/register", nil)
+	recorder := httptest.NewRecorder()
+
+	tc.router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", http.StatusForbidden, recorder.Code)
+	}
+
+	response := parseResponse(t, recorder)
+	if response.Error == nil || response.Error.Code != "RELIABILITY_TOO_LOW" {
+		t.Errorf("expected error code RELIABILITY_TOO_LOW, got %v", response.Error)
+	}
+
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRegisterEvent_TooManyGuests(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	userID := uuid.New()
+	eventID := uuid.New()
+
+	now := time.Now()
+	eventRows := sqlmock.NewRows([]string{
+		"id", "host_id", "short_code", "title", "description", "event_date", "start_time", "end_time",
+		"location_name", "location_address", "latitude", "longitude", "google_place_id",
+		"capacity", "skill_level", "fee", "status", "max_guests", "created_at", "updated_at",
+	}).AddRow(
+		eventID, uuid.New(), "abc123", nil, nil, now, "20:00", nil,
+		"Test Location", nil, 25.033, 121.565, nil,
+		8, "beginner", 200, "open", 1, now, now,
+	)
+	tc.mock.ExpectQuery("SELECT .* FROM events WHERE id").
+		WithArgs(eventID).
+		WillReturnRows(eventRows)
+
+	tc.router.POST("/events/:id/register", createAuthContext(userID.String(), "Test User"), tc.handler.RegisterEvent)
+
+	body := jsonBody(map[string]interface{}{"guests": []string{"Amy", "Ben"}})
+	req := httptest.NewRequest(http.MethodPost, "/events/"+eventID.String()+"/register", body)
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	tc.router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, recorder.Code)
+	}
+
+	response := parseResponse(t, recorder)
+	if response.Error == nil || response.Error.Code != "TOO_MANY_GUESTS" {
+		t.Errorf("expected error code TOO_MANY_GUESTS, got %v", response.Error)
+	}
+
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRegisterEvent_RequiresApproval(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	userID := uuid.New()
+	eventID := uuid.New()
+	hostID := uuid.New()
+
+	now := time.Now()
+	eventRows := sqlmock.NewRows([]string{
+		"id", "host_id", "short_code", "title", "description", "event_date", "start_time", "end_time",
+		"location_name", "location_address", "latitude", "longitude", "google_place_id",
+		"capacity", "skill_level", "fee", "status", "requires_approval", "created_at", "updated_at",
+	}).AddRow(
+		eventID, hostID, "abc123", nil, nil, now, "20:00", nil,
+		"Test Location", nil, 25.033, 121.565, nil,
+		8, "intermediate", 200, "open", true, now, now,
+	)
+	tc.mock.ExpectQuery("SELECT .* FROM events WHERE id").
+		WithArgs(eventID).
+		WillReturnRows(eventRows)
+
+	tc.mock.ExpectBegin()
+	tc.mock.ExpectQuery("SELECT status, host_id FROM events WHERE id = .* FOR UPDATE").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "host_id"}).AddRow("open", hostID))
+	expectNotBlocked(tc.mock)
+	tc.mock.ExpectQuery("SELECT \\* FROM registrations WHERE event_id").
+		WithArgs(eventID, userID).
+		WillReturnError(sql.ErrNoRows)
+	tc.mock.ExpectQuery("INSERT INTO registrations .* 'pending'").
+		WithArgs(sqlmock.AnyArg(), eventID, userID, "intermediate").
+		WillReturnRows(sqlmock.NewRows([]string{"registered_at"}).AddRow(now))
+	tc.mock.ExpectCommit()
+
+	tc.router.POST("/events/:id/register", createAuthContext(userID.String(), "Test User"), tc.handler.RegisterEvent)
+
+	body := jsonBody(map[string]interface{}{"skill_level": "intermediate"})
+	req := httptest.NewRequest(http.MethodPost, "/events/"+eventID.String()+"/register", body)
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	tc.router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, recorder.Code, recorder.Body.String())
+	}
+
+	data := parseResponse(t, recorder).Data.(map[string]interface{})
+	if data["status"] != string(model.RegistrationPending) {
+		t.Errorf("expected status pending, got %v", data["status"])
+	}
+
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRegisterEvent_InviteOnlyWithoutInvite(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	userID := uuid.New()
+	eventID := uuid.New()
+
+	tc.expectVisibleEvent(eventID, uuid.New(), model.VisibilityInviteOnly)
+
+	tc.router.POST("/events/:id/register", createAuthContext(userID.String(), "Test User"), tc.handler.RegisterEvent)
+
+	// An invite to another event does not count
+	body := jsonBody(map[string]interface{}{"invite_token": invite.NewSigner("test-secret").Sign(uuid.New())})
+	req := httptest.NewRequest(http.MethodPost, "/events/"+eventID.String()+"/register", body)
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	tc.router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", http.StatusForbidden, recorder.Code)
+	}
+
+	response := parseResponse(t, recorder)
+	if response.Error == nil || response.Error.Code != "INVITE_REQUIRED" {
+		t.Errorf("expected error code INVITE_REQUIRED, got %v", response.Error)
+	}
+
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRegisterGroup_ListsCallerTwice(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	userID := uuid.New()
+	eventID := uuid.New()
+
+	tc.router.POST("/events/:id/register/group", createAuthContext(userID.String(), "Test User"), tc.handler.RegisterGroup)
+
+	body := jsonBody(map[string]interface{}{"user_ids": []string{uuid.NewString(), userID.String()}})
+	req := httptest.NewRequest(http.MethodPost, "/events/"+eventID.String()+"/register/group", body)
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	tc.router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, recorder.Code)
+	}
+
+	response := parseResponse(t, recorder)
+	if response.Error == nil || response.Error.Code != "VALIDATION_ERROR" {
+		t.Errorf("expected error code VALIDATION_ERROR, got %v", response.Error)
+	}
+
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
 // =============================================================================
 // CancelRegistration Handler Tests
 // =============================================================================
@@ -559,6 +789,9 @@ func TestCancelRegistration_Success_WithPromotion(t *testing.T) {
 		WithArgs(eventID, userID).
 		WillReturnRows(regRows)
 
+	// Event lookup for the cancellation deadline
+	tc.expectCancelEvent(eventID, now.Add(48*time.Hour), nil)
+
 	// Transaction
 	tc.mock.ExpectBegin()
 
@@ -570,6 +803,9 @@ func TestCancelRegistration_Success_WithPromotion(t *testing.T) {
 		regID, eventID, userID, "confirmed", nil,
 		now, now, nil,
 	)
+	tc.mock.ExpectQuery("SELECT capacity, status FROM events WHERE id = .* FOR UPDATE").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(8, "open"))
 	tc.mock.ExpectQuery("SELECT .* FROM registrations WHERE id = .* FOR UPDATE").
 		WithArgs(regID).
 		WillReturnRows(lockRegRows)
@@ -592,16 +828,24 @@ func TestCancelRegistration_Success_WithPromotion(t *testing.T) {
 		WillReturnRows(waitlistRows)
 
 	// Promote the waitlisted user
-	tc.mock.ExpectExec("UPDATE registrations").
+	tc.mock.ExpectQuery("UPDATE registrations").
 		WithArgs(promotedRegID).
-		WillReturnResult(sqlmock.NewResult(0, 1))
+		WillReturnRows(sqlmock.NewRows([]string{"confirmed_at"}).AddRow(now))
 
 	// Reorder waitlist
 	tc.mock.ExpectExec("UPDATE registrations").
-		WithArgs(eventID).
+		WithArgs(eventID, 1).
 		WillReturnResult(sqlmock.NewResult(0, 0))
 
-	tc.mock.ExpectCommit()
+	// Nobody else is waiting
+	tc.mock.ExpectQuery("SELECT .* FROM registrations").
+		WithArgs(eventID).
+		WillReturnError(sql.ErrNoRows)
+
+	// Recalculate open/full
+	tc.mock.ExpectExec("WITH held AS").
+		WithArgs(eventID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	// Notification (look up event for notification)
 	eventRows := sqlmock.NewRows([]string{
@@ -617,35 +861,12 @@ func TestCancelRegistration_Success_WithPromotion(t *testing.T) {
 		WithArgs(eventID).
 		WillReturnRows(eventRows)
 
-	// Create notification
-	tc.mock.ExpectQuery("INSERT INTO notifications").
-		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(now))
-
-	// Update event status (after cancel) - event lookup
-	eventRows2 := sqlmock.NewRows([]string{
-		"id", "host_id", "short_code", "title", "description", "event_date", "start_time", "end_time",
-		"location_name", "location_address", "latitude", "longitude", "google_place_id",
-		"capacity", "skill_level", "fee", "status", "created_at", "updated_at",
-	}).AddRow(
-		eventID, hostID, "abc123", nil, nil, now, "20:00", nil,
-		"Test Location", nil, 25.033, 121.565, nil,
-		4, "beginner", 200, "full", now, now,
-	)
-	tc.mock.ExpectQuery("SELECT .* FROM events WHERE id").
-		WithArgs(eventID).
-		WillReturnRows(eventRows2)
-
-	// Count confirmed
-	countRows := sqlmock.NewRows([]string{"count"}).AddRow(3)
-	tc.mock.ExpectQuery("SELECT COUNT.* FROM registrations").
-		WithArgs(eventID).
-		WillReturnRows(countRows)
-
-	// Update event status to open
-	tc.mock.ExpectExec("UPDATE events SET status").
-		WithArgs(eventID, model.EventStatusOpen).
+	// Enqueue notification in the same transaction
+	tc.mock.ExpectExec("INSERT INTO outbox").
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
+	tc.mock.ExpectCommit()
+
 	// Setup router
 	tc.router.DELETE("/events/:id/register", createAuthContext(userID.String(), "Test User"), tc.handler.CancelRegistration)
 
@@ -677,7 +898,6 @@ func TestCancelRegistration_Success_NoWaitlist(t *testing.T) {
 	userID := uuid.New()
 	eventID := uuid.New()
 	regID := uuid.New()
-	hostID := uuid.New()
 
 	now := time.Now()
 
@@ -693,6 +913,9 @@ func TestCancelRegistration_Success_NoWaitlist(t *testing.T) {
 		WithArgs(eventID, userID).
 		WillReturnRows(regRows)
 
+	// Event lookup for the cancellation deadline
+	tc.expectCancelEvent(eventID, now.Add(48*time.Hour), nil)
+
 	// Transaction
 	tc.mock.ExpectBegin()
 
@@ -704,6 +927,9 @@ func TestCancelRegistration_Success_NoWaitlist(t *testing.T) {
 		regID, eventID, userID, "confirmed", nil,
 		now, now, nil,
 	)
+	tc.mock.ExpectQuery("SELECT capacity, status FROM events WHERE id = .* FOR UPDATE").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(8, "open"))
 	tc.mock.ExpectQuery("SELECT .* FROM registrations WHERE id = .* FOR UPDATE").
 		WithArgs(regID).
 		WillReturnRows(lockRegRows)
@@ -718,33 +944,13 @@ func TestCancelRegistration_Success_NoWaitlist(t *testing.T) {
 		WithArgs(eventID).
 		WillReturnError(sql.ErrNoRows)
 
-	tc.mock.ExpectCommit()
-
-	// Update event status (after cancel) - event lookup
-	eventRows := sqlmock.NewRows([]string{
-		"id", "host_id", "short_code", "title", "description", "event_date", "start_time", "end_time",
-		"location_name", "location_address", "latitude", "longitude", "google_place_id",
-		"capacity", "skill_level", "fee", "status", "created_at", "updated_at",
-	}).AddRow(
-		eventID, hostID, "abc123", nil, nil, now, "20:00", nil,
-		"Test Location", nil, 25.033, 121.565, nil,
-		4, "beginner", 200, "full", now, now,
-	)
-	tc.mock.ExpectQuery("SELECT .* FROM events WHERE id").
-		WithArgs(eventID).
-		WillReturnRows(eventRows)
-
-	// Count confirmed
-	countRows := sqlmock.NewRows([]string{"count"}).AddRow(3)
-	tc.mock.ExpectQuery("SELECT COUNT.* FROM registrations").
+	// Recalculate open/full in the same transaction
+	tc.mock.ExpectExec("WITH held AS").
 		WithArgs(eventID).
-		WillReturnRows(countRows)
-
-	// Update event status to open
-	tc.mock.ExpectExec("UPDATE events SET status").
-		WithArgs(eventID, model.EventStatusOpen).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
+	tc.mock.ExpectCommit()
+
 	// Setup router
 	tc.router.DELETE("/events/:id/register", createAuthContext(userID.String(), "Test User"), tc.handler.CancelRegistration)
 
@@ -763,46 +969,118 @@ func TestCancelRegistration_Success_NoWaitlist(t *testing.T) {
 	if !response.Success {
 		t.Errorf("expected success, got error: %v", response.Error)
 	}
-}
-
-func TestCancelRegistration_Unauthorized(t *testing.T) {
-	tc := setupTestContext(t)
-	defer tc.cleanup()
-
-	eventID := uuid.New()
-
-	// Setup router WITHOUT auth middleware
-	tc.router.DELETE("/events/:id/register", tc.handler.CancelRegistration)
-
-	// Make request
-	req := httptest.NewRequest(http.MethodDelete, "/events/"+eventID.String()+"/register", nil)
-	recorder := httptest.NewRecorder()
-
-	tc.router.ServeHTTP(recorder, req)
 
-	// Assert response
-	if recorder.Code != http.StatusUnauthorized {
-		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, recorder.Code)
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
 	}
 }
 
-func TestCancelRegistration_NotRegistered(t *testing.T) {
+func TestCancelRegistration_AfterDeadline(t *testing.T) {
 	tc := setupTestContext(t)
 	defer tc.cleanup()
 
 	userID := uuid.New()
 	eventID := uuid.New()
+	regID := uuid.New()
 
-	// Find user's registration - not found
+	now := time.Now()
+
+	regRows := sqlmock.NewRows([]string{
+		"id", "event_id", "user_id", "status", "waitlist_position",
+		"registered_at", "confirmed_at", "cancelled_at",
+	}).AddRow(regID, eventID, userID, "confirmed", nil, now, now, nil)
 	tc.mock.ExpectQuery("SELECT .* FROM registrations WHERE event_id = .* AND user_id").
 		WithArgs(eventID, userID).
-		WillReturnError(sql.ErrNoRows)
+		WillReturnRows(regRows)
 
-	// Setup router
-	tc.router.DELETE("/events/:id/register", createAuthContext(userID.String(), "Test User"), tc.handler.CancelRegistration)
+	// Event starts in 6 hours with a 12 hour deadline
+	tc.expectCancelEvent(eventID, now.Add(6*time.Hour).Truncate(time.Minute), 12)
 
-	// Make request
-	req := httptest.NewRequest(http.MethodDelete, "/events/"+eventID.String()+"/register", nil)
+	tc.mock.ExpectBegin()
+	tc.mock.ExpectQuery("SELECT capacity, status FROM events WHERE id = .* FOR UPDATE").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(8, "open"))
+	tc.mock.ExpectQuery("SELECT .* FROM registrations WHERE id = .* FOR UPDATE").
+		WithArgs(regID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "event_id", "user_id", "status", "waitlist_position",
+			"registered_at", "confirmed_at", "cancelled_at",
+		}).AddRow(regID, eventID, userID, "confirmed", nil, now, now, nil))
+	tc.mock.ExpectExec("UPDATE registrations SET status = 'cancelled'").
+		WithArgs(regID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	tc.mock.ExpectQuery("SELECT .* FROM registrations").
+		WithArgs(eventID).
+		WillReturnError(sql.ErrNoRows)
+	tc.mock.ExpectExec("WITH held AS").
+		WithArgs(eventID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// The cancellation is flagged as late
+	tc.mock.ExpectExec("UPDATE registrations SET late_cancelled = TRUE").
+		WithArgs(regID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	tc.mock.ExpectCommit()
+
+	tc.router.DELETE("/events/:id/register", createAuthContext(userID.String(), "Test User"), tc.handler.CancelRegistration)
+
+	req := httptest.NewRequest(http.MethodDelete, "/events/"+eventID.String()+"/register", nil)
+	recorder := httptest.NewRecorder()
+
+	tc.router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+
+	data := parseResponse(t, recorder).Data.(map[string]interface{})
+	if data["late_cancellation"] != true {
+		t.Errorf("expected late_cancellation true, got %v", data["late_cancellation"])
+	}
+
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCancelRegistration_Unauthorized(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	eventID := uuid.New()
+
+	// Setup router WITHOUT auth middleware
+	tc.router.DELETE("/events/:id/register", tc.handler.CancelRegistration)
+
+	// Make request
+	req := httptest.NewRequest(http.MethodDelete, "/events/"+eventID.String()+"/register", nil)
+	recorder := httptest.NewRecorder()
+
+	tc.router.ServeHTTP(recorder, req)
+
+	// Assert response
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, recorder.Code)
+	}
+}
+
+func TestCancelRegistration_NotRegistered(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	userID := uuid.New()
+	eventID := uuid.New()
+
+	// Find user's registration - not found
+	tc.mock.ExpectQuery("SELECT .* FROM registrations WHERE event_id = .* AND user_id").
+		WithArgs(eventID, userID).
+		WillReturnError(sql.ErrNoRows)
+
+	// Setup router
+	tc.router.DELETE("/events/:id/register", createAuthContext(userID.String(), "Test User"), tc.handler.CancelRegistration)
+
+	// Make request
+	req := httptest.NewRequest(http.MethodDelete, "/events/"+eventID.String()+"/register", nil)
 	recorder := httptest.NewRecorder()
 
 	tc.router.ServeHTTP(recorder, req)
@@ -841,6 +1119,9 @@ func TestCancelRegistration_AlreadyCancelled(t *testing.T) {
 		WithArgs(eventID, userID).
 		WillReturnRows(regRows)
 
+	// Event lookup for the cancellation deadline
+	tc.expectCancelEvent(eventID, now.Add(48*time.Hour), nil)
+
 	// Transaction
 	tc.mock.ExpectBegin()
 
@@ -852,6 +1133,9 @@ func TestCancelRegistration_AlreadyCancelled(t *testing.T) {
 		regID, eventID, userID, "cancelled", nil,
 		now.Add(-2*time.Hour), nil, cancelledAt,
 	)
+	tc.mock.ExpectQuery("SELECT capacity, status FROM events WHERE id = .* FOR UPDATE").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(8, "open"))
 	tc.mock.ExpectQuery("SELECT .* FROM registrations WHERE id = .* FOR UPDATE").
 		WithArgs(regID).
 		WillReturnRows(lockRegRows)
@@ -878,6 +1162,331 @@ func TestCancelRegistration_AlreadyCancelled(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Waitlist Offer Handler Tests
+// =============================================================================
+
+func TestAcceptOffer_Success(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	userID := uuid.New()
+	eventID := uuid.New()
+	regID := uuid.New()
+	now := time.Now()
+
+	tc.mock.ExpectBegin()
+
+	// Lock the offered registration
+	regRows := sqlmock.NewRows([]string{
+		"id", "event_id", "user_id", "status", "waitlist_position",
+		"registered_at", "confirmed_at", "cancelled_at", "offer_expires_at",
+	}).AddRow(
+		regID, eventID, userID, "offered", nil,
+		now.Add(-time.Hour), nil, nil, now.Add(time.Hour),
+	)
+	tc.mock.ExpectQuery("SELECT .* FROM registrations WHERE event_id = .* AND user_id = .* FOR UPDATE").
+		WithArgs(eventID, userID).
+		WillReturnRows(regRows)
+
+	// Confirm the registration
+	tc.mock.ExpectQuery("UPDATE registrations").
+		WithArgs(regID).
+		WillReturnRows(sqlmock.NewRows([]string{"confirmed_at"}).AddRow(now))
+
+	tc.mock.ExpectCommit()
+
+	// Setup router
+	tc.router.POST("/events/:id/offer/accept", createAuthContext(userID.String(), "Test User"), tc.handler.AcceptOffer)
+
+	// Make request
+	req := httptest.NewRequest(http.MethodPost, "/events/"+eventID.String()+"/offer/accept", nil)
+	recorder := httptest.NewRecorder()
+
+	tc.router.ServeHTTP(recorder, req)
+
+	// Assert response
+	if recorder.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+
+	response := parseResponse(t, recorder)
+	if !response.Success {
+		t.Fatalf("expected success, got error: %v", response.Error)
+	}
+
+	data, ok := response.Data.(map[string]interface{})
+	if !ok {
+		t.Fatal("response data is not a map")
+	}
+	if data["status"] != "confirmed" {
+		t.Errorf("expected status confirmed, got %v", data["status"])
+	}
+
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAcceptOffer_Expired(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	userID := uuid.New()
+	eventID := uuid.New()
+	now := time.Now()
+
+	tc.mock.ExpectBegin()
+
+	// Lock the offered registration - hold window already passed
+	regRows := sqlmock.NewRows([]string{
+		"id", "event_id", "user_id", "status", "waitlist_position",
+		"registered_at", "confirmed_at", "cancelled_at", "offer_expires_at",
+	}).AddRow(
+		uuid.New(), eventID, userID, "offered", nil,
+		now.Add(-3*time.Hour), nil, nil, now.Add(-time.Minute),
+	)
+	tc.mock.ExpectQuery("SELECT .* FROM registrations WHERE event_id = .* AND user_id = .* FOR UPDATE").
+		WithArgs(eventID, userID).
+		WillReturnRows(regRows)
+
+	tc.mock.ExpectRollback()
+
+	// Setup router
+	tc.router.POST("/events/:id/offer/accept", createAuthContext(userID.String(), "Test User"), tc.handler.AcceptOffer)
+
+	// Make request
+	req := httptest.NewRequest(http.MethodPost, "/events/"+eventID.String()+"/offer/accept", nil)
+	recorder := httptest.NewRecorder()
+
+	tc.router.ServeHTTP(recorder, req)
+
+	// Assert response
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, recorder.Code)
+	}
+
+	response := parseResponse(t, recorder)
+	if response.Error == nil || response.Error.Code != "OFFER_EXPIRED" {
+		t.Errorf("expected error code OFFER_EXPIRED, got %v", response.Error)
+	}
+
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDeclineOffer_NoOffer(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	userID := uuid.New()
+	eventID := uuid.New()
+	now := time.Now()
+
+	tc.mock.ExpectBegin()
+
+	// Lock the event before the registration
+	tc.mock.ExpectQuery("SELECT capacity, status FROM events WHERE id = .* FOR UPDATE").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(8, "open"))
+
+	// Lock the registration - user is confirmed, not offered
+	regRows := sqlmock.NewRows([]string{
+		"id", "event_id", "user_id", "status", "waitlist_position",
+		"registered_at", "confirmed_at", "cancelled_at",
+	}).AddRow(
+		uuid.New(), eventID, userID, "confirmed", nil,
+		now, now, nil,
+	)
+	tc.mock.ExpectQuery("SELECT .* FROM registrations WHERE event_id = .* AND user_id = .* FOR UPDATE").
+		WithArgs(eventID, userID).
+		WillReturnRows(regRows)
+
+	tc.mock.ExpectRollback()
+
+	// Setup router
+	tc.router.POST("/events/:id/offer/decline", createAuthContext(userID.String(), "Test User"), tc.handler.DeclineOffer)
+
+	// Make request
+	req := httptest.NewRequest(http.MethodPost, "/events/"+eventID.String()+"/offer/decline", nil)
+	recorder := httptest.NewRecorder()
+
+	tc.router.ServeHTTP(recorder, req)
+
+	// Assert response
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, recorder.Code)
+	}
+
+	response := parseResponse(t, recorder)
+	if response.Error == nil || response.Error.Code != "NO_OFFER" {
+		t.Errorf("expected error code NO_OFFER, got %v", response.Error)
+	}
+
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+// =============================================================================
+// Registration Request Handler Tests
+// =============================================================================
+
+func TestApproveRegistration_NotHost(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	userID := uuid.New()
+	eventID := uuid.New()
+
+	expectEventRole(tc.mock, eventID, userID, "")
+
+	tc.router.POST("/events/:id/registrations/:registrationId/approve", createAuthContext(userID.String(), "Test User"), tc.handler.ApproveRegistration)
+
+	path := "/events/" + eventID.String() + "/registrations/" + uuid.NewString() + "/approve"
+	req := httptest.NewRequest(http.MethodPost, path, nil)
+	recorder := httptest.NewRecorder()
+
+	tc.router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", http.StatusForbidden, recorder.Code)
+	}
+
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRejectRegistration_NotPending(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	hostID := uuid.New()
+	eventID := uuid.New()
+	regID := uuid.New()
+
+	expectEventRole(tc.mock, eventID, hostID, model.RoleHost)
+	tc.mock.ExpectBegin()
+	tc.mock.ExpectQuery("SELECT \\* FROM registrations WHERE id = .* FOR UPDATE").
+		WithArgs(regID, eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status"}).
+			AddRow(regID, eventID, uuid.New(), model.RegistrationConfirmed))
+	tc.mock.ExpectRollback()
+
+	tc.router.POST("/events/:id/registrations/:registrationId/reject", createAuthContext(hostID.String(), "Host"), tc.handler.RejectRegistration)
+
+	path := "/events/" + eventID.String() + "/registrations/" + regID.String() + "/reject"
+	req := httptest.NewRequest(http.MethodPost, path, nil)
+	recorder := httptest.NewRecorder()
+
+	tc.router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d", http.StatusConflict, recorder.Code)
+	}
+
+	response := parseResponse(t, recorder)
+	if response.Error == nil || response.Error.Code != "NOT_PENDING" {
+		t.Errorf("expected error code NOT_PENDING, got %v", response.Error)
+	}
+
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRemoveRegistration(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	hostID := uuid.New()
+	eventID := uuid.New()
+	regID := uuid.New()
+
+	expectEventRole(tc.mock, eventID, hostID, model.RoleHost)
+	tc.mock.ExpectBegin()
+	tc.mock.ExpectQuery("SELECT capacity, status FROM events WHERE id = .* FOR UPDATE").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(4, "full"))
+	tc.mock.ExpectQuery("SELECT \\* FROM registrations WHERE id = .* AND event_id = .* FOR UPDATE").
+		WithArgs(regID, eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status", "waitlist_position"}).
+			AddRow(regID, eventID, uuid.New(), model.RegistrationWaitlist, 2))
+	tc.mock.ExpectExec("UPDATE registrations SET status = 'cancelled'").
+		WithArgs(regID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	tc.mock.ExpectExec("SET waitlist_position = waitlist_position - 1").
+		WithArgs(eventID, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	tc.expectVisibleEvent(eventID, hostID, model.VisibilityPublic)
+	tc.mock.ExpectExec("INSERT INTO outbox").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	tc.mock.ExpectCommit()
+
+	tc.router.POST("/events/:id/registrations/:registrationId/remove", createAuthContext(hostID.String(), "Host"), tc.handler.RemoveRegistration)
+
+	path := "/events/" + eventID.String() + "/registrations/" + regID.String() + "/remove"
+	req := httptest.NewRequest(http.MethodPost, path, jsonBody(map[string]string{"reason": "Registered by mistake"}))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+
+	tc.router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPromoteRegistration_NotEnoughSeats(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	coHostID := uuid.New()
+	eventID := uuid.New()
+	regID := uuid.New()
+
+	expectEventRole(tc.mock, eventID, coHostID, model.RoleCoHost)
+	tc.mock.ExpectBegin()
+	tc.mock.ExpectQuery("SELECT capacity, status FROM events WHERE id = .* FOR UPDATE").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(4, "full"))
+	tc.mock.ExpectQuery("SELECT \\* FROM registrations WHERE id = .* AND event_id = .* FOR UPDATE").
+		WithArgs(regID, eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status", "waitlist_position"}).
+			AddRow(regID, eventID, uuid.New(), model.RegistrationWaitlist, 1))
+	tc.mock.ExpectQuery("SELECT COALESCE\\(SUM\\(1 \\+ guest_count\\), 0\\) FROM registrations").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+	tc.mock.ExpectRollback()
+
+	tc.router.POST("/events/:id/registrations/:registrationId/promote", createAuthContext(coHostID.String(), "Co-host"), tc.handler.PromoteRegistration)
+
+	path := "/events/" + eventID.String() + "/registrations/" + regID.String() + "/promote"
+	req := httptest.NewRequest(http.MethodPost, path, nil)
+	recorder := httptest.NewRecorder()
+
+	tc.router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d", http.StatusConflict, recorder.Code)
+	}
+
+	response := parseResponse(t, recorder)
+	if response.Error == nil || response.Error.Code != "NOT_ENOUGH_SEATS" {
+		t.Errorf("expected error code NOT_ENOUGH_SEATS, got %v", response.Error)
+	}
+
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
 // =============================================================================
 // GetEventRegistrations Handler Tests
 // =============================================================================
@@ -890,28 +1499,33 @@ func TestGetEventRegistrations_Success(t *testing.T) {
 	user1ID := uuid.New()
 	user2ID := uuid.New()
 	user3ID := uuid.New()
+	reg1ID := uuid.New()
 
 	now := time.Now()
 
 	// Check event exists
-	existsRows := sqlmock.NewRows([]string{"exists"}).AddRow(true)
-	tc.mock.ExpectQuery("SELECT EXISTS").
-		WithArgs(eventID).
-		WillReturnRows(existsRows)
+	tc.expectVisibleEvent(eventID, uuid.New(), model.VisibilityPublic)
 
 	// Get registrations with users
 	regRows := sqlmock.NewRows([]string{
 		"id", "event_id", "user_id", "status", "waitlist_position",
-		"registered_at", "confirmed_at", "cancelled_at",
+		"registered_at", "confirmed_at", "cancelled_at", "checked_in_at", "attendance", "guest_count", "group_id",
 		"user.id", "user.display_name", "user.avatar_url",
 	}).
-		AddRow(uuid.New(), eventID, user1ID, "confirmed", nil, now, now, nil, user1ID, "User 1", nil).
-		AddRow(uuid.New(), eventID, user2ID, "confirmed", nil, now, now, nil, user2ID, "User 2", nil).
-		AddRow(uuid.New(), eventID, user3ID, "waitlist", 1, now, nil, nil, user3ID, "User 3", nil)
+		AddRow(reg1ID, eventID, user1ID, "confirmed", nil, now, now, nil, now, nil, 1, nil, user1ID, "User 1", nil).
+		AddRow(uuid.New(), eventID, user2ID, "confirmed", nil, now, now, nil, nil, nil, 0, nil, user2ID, "User 2", nil).
+		AddRow(uuid.New(), eventID, user3ID, "waitlist", 1, now, nil, nil, nil, nil, 0, nil, user3ID, "User 3", nil)
 	tc.mock.ExpectQuery("SELECT").
 		WithArgs(eventID).
 		WillReturnRows(regRows)
 
+	// User 1's guest takes a seat too
+	tc.expectRegistrationStats(eventID, 3, 1)
+	tc.mock.ExpectQuery("SELECT .* FROM registration_guests").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "registration_id", "name", "created_at", "cancelled_at"}).
+			AddRow(uuid.New(), reg1ID, "Guest", now, nil))
+
 	// Setup router (no auth required for viewing registrations)
 	tc.router.GET("/events/:id/registrations", tc.handler.GetEventRegistrations)
 
@@ -939,8 +1553,8 @@ func TestGetEventRegistrations_Success(t *testing.T) {
 	confirmedCount := int(data["confirmed_count"].(float64))
 	waitlistCount := int(data["waitlist_count"].(float64))
 
-	if confirmedCount != 2 {
-		t.Errorf("expected confirmed_count 2, got %d", confirmedCount)
+	if confirmedCount != 3 {
+		t.Errorf("expected confirmed_count 3, got %d", confirmedCount)
 	}
 	if waitlistCount != 1 {
 		t.Errorf("expected waitlist_count 1, got %d", waitlistCount)
@@ -954,10 +1568,9 @@ func TestGetEventRegistrations_EventNotFound(t *testing.T) {
 	eventID := uuid.New()
 
 	// Check event exists - not found
-	existsRows := sqlmock.NewRows([]string{"exists"}).AddRow(false)
-	tc.mock.ExpectQuery("SELECT EXISTS").
+	tc.mock.ExpectQuery("SELECT .* FROM events WHERE id").
 		WithArgs(eventID).
-		WillReturnRows(existsRows)
+		WillReturnError(sql.ErrNoRows)
 
 	// Setup router
 	tc.router.GET("/events/:id/registrations", tc.handler.GetEventRegistrations)
@@ -1010,10 +1623,7 @@ func TestGetEventRegistrations_EmptyList(t *testing.T) {
 	eventID := uuid.New()
 
 	// Check event exists
-	existsRows := sqlmock.NewRows([]string{"exists"}).AddRow(true)
-	tc.mock.ExpectQuery("SELECT EXISTS").
-		WithArgs(eventID).
-		WillReturnRows(existsRows)
+	tc.expectVisibleEvent(eventID, uuid.New(), model.VisibilityPublic)
 
 	// Get registrations - empty
 	regRows := sqlmock.NewRows([]string{
@@ -1024,6 +1634,7 @@ func TestGetEventRegistrations_EmptyList(t *testing.T) {
 	tc.mock.ExpectQuery("SELECT").
 		WithArgs(eventID).
 		WillReturnRows(regRows)
+	tc.expectRegistrationStats(eventID, 0, 0)
 
 	// Setup router
 	tc.router.GET("/events/:id/registrations", tc.handler.GetEventRegistrations)
@@ -1060,10 +1671,123 @@ func TestGetEventRegistrations_EmptyList(t *testing.T) {
 	}
 }
 
+func TestGetEventRegistrations_HostSeesReliability(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	eventID := uuid.New()
+	hostID := uuid.New()
+	playerID := uuid.New()
+	now := time.Now()
+
+	tc.expectVisibleEvent(eventID, hostID, model.VisibilityPublic)
+
+	tc.mock.ExpectQuery("SELECT").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "event_id", "user_id", "status", "waitlist_position",
+			"registered_at", "confirmed_at", "cancelled_at", "checked_in_at", "attendance", "guest_count", "group_id",
+			"user.id", "user.display_name", "user.avatar_url",
+		}).AddRow(uuid.New(), eventID, playerID, "confirmed", nil, now, now, nil, nil, "no_show", 0, nil, playerID, "Player", nil))
+	tc.expectRegistrationStats(eventID, 1, 0)
+
+	tc.mock.ExpectQuery("SELECT r.user_id").
+		WithArgs(sqlmock.AnyArg(), "UTC", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "attended", "late_cancelled", "no_shows"}).
+			AddRow(playerID, 3, 0, 1))
+
+	lateUserID := uuid.New()
+	tc.mock.ExpectQuery("SELECT .* r.late_cancelled").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "event_id", "user_id", "status", "registered_at", "confirmed_at", "cancelled_at",
+			"user.id", "user.display_name", "user.avatar_url",
+		}).AddRow(uuid.New(), eventID, lateUserID, "cancelled", now, now, now, lateUserID, "Late Player", nil))
+
+	tc.router.GET("/events/:id/registrations", createAuthContext(hostID.String(), "Host"), tc.handler.GetEventRegistrations)
+
+	req := httptest.NewRequest(http.MethodGet, "/events/"+eventID.String()+"/registrations", nil)
+	recorder := httptest.NewRecorder()
+
+	tc.router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+
+	data := parseResponse(t, recorder).Data.(map[string]interface{})
+	player := data["confirmed"].([]interface{})[0].(map[string]interface{})
+	if player["attendance"] != "no_show" {
+		t.Errorf("expected attendance no_show, got %v", player["attendance"])
+	}
+	reliability, ok := player["reliability"].(map[string]interface{})
+	if !ok {
+		t.Fatal("expected reliability for the host")
+	}
+	if reliability["score"] != float64(75) {
+		t.Errorf("expected score 75, got %v", reliability["score"])
+	}
+	if data["late_cancellation_count"] != float64(1) {
+		t.Errorf("expected 1 late cancellation, got %v", data["late_cancellation_count"])
+	}
+
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
 // =============================================================================
 // Edge Case Tests
 // =============================================================================
 
+func TestGetEventRegistrations_PrivateEventHidesParticipants(t *testing.T) {
+	tc := setupTestContext(t)
+	defer tc.cleanup()
+
+	eventID := uuid.New()
+	playerID := uuid.New()
+	strangerID := uuid.New()
+	now := time.Now()
+
+	tc.expectVisibleEvent(eventID, uuid.New(), model.VisibilityUnlisted)
+
+	tc.mock.ExpectQuery("SELECT").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "event_id", "user_id", "status", "waitlist_position",
+			"registered_at", "confirmed_at", "cancelled_at", "checked_in_at", "attendance", "guest_count", "group_id",
+			"user.id", "user.display_name", "user.avatar_url",
+		}).AddRow(uuid.New(), eventID, playerID, "confirmed", nil, now, now, nil, nil, nil, 0, nil, playerID, "Player", nil))
+	tc.expectRegistrationStats(eventID, 1, 0)
+	expectEventRole(tc.mock, eventID, strangerID, "")
+
+	tc.router.GET("/events/:id/registrations", createAuthContext(strangerID.String(), "Stranger"), tc.handler.GetEventRegistrations)
+
+	req := httptest.NewRequest(http.MethodGet, "/events/"+eventID.String()+"/registrations", nil)
+	recorder := httptest.NewRecorder()
+
+	tc.router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+
+	data := parseResponse(t, recorder).Data.(map[string]interface{})
+	if confirmed := data["confirmed"].([]interface{}); len(confirmed) != 0 {
+		t.Errorf("expected participants to be hidden, got %v", confirmed)
+	}
+	if data["confirmed_count"] != float64(1) {
+		t.Errorf("expected confirmed_count 1, got %v", data["confirmed_count"])
+	}
+	if data["participants_hidden"] != true {
+		t.Errorf("expected participants_hidden, got %v", data["participants_hidden"])
+	}
+
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
 func TestRegisterEvent_DatabaseError(t *testing.T) {
 	tc := setupTestContext(t)
 	defer tc.cleanup()
@@ -1132,7 +1856,48 @@ func TestCancelRegistration_DatabaseError(t *testing.T) {
 // Helper for request body
 // =============================================================================
 
+// expectCancelEvent mocks loading an event starting at start with an optional
+// cancellation deadline in hours
+func (tc *testContext) expectCancelEvent(eventID uuid.UUID, start time.Time, deadlineHours interface{}) {
+	now := time.Now()
+	start = start.UTC()
+	tc.mock.ExpectQuery("SELECT .* FROM events WHERE id").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "host_id", "short_code", "title", "description", "event_date", "start_time", "end_time",
+			"location_name", "location_address", "latitude", "longitude", "google_place_id",
+			"capacity", "skill_level", "fee", "status", "cancel_deadline_hours", "created_at", "updated_at",
+		}).AddRow(
+			eventID, uuid.New(), "abc123", nil, nil, start, start.Format("15:04"), nil,
+			"Test Location", nil, 25.033, 121.565, nil,
+			4, "beginner", 200, "open", deadlineHours, now, now,
+		))
+}
+
+// expectVisibleEvent mocks loading an event with the given host and visibility
+func (tc *testContext) expectVisibleEvent(eventID, hostID uuid.UUID, visibility model.EventVisibility) {
+	now := time.Now()
+	tc.mock.ExpectQuery("SELECT .* FROM events WHERE id").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "host_id", "short_code", "title", "description", "event_date", "start_time", "end_time",
+			"location_name", "location_address", "latitude", "longitude", "google_place_id",
+			"capacity", "skill_level", "fee", "status", "visibility", "created_at", "updated_at",
+		}).AddRow(
+			eventID, hostID, "abc123", nil, nil, now, "20:00", nil,
+			"Test Location", nil, 25.033, 121.565, nil,
+			8, "beginner", 200, "open", visibility, now, now,
+		))
+}
+
 func jsonBody(v interface{}) *bytes.Buffer {
 	data, _ := json.Marshal(v)
 	return bytes.NewBuffer(data)
 }
+
+// expectRegistrationStats mocks the seat counts for an event
+func (tc *testContext) expectRegistrationStats(eventID uuid.UUID, confirmed, waitlist int) {
+	tc.mock.ExpectQuery("SELECT .* as confirmed_count").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"confirmed_count", "waitlist_count"}).AddRow(confirmed, waitlist))
+}
@@ -0,0 +1,373 @@
+package handler
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/dto"
+	"github.com/anthropics/pickle-go/apps/api/internal/middleware"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/internal/service"
+	"github.com/anthropics/pickle-go/apps/api/pkg/recurrence"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// SeriesHandler handles recurring event series requests
+type SeriesHandler struct {
+	seriesRepo    *repository.SeriesRepository
+	seriesService *service.SeriesService
+}
+
+// NewSeriesHandler creates a new SeriesHandler
+func NewSeriesHandler(seriesRepo *repository.SeriesRepository, seriesService *service.SeriesService) *SeriesHandler {
+	return &SeriesHandler{
+		seriesRepo:    seriesRepo,
+		seriesService: seriesService,
+	}
+}
+
+// CreateSeries creates a recurring event series and materializes its first occurrences
+// POST /api/v1/series
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	var req dto.CreateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	startsOn, err := time.Parse("2006-01-02", req.Recurrence.StartsOn)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid starts_on date format"))
+		return
+	}
+
+	series := &model.EventSeries{
+		ID:           uuid.New(),
+		HostID:       userID,
+		StartTime:    req.StartTime,
+		LocationName: req.Location.Name,
+		Latitude:     req.Location.Lat,
+		Longitude:    req.Location.Lng,
+		Capacity:     req.Capacity,
+		SkillLevel:   model.SkillLevel(req.SkillLevel),
+		Fee:          req.Fee,
+		Frequency:    recurrence.Frequency(req.Recurrence.Frequency),
+		Weekdays:     pq.Int64Array{},
+		StartsOn:     startsOn,
+		Status:       model.SeriesStatusActive,
+	}
+
+	if req.Title != "" {
+		series.Title = &req.Title
+	}
+	if req.Description != "" {
+		series.Description = &req.Description
+	}
+	if req.EndTime != "" {
+		series.EndTime = &req.EndTime
+	}
+	if req.Location.Address != "" {
+		series.LocationAddress = &req.Location.Address
+	}
+	if req.Location.GooglePlaceID != "" {
+		series.GooglePlaceID = &req.Location.GooglePlaceID
+	}
+	for _, wd := range req.Recurrence.Weekdays {
+		series.Weekdays = append(series.Weekdays, int64(wd))
+	}
+	if req.Recurrence.MonthDay != 0 {
+		series.MonthDay = &req.Recurrence.MonthDay
+	}
+	if req.Recurrence.EndsOn != "" {
+		endsOn, err := time.Parse("2006-01-02", req.Recurrence.EndsOn)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid ends_on date format"))
+			return
+		}
+		series.EndsOn = &endsOn
+	}
+
+	exceptions := make([]time.Time, 0, len(req.Recurrence.Exceptions))
+	for _, s := range req.Recurrence.Exceptions {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid exception date format"))
+			return
+		}
+		exceptions = append(exceptions, d)
+	}
+
+	if err := h.seriesService.CreateSeries(c.Request.Context(), series, exceptions); err != nil {
+		switch {
+		case errors.Is(err, recurrence.ErrInvalidFrequency),
+			errors.Is(err, recurrence.ErrMissingWeekdays),
+			errors.Is(err, recurrence.ErrInvalidMonthDay),
+			errors.Is(err, recurrence.ErrInvalidRange):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		default:
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to create series"))
+		}
+		return
+	}
+
+	h.respondWithSeries(c, http.StatusCreated, series)
+}
+
+// GetSeries returns a series with its occurrences
+// GET /api/v1/series/:id
+func (h *SeriesHandler) GetSeries(c *gin.Context) {
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid series ID"))
+		return
+	}
+
+	series, err := h.seriesRepo.FindByID(c.Request.Context(), seriesID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Series not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch series"))
+		return
+	}
+
+	h.respondWithSeries(c, http.StatusOK, series)
+}
+
+// UpdateOccurrence edits either a single occurrence or all future occurrences
+// PUT /api/v1/series/:id/occurrences/:eventId
+func (h *SeriesHandler) UpdateOccurrence(c *gin.Context) {
+	series, ok := h.authorizeHost(c)
+	if !ok {
+		return
+	}
+
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return
+	}
+
+	var req dto.UpdateSeriesOccurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	changes := service.SeriesChanges{
+		Title:       req.Title,
+		Description: req.Description,
+		StartTime:   req.StartTime,
+		EndTime:     req.EndTime,
+		Capacity:    req.Capacity,
+		Fee:         req.Fee,
+		// Refuse by default so lowering capacity never drops confirmed players silently
+		CapacityPolicy: model.CapacityPolicyRefuse,
+	}
+	if req.CapacityPolicy != nil {
+		changes.CapacityPolicy = model.CapacityPolicy(*req.CapacityPolicy)
+	}
+	if req.SkillLevel != nil {
+		skillLevel := model.SkillLevel(*req.SkillLevel)
+		changes.SkillLevel = &skillLevel
+	}
+
+	var updated []uuid.UUID
+	if req.Scope == "this" {
+		_, err = h.seriesService.UpdateOccurrence(c.Request.Context(), series.ID, eventID, changes)
+		updated = []uuid.UUID{eventID}
+	} else {
+		updated, err = h.seriesService.UpdateFutureOccurrences(c.Request.Context(), series.ID, eventID, changes)
+	}
+	if err != nil {
+		h.respondWithServiceError(c, err, "Failed to update occurrence")
+		return
+	}
+
+	updatedIDs := make([]string, 0, len(updated))
+	for _, id := range updated {
+		updatedIDs = append(updatedIDs, id.String())
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"scope":             req.Scope,
+		"updated_event_ids": updatedIDs,
+		"message":           "Occurrence updated successfully",
+	}))
+}
+
+// CancelOccurrence cancels a single occurrence of a series
+// DELETE /api/v1/series/:id/occurrences/:eventId
+func (h *SeriesHandler) CancelOccurrence(c *gin.Context) {
+	series, ok := h.authorizeHost(c)
+	if !ok {
+		return
+	}
+
+	eventID, err := uuid.Parse(c.Param("eventId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return
+	}
+
+	if err := h.seriesService.CancelOccurrence(c.Request.Context(), series.ID, eventID); err != nil {
+		h.respondWithServiceError(c, err, "Failed to cancel occurrence")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"message": "Occurrence cancelled successfully",
+	}))
+}
+
+// EndSeries stops a series and cancels its upcoming occurrences
+// DELETE /api/v1/series/:id
+func (h *SeriesHandler) EndSeries(c *gin.Context) {
+	series, ok := h.authorizeHost(c)
+	if !ok {
+		return
+	}
+
+	if series.Status == model.SeriesStatusEnded {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("SERIES_ENDED", "Series has already ended"))
+		return
+	}
+
+	if err := h.seriesService.EndSeries(c.Request.Context(), series.ID); err != nil {
+		h.respondWithServiceError(c, err, "Failed to end series")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"message": "Series ended successfully",
+	}))
+}
+
+// authorizeHost loads the series from the path and checks the current user is its host.
+// It writes the error response and returns false if the request should stop.
+func (h *SeriesHandler) authorizeHost(c *gin.Context) (*model.EventSeries, bool) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return nil, false
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return nil, false
+	}
+
+	seriesID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid series ID"))
+		return nil, false
+	}
+
+	series, err := h.seriesRepo.FindByID(c.Request.Context(), seriesID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Series not found"))
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch series"))
+		return nil, false
+	}
+
+	if series.HostID != userID {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "You are not the host of this series"))
+		return nil, false
+	}
+
+	return series, true
+}
+
+// respondWithServiceError maps series service errors to API responses
+func (h *SeriesHandler) respondWithServiceError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrOccurrenceNotFound), errors.Is(err, sql.ErrNoRows):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Occurrence not found"))
+	case errors.Is(err, service.ErrOccurrenceClosed):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("OCCURRENCE_CLOSED", "Occurrence has already been cancelled or completed"))
+	case errors.Is(err, service.ErrSeriesEnded):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("SERIES_ENDED", "Series has already ended"))
+	case errors.Is(err, repository.ErrCapacityBelowHeld):
+		c.JSON(http.StatusConflict, dto.ErrorResponse("CAPACITY_BELOW_CONFIRMED", "Capacity is below the number of confirmed players; use capacity_policy demote to move the latest confirmed players to the waitlist"))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", fallback))
+	}
+}
+
+// respondWithSeries writes a series and its occurrences
+func (h *SeriesHandler) respondWithSeries(c *gin.Context, status int, series *model.EventSeries) {
+	occurrences, err := h.seriesRepo.FindOccurrences(c.Request.Context(), series.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch occurrences"))
+		return
+	}
+
+	resp := dto.SeriesResponse{
+		ID:          series.ID.String(),
+		Title:       series.Title,
+		Description: series.Description,
+		StartTime:   series.StartTime,
+		EndTime:     series.EndTime,
+		Location: dto.LocationResponse{
+			Name:          series.LocationName,
+			Address:       series.LocationAddress,
+			Lat:           series.Latitude,
+			Lng:           series.Longitude,
+			GooglePlaceID: series.GooglePlaceID,
+		},
+		Capacity:    series.Capacity,
+		SkillLevel:  string(series.SkillLevel),
+		Fee:         series.Fee,
+		Frequency:   string(series.Frequency),
+		Weekdays:    series.Weekdays,
+		MonthDay:    series.MonthDay,
+		StartsOn:    series.StartsOn.Format("2006-01-02"),
+		Status:      string(series.Status),
+		Occurrences: make([]dto.SeriesOccurrenceResponse, 0, len(occurrences)),
+	}
+	if series.EndsOn != nil {
+		endsOn := series.EndsOn.Format("2006-01-02")
+		resp.EndsOn = &endsOn
+	}
+
+	for _, occ := range occurrences {
+		item := dto.SeriesOccurrenceResponse{
+			Date:        occ.OccurrenceDate.Format("2006-01-02"),
+			IsException: occ.IsException,
+			IsDetached:  occ.IsDetached,
+		}
+		if occ.EventID != nil {
+			eventID := occ.EventID.String()
+			item.EventID = &eventID
+		}
+		if occ.EventStatus != nil {
+			eventStatus := string(*occ.EventStatus)
+			item.EventStatus = &eventStatus
+		}
+		resp.Occurrences = append(resp.Occurrences, item)
+	}
+
+	c.JSON(status, dto.SuccessResponse(resp))
+}
@@ -0,0 +1,242 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// seriesTestContext holds the series handler and its mocked database
+type seriesTestContext struct {
+	handler *SeriesHandler
+	mock    sqlmock.Sqlmock
+	db      *sqlx.DB
+}
+
+func setupSeriesTestContext(t *testing.T) *seriesTestContext {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+
+	db := sqlx.NewDb(mockDB, "postgres")
+	seriesRepo := repository.NewSeriesRepository(db)
+	seriesService := service.NewSeriesService(seriesRepo, repository.NewEventRepository(db), repository.NewRegistrationRepository(db),
+		repository.NewOutboxRepository(db), database.NewTxManager(db))
+	return &seriesTestContext{
+		handler: NewSeriesHandler(seriesRepo, seriesService),
+		mock:    mock,
+		db:      db,
+	}
+}
+
+var seriesTestColumns = []string{
+	"id", "host_id", "start_time", "location_name", "capacity", "skill_level", "fee",
+	"frequency", "weekdays", "starts_on", "status", "created_at", "updated_at",
+}
+
+func seriesRow(seriesID, hostID uuid.UUID, status model.SeriesStatus) *sqlmock.Rows {
+	now := time.Now()
+	return sqlmock.NewRows(seriesTestColumns).AddRow(
+		seriesID, hostID, "19:00", "Test Location", 8, "beginner", 200,
+		"weekly", "{2}", now, status, now, now,
+	)
+}
+
+// expectSeries mocks loading a series
+func (tc *seriesTestContext) expectSeries(seriesID, hostID uuid.UUID, status model.SeriesStatus) {
+	tc.mock.ExpectQuery("SELECT .* FROM event_series WHERE id = \\$1$").
+		WithArgs(seriesID).
+		WillReturnRows(seriesRow(seriesID, hostID, status))
+}
+
+// expectOccurrence mocks looking up the series occurrence an event was materialized for
+func (tc *seriesTestContext) expectOccurrence(seriesID, eventID uuid.UUID) {
+	tc.mock.ExpectQuery("SELECT .* FROM event_series_occurrences WHERE event_id").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "series_id", "occurrence_date", "event_id", "is_exception", "is_detached", "created_at",
+		}).AddRow(uuid.New(), seriesID, time.Now(), eventID, false, false, time.Now()))
+}
+
+func (tc *seriesTestContext) serve(method, path, route string, userID uuid.UUID, body interface{}, handle gin.HandlerFunc) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.Handle(method, route, createAuthContext(userID.String(), "Host"), handle)
+
+	req := httptest.NewRequest(method, path, jsonBody(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestCreateSeries_ValidationErrors(t *testing.T) {
+	location := map[string]interface{}{"name": "Test Location", "lat": 25.033, "lng": 121.565}
+	tests := []struct {
+		name string
+		body map[string]interface{}
+	}{
+		{
+			name: "missing start time",
+			body: map[string]interface{}{
+				"location": location, "capacity": 8, "skill_level": "beginner",
+				"recurrence": map[string]interface{}{"frequency": "weekly", "weekdays": []int{2}, "starts_on": "2026-01-06"},
+			},
+		},
+		{
+			name: "invalid starts_on",
+			body: map[string]interface{}{
+				"start_time": "19:00", "location": location, "capacity": 8, "skill_level": "beginner",
+				"recurrence": map[string]interface{}{"frequency": "weekly", "weekdays": []int{2}, "starts_on": "06/01/2026"},
+			},
+		},
+		{
+			name: "month day out of range",
+			body: map[string]interface{}{
+				"start_time": "19:00", "location": location, "capacity": 8, "skill_level": "beginner",
+				"recurrence": map[string]interface{}{"frequency": "monthly", "month_day": 32, "starts_on": "2026-01-06"},
+			},
+		},
+		{
+			name: "weekly without weekdays",
+			body: map[string]interface{}{
+				"start_time": "19:00", "location": location, "capacity": 8, "skill_level": "beginner",
+				"recurrence": map[string]interface{}{"frequency": "weekly", "starts_on": "2026-01-06"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := setupSeriesTestContext(t)
+			defer tc.db.Close()
+
+			recorder := tc.serve(http.MethodPost, "/series", "/series", uuid.New(), tt.body, tc.handler.CreateSeries)
+
+			if recorder.Code != http.StatusBadRequest {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusBadRequest, recorder.Code, recorder.Body.String())
+			}
+			if code := parseResponse(t, recorder).Error.Code; code != "VALIDATION_ERROR" {
+				t.Errorf("expected VALIDATION_ERROR, got %s", code)
+			}
+			if err := tc.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdateOccurrence_NotHost(t *testing.T) {
+	tc := setupSeriesTestContext(t)
+	defer tc.db.Close()
+
+	seriesID, eventID := uuid.New(), uuid.New()
+	tc.expectSeries(seriesID, uuid.New(), model.SeriesStatusActive)
+
+	path := "/series/" + seriesID.String() + "/occurrences/" + eventID.String()
+	body := map[string]interface{}{"scope": "this", "title": "Rematch"}
+	recorder := tc.serve(http.MethodPut, path, "/series/:id/occurrences/:eventId", uuid.New(), body, tc.handler.UpdateOccurrence)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d", http.StatusForbidden, recorder.Code)
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdateOccurrence_CancelledOccurrence(t *testing.T) {
+	tc := setupSeriesTestContext(t)
+	defer tc.db.Close()
+
+	seriesID, hostID, eventID := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+
+	tc.expectSeries(seriesID, hostID, model.SeriesStatusActive)
+	tc.expectOccurrence(seriesID, eventID)
+	tc.mock.ExpectBegin()
+	tc.mock.ExpectQuery("SELECT .* FROM events WHERE id = .* FOR UPDATE").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "host_id", "short_code", "event_date", "start_time",
+			"location_name", "latitude", "longitude", "capacity", "skill_level", "fee", "status", "created_at", "updated_at",
+		}).AddRow(
+			eventID, hostID, "abc123", now.Add(48*time.Hour), "19:00",
+			"Test Location", 25.033, 121.565, 8, "beginner", 200, "cancelled", now, now,
+		))
+	tc.mock.ExpectRollback()
+
+	path := "/series/" + seriesID.String() + "/occurrences/" + eventID.String()
+	body := map[string]interface{}{"scope": "this", "title": "Rematch"}
+	recorder := tc.serve(http.MethodPut, path, "/series/:id/occurrences/:eventId", hostID, body, tc.handler.UpdateOccurrence)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusBadRequest, recorder.Code, recorder.Body.String())
+	}
+	if code := parseResponse(t, recorder).Error.Code; code != "OCCURRENCE_CLOSED" {
+		t.Errorf("expected OCCURRENCE_CLOSED, got %s", code)
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestUpdateOccurrence_FutureOnEndedSeries(t *testing.T) {
+	tc := setupSeriesTestContext(t)
+	defer tc.db.Close()
+
+	seriesID, hostID, eventID := uuid.New(), uuid.New(), uuid.New()
+
+	// The series ends between loading it and locking it
+	tc.expectSeries(seriesID, hostID, model.SeriesStatusActive)
+	tc.expectOccurrence(seriesID, eventID)
+	tc.mock.ExpectBegin()
+	tc.mock.ExpectQuery("SELECT .* FROM event_series WHERE id = .* FOR UPDATE").
+		WithArgs(seriesID).
+		WillReturnRows(seriesRow(seriesID, hostID, model.SeriesStatusEnded))
+	tc.mock.ExpectRollback()
+
+	path := "/series/" + seriesID.String() + "/occurrences/" + eventID.String()
+	body := map[string]interface{}{"scope": "future", "fee": 250}
+	recorder := tc.serve(http.MethodPut, path, "/series/:id/occurrences/:eventId", hostID, body, tc.handler.UpdateOccurrence)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusBadRequest, recorder.Code, recorder.Body.String())
+	}
+	if code := parseResponse(t, recorder).Error.Code; code != "SERIES_ENDED" {
+		t.Errorf("expected SERIES_ENDED, got %s", code)
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestEndSeries_AlreadyEnded(t *testing.T) {
+	tc := setupSeriesTestContext(t)
+	defer tc.db.Close()
+
+	seriesID, hostID := uuid.New(), uuid.New()
+	tc.expectSeries(seriesID, hostID, model.SeriesStatusEnded)
+
+	recorder := tc.serve(http.MethodDelete, "/series/"+seriesID.String(), "/series/:id", hostID, nil, tc.handler.EndSeries)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusBadRequest, recorder.Code, recorder.Body.String())
+	}
+	if code := parseResponse(t, recorder).Error.Code; code != "SERIES_ENDED" {
+		t.Errorf("expected SERIES_ENDED, got %s", code)
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/dto"
+	"github.com/anthropics/pickle-go/apps/api/internal/middleware"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/realtime"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// streamHeartbeat is how often an idle stream sends a comment so proxies keep it open
+const streamHeartbeat = 25 * time.Second
+
+// StreamHandler handles live Server-Sent Event streams
+type StreamHandler struct {
+	hub              *realtime.Hub
+	shutdown         <-chan struct{}
+	eventRepo        *repository.EventRepository
+	registrationRepo *repository.RegistrationRepository
+	notificationRepo *repository.NotificationRepository
+}
+
+// NewStreamHandler creates a new StreamHandler. Open streams end when shutdown
+// is closed, so they do not hold up a graceful server shutdown.
+func NewStreamHandler(hub *realtime.Hub, shutdown <-chan struct{}, eventRepo *repository.EventRepository, registrationRepo *repository.RegistrationRepository, notificationRepo *repository.NotificationRepository) *StreamHandler {
+	return &StreamHandler{
+		hub:              hub,
+		shutdown:         shutdown,
+		eventRepo:        eventRepo,
+		registrationRepo: registrationRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+// StreamEvent streams an event's status and registration counts as they change
+// GET /api/v1/events/:id/stream
+func (h *StreamHandler) StreamEvent(c *gin.Context) {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Subscribe before reading the state so no change in between is missed
+	changes, unsubscribe := h.hub.Subscribe(realtime.EventTopic(eventID))
+	defer unsubscribe()
+
+	state, err := h.eventState(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch event"))
+		return
+	}
+
+	startStream(c)
+	writeStreamEvent(c, "event", state)
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.shutdown:
+			return
+		case <-heartbeat.C:
+			writeStreamHeartbeat(c)
+		case <-changes:
+			next, err := h.eventState(ctx, eventID)
+			if err != nil {
+				// Closing the stream makes the client reconnect and start over
+				return
+			}
+			if *next != *state {
+				state = next
+				writeStreamEvent(c, "event", state)
+			}
+		}
+	}
+}
+
+// StreamMyUpdates streams the current user's new notifications and changes to
+// their registrations, such as moving up the waitlist
+// GET /api/v1/users/me/stream
+func (h *StreamHandler) StreamMyUpdates(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	changes, unsubscribe := h.hub.Subscribe(realtime.UserTopic(userID))
+	defer unsubscribe()
+
+	unread, err := h.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to count unread notifications"))
+		return
+	}
+
+	startStream(c)
+	writeStreamEvent(c, "unread_count", gin.H{"unread_count": unread})
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.shutdown:
+			return
+		case <-heartbeat.C:
+			writeStreamHeartbeat(c)
+		case change := <-changes:
+			if err := h.writeUserChange(c, userID, change); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeUserChange sends the current state behind a change to the user's stream
+func (h *StreamHandler) writeUserChange(c *gin.Context, userID uuid.UUID, change realtime.Change) error {
+	ctx := c.Request.Context()
+
+	switch change.Type {
+	case realtime.ChangeNotification:
+		if change.NotificationID == nil {
+			return nil
+		}
+		n, err := h.notificationRepo.FindByID(ctx, *change.NotificationID, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			// Deleted before we got to it
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		writeStreamEvent(c, "notification", toNotificationResponse(n))
+
+	case realtime.ChangeRegistration:
+		if change.EventID == nil {
+			return nil
+		}
+		resp := dto.RegistrationStreamResponse{
+			EventID: change.EventID.String(),
+			Status:  string(model.RegistrationCancelled),
+		}
+		reg, err := h.registrationRepo.FindByEventAndUser(ctx, *change.EventID, userID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if reg != nil {
+			resp.Status = string(reg.Status)
+			resp.WaitlistPosition = reg.WaitlistPosition
+			resp.OfferExpiresAt = reg.OfferExpiresAt
+		}
+		writeStreamEvent(c, "registration", resp)
+		return nil
+
+	case realtime.ChangeResync:
+		// Changes may have been missed; the client should reload what it shows
+		writeStreamEvent(c, "resync", gin.H{})
+	}
+
+	unread, err := h.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return err
+	}
+	writeStreamEvent(c, "unread_count", gin.H{"unread_count": unread})
+	return nil
+}
+
+// eventState reads the live state of an event
+func (h *StreamHandler) eventState(ctx context.Context, eventID uuid.UUID) (*dto.EventStreamResponse, error) {
+	event, err := h.eventRepo.FindByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := h.registrationRepo.GetRegistrationStats(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.EventStreamResponse{
+		EventID:        event.ID.String(),
+		Status:         string(event.Status),
+		Capacity:       event.Capacity,
+		ConfirmedCount: stats.ConfirmedCount,
+		WaitlistCount:  stats.WaitlistCount,
+	}, nil
+}
+
+// startStream writes the Server-Sent Events headers. Streams outlive the
+// server's write timeout, so it is lifted for this response.
+func startStream(c *gin.Context) {
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Stop nginx from buffering the stream
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+}
+
+// writeStreamEvent sends one Server-Sent Event with a JSON payload
+func writeStreamEvent(c *gin.Context, name string, data interface{}) {
+	c.SSEvent(name, data)
+	c.Writer.Flush()
+}
+
+// writeStreamHeartbeat sends a comment line, which clients ignore
+func writeStreamHeartbeat(c *gin.Context) {
+	_, _ = c.Writer.WriteString(": ping\n\n")
+	c.Writer.Flush()
+}
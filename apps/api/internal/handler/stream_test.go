@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/realtime"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// readStreamEvent reads the next named event from a Server-Sent Event stream
+func readStreamEvent(t *testing.T, r *bufio.Reader) (name, data string) {
+	t.Helper()
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("stream ended: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			name = line[len("event:"):]
+		case strings.HasPrefix(line, "data:"):
+			data = line[len("data:"):]
+		case line == "" && name != "":
+			return name, data
+		}
+	}
+}
+
+func TestStreamEvent_SendsChanges(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+	db := sqlx.NewDb(mockDB, "postgres")
+	defer db.Close()
+
+	hub := realtime.NewHub()
+	h := NewStreamHandler(hub, make(chan struct{}), repository.NewEventRepository(db), repository.NewRegistrationRepository(db), repository.NewNotificationRepository(db))
+	router := gin.New()
+	router.GET("/events/:id/stream", h.StreamEvent)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	eventID := uuid.New()
+	now := time.Now()
+	expectState := func(confirmed int) {
+		mock.ExpectQuery("SELECT .* FROM events WHERE id").
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{
+				"id", "host_id", "short_code", "title", "description", "event_date", "start_time", "end_time",
+				"location_name", "location_address", "latitude", "longitude", "google_place_id",
+				"capacity", "skill_level", "fee", "status", "created_at", "updated_at",
+			}).AddRow(
+				eventID, uuid.New(), "abc123", nil, nil, now, "20:00", nil,
+				"Test Location", nil, 25.033, 121.565, nil,
+				4, "beginner", 200, "open", now, now,
+			))
+		mock.ExpectQuery("SELECT .* FROM registrations").
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"confirmed_count", "waitlist_count"}).AddRow(confirmed, 0))
+	}
+	expectState(1)
+	// Unchanged state is not sent again
+	expectState(1)
+	expectState(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events/"+eventID.String()+"/stream", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %q", ct)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	name, data := readStreamEvent(t, reader)
+	if name != "event" || !strings.Contains(data, `"confirmed_count":1`) {
+		t.Fatalf("expected initial state, got %s %s", name, data)
+	}
+
+	hub.Publish(realtime.Change{Type: realtime.ChangeEvent, EventID: &eventID})
+	hub.Publish(realtime.Change{Type: realtime.ChangeEvent, EventID: &eventID})
+
+	name, data = readStreamEvent(t, reader)
+	if name != "event" || !strings.Contains(data, `"confirmed_count":2`) {
+		t.Fatalf("expected updated state, got %s %s", name, data)
+	}
+
+	cancel()
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStreamEvent_EndsOnShutdown(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+	db := sqlx.NewDb(mockDB, "postgres")
+	defer db.Close()
+
+	hub := realtime.NewHub()
+	shutdown := make(chan struct{})
+	h := NewStreamHandler(hub, shutdown, repository.NewEventRepository(db), repository.NewRegistrationRepository(db), repository.NewNotificationRepository(db))
+	router := gin.New()
+	router.GET("/events/:id/stream", h.StreamEvent)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	eventID := uuid.New()
+	now := time.Now()
+	mock.ExpectQuery("SELECT .* FROM events WHERE id").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "host_id", "short_code", "event_date", "start_time",
+			"location_name", "latitude", "longitude", "capacity", "skill_level", "fee", "status", "created_at", "updated_at",
+		}).AddRow(
+			eventID, uuid.New(), "abc123", now, "20:00",
+			"Test Location", 25.033, 121.565, 4, "beginner", 200, "open", now, now,
+		))
+	mock.ExpectQuery("SELECT .* FROM registrations").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"confirmed_count", "waitlist_count"}).AddRow(1, 0))
+
+	resp, err := http.Get(server.URL + "/events/" + eventID.String() + "/stream")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	readStreamEvent(t, reader)
+
+	close(shutdown)
+	if _, err := reader.ReadString('\n'); err == nil {
+		t.Fatal("expected the stream to end on shutdown")
+	}
+}
+
+func TestStreamEvent_NotFound(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+	db := sqlx.NewDb(mockDB, "postgres")
+	defer db.Close()
+
+	hub := realtime.NewHub()
+	h := NewStreamHandler(hub, make(chan struct{}), repository.NewEventRepository(db), repository.NewRegistrationRepository(db), repository.NewNotificationRepository(db))
+	router := gin.New()
+	router.GET("/events/:id/stream", h.StreamEvent)
+
+	eventID := uuid.New()
+	mock.ExpectQuery("SELECT .* FROM events WHERE id").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	req := httptest.NewRequest(http.MethodGet, "/events/"+eventID.String()+"/stream", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, recorder.Code)
+	}
+	if n := hub.Subscribers(); n != 0 {
+		t.Errorf("expected subscription to be released, got %d", n)
+	}
+}
@@ -0,0 +1,517 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/dto"
+	"github.com/anthropics/pickle-go/apps/api/internal/middleware"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/internal/service"
+	"github.com/anthropics/pickle-go/apps/api/pkg/transfer"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// TransferHandler handles confirmed players handing their spot to someone else
+type TransferHandler struct {
+	transferRepo       *repository.TransferRepository
+	registrationRepo   *repository.RegistrationRepository
+	eventRepo          *repository.EventRepository
+	userRepo           *repository.UserRepository
+	clubRepo           *repository.ClubRepository
+	outboxRepo         *repository.OutboxRepository
+	txManager          *database.TxManager
+	reliabilityService *service.ReliabilityService
+	baseURL            string
+	linkTTL            time.Duration
+	location           *time.Location
+}
+
+// NewTransferHandler creates a new TransferHandler. Transfer links point at
+// baseURL and stay valid for linkTTL, but never past the event start. Event
+// dates and times are wall-clock times in location.
+func NewTransferHandler(transferRepo *repository.TransferRepository, registrationRepo *repository.RegistrationRepository, eventRepo *repository.EventRepository, userRepo *repository.UserRepository, clubRepo *repository.ClubRepository, outboxRepo *repository.OutboxRepository, txManager *database.TxManager, reliabilityService *service.ReliabilityService, baseURL string, linkTTL time.Duration, location *time.Location) *TransferHandler {
+	return &TransferHandler{
+		transferRepo:       transferRepo,
+		registrationRepo:   registrationRepo,
+		eventRepo:          eventRepo,
+		userRepo:           userRepo,
+		clubRepo:           clubRepo,
+		outboxRepo:         outboxRepo,
+		txManager:          txManager,
+		reliabilityService: reliabilityService,
+		baseURL:            strings.TrimSuffix(baseURL, "/"),
+		linkTTL:            linkTTL,
+		location:           location,
+	}
+}
+
+// CreateTransfer creates a one-time link handing the current user's confirmed
+// spot to whoever accepts it. Any link the user already had is revoked.
+// POST /api/v1/events/:id/transfer
+func (h *TransferHandler) CreateTransfer(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return
+	}
+
+	registration, err := h.registrationRepo.FindByEventAndUser(c.Request.Context(), eventID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "You are not registered for this event"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch registration"))
+		return
+	}
+	if registration.Status != model.RegistrationConfirmed {
+		c.JSON(http.StatusConflict, dto.ErrorResponse("NOT_CONFIRMED", "Only confirmed players can transfer their spot"))
+		return
+	}
+	if registration.GuestCount > 0 {
+		c.JSON(http.StatusConflict, dto.ErrorResponse("HAS_GUESTS", "Cancel your guests before transferring your spot"))
+		return
+	}
+
+	event, err := h.eventRepo.FindByID(c.Request.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch event"))
+		return
+	}
+	if event.Status == model.EventStatusCancelled || event.Status == model.EventStatusCompleted {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("EVENT_CLOSED", "This event is not open for registration"))
+		return
+	}
+	now := time.Now()
+	start := event.StartsAt(h.location)
+	if !now.Before(start) {
+		c.JSON(http.StatusConflict, dto.ErrorResponse("EVENT_STARTED", "Spots cannot be transferred once the event has started"))
+		return
+	}
+
+	token, tokenHash, err := transfer.NewToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to create transfer link"))
+		return
+	}
+	spotTransfer := &model.SpotTransfer{
+		ID:             uuid.New(),
+		EventID:        eventID,
+		RegistrationID: registration.ID,
+		FromUserID:     userID,
+		TokenHash:      tokenHash,
+		ExpiresAt:      model.TransferExpiry(now, start, h.linkTTL),
+	}
+
+	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		return h.transferRepo.CreateTx(c.Request.Context(), tx, spotTransfer)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to create transfer link"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.SuccessResponse(dto.TransferLinkResponse{
+		ID:               spotTransfer.ID.String(),
+		URL:              h.baseURL + "/transfer/" + token,
+		ExpiresAt:        spotTransfer.ExpiresAt,
+		RequiresApproval: event.TransfersNeedApproval(),
+	}))
+}
+
+// RevokeTransfer revokes the current user's open transfer link for an event
+// DELETE /api/v1/events/:id/transfer
+func (h *TransferHandler) RevokeTransfer(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return
+	}
+
+	registration, err := h.registrationRepo.FindByEventAndUser(c.Request.Context(), eventID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "You are not registered for this event"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch registration"))
+		return
+	}
+
+	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		return h.transferRepo.RevokeOpenTx(c.Request.Context(), tx, registration.ID)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to revoke transfer link"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"message": "Transfer link revoked",
+	}))
+}
+
+// GetTransfer shows the recipient of a transfer link what they are being offered
+// GET /api/v1/transfers/:token
+func (h *TransferHandler) GetTransfer(c *gin.Context) {
+	spotTransfer, ok := h.findByToken(c)
+	if !ok {
+		return
+	}
+
+	event, err := h.eventRepo.FindByID(c.Request.Context(), spotTransfer.EventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch event"))
+		return
+	}
+	from, err := h.userRepo.FindByID(c.Request.Context(), spotTransfer.FromUserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch user"))
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.TransferResponse{
+		ID:               spotTransfer.ID.String(),
+		EventID:          spotTransfer.EventID.String(),
+		FromUser:         from.ToProfile(),
+		Status:           string(spotTransfer.Status),
+		Expired:          spotTransfer.Status == model.TransferPending && !spotTransfer.IsOpen(time.Now()),
+		ExpiresAt:        spotTransfer.ExpiresAt,
+		RequiresApproval: event.TransfersNeedApproval(),
+	}))
+}
+
+// AcceptTransfer takes over the spot behind a transfer link. If the host must
+// approve transfers, the spot is held for the host's decision instead.
+// POST /api/v1/transfers/:token/accept
+func (h *TransferHandler) AcceptTransfer(c *gin.Context) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return
+	}
+
+	spotTransfer, ok := h.findByToken(c)
+	if !ok {
+		return
+	}
+
+	event, err := h.eventRepo.FindByID(c.Request.Context(), spotTransfer.EventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch event"))
+		return
+	}
+
+	// A transfer does not get around the host's minimum reliability
+	if event.MinReliability != nil && event.HostID != userID {
+		reliability, err := h.reliabilityService.ForUser(c.Request.Context(), userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to check reliability"))
+			return
+		}
+		if !reliability.Meets(*event.MinReliability) {
+			c.JSON(http.StatusForbidden, dto.ErrorResponse("RELIABILITY_TOO_LOW",
+				fmt.Sprintf("This event requires a reliability score of at least %d", *event.MinReliability)))
+			return
+		}
+	}
+
+	// Nor does it get a non-member into an event kept for club members
+	if !checkClubAccess(c, h.clubRepo, event, []uuid.UUID{userID}, h.location) {
+		return
+	}
+
+	if event.TransfersNeedApproval() {
+		if event.HostID == userID {
+			h.respondWithTransferError(c, repository.ErrHostCannotRegister)
+			return
+		}
+		if spotTransfer.FromUserID == userID {
+			h.respondWithTransferError(c, repository.ErrAlreadyRegistered)
+			return
+		}
+		err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+			if txErr := h.transferRepo.RequestApprovalTx(c.Request.Context(), tx, spotTransfer.ID, userID); txErr != nil {
+				return txErr
+			}
+			return h.notifyApprovalRequestTx(c.Request.Context(), tx, event, spotTransfer.FromUserID, userID)
+		})
+		if err != nil {
+			h.respondWithTransferError(c, err)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, dto.SuccessResponse(gin.H{
+			"transfer_id": spotTransfer.ID.String(),
+			"event_id":    event.ID.String(),
+			"status":      string(model.TransferAwaitingApproval),
+			"message":     "Waiting for the host to approve the transfer",
+		}))
+		return
+	}
+
+	var registration *model.Registration
+	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		var txErr error
+		registration, _, txErr = h.registrationRepo.TransferWithLock(c.Request.Context(), tx, event.ID, spotTransfer.ID, userID, model.TransferPending)
+		if txErr != nil {
+			return txErr
+		}
+		return h.outboxRepo.EnqueueNotificationsTx(c.Request.Context(), tx,
+			model.NewSpotTransferredNotification(spotTransfer.FromUserID, event.ID, event.GetNotificationTitle(), "Your spot has been handed over"))
+	})
+	if err != nil {
+		h.respondWithTransferError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(dto.RegistrationResponse{
+		ID:      registration.ID.String(),
+		EventID: event.ID.String(),
+		Status:  string(registration.Status),
+		Message: "報名成功！",
+	}))
+}
+
+// ListTransfers returns the transfer history of an event to its host
+// GET /api/v1/events/:id/transfers
+func (h *TransferHandler) ListTransfers(c *gin.Context) {
+	event, ok := h.authorizeHost(c)
+	if !ok {
+		return
+	}
+
+	transfers, err := h.transferRepo.FindByEventID(c.Request.Context(), event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch transfers"))
+		return
+	}
+	if transfers == nil {
+		transfers = []model.SpotTransferWithUsers{}
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(gin.H{
+		"transfers": transfers,
+		"total":     len(transfers),
+	}))
+}
+
+// ApproveTransfer completes a transfer awaiting the host's approval
+// POST /api/v1/events/:id/transfers/:transferId/approve
+func (h *TransferHandler) ApproveTransfer(c *gin.Context) {
+	event, ok := h.authorizeHost(c)
+	if !ok {
+		return
+	}
+
+	transferID, err := uuid.Parse(c.Param("transferId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid transfer ID"))
+		return
+	}
+
+	spotTransfer, err := h.transferRepo.FindByID(c.Request.Context(), transferID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch transfer"))
+		return
+	}
+	if err != nil || spotTransfer.EventID != event.ID {
+		c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Transfer not found"))
+		return
+	}
+	if spotTransfer.Status != model.TransferAwaitingApproval || spotTransfer.ToUserID == nil {
+		h.respondWithTransferError(c, repository.ErrTransferUnavailable)
+		return
+	}
+	toUserID := *spotTransfer.ToUserID
+
+	var completed *model.SpotTransfer
+	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		var txErr error
+		_, completed, txErr = h.registrationRepo.TransferWithLock(c.Request.Context(), tx, event.ID, transferID, toUserID, model.TransferAwaitingApproval)
+		if txErr != nil {
+			return txErr
+		}
+		title := event.GetNotificationTitle()
+		return h.outboxRepo.EnqueueNotificationsTx(c.Request.Context(), tx,
+			model.NewSpotTransferredNotification(spotTransfer.FromUserID, event.ID, title, "The host approved handing over your spot"),
+			model.NewSpotTransferredNotification(toUserID, event.ID, title, "The host approved the transfer. You are now confirmed"))
+	})
+	if err != nil {
+		h.respondWithTransferError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(completed))
+}
+
+// RejectTransfer turns down a transfer awaiting the host's approval. The
+// sender keeps their spot.
+// POST /api/v1/events/:id/transfers/:transferId/reject
+func (h *TransferHandler) RejectTransfer(c *gin.Context) {
+	event, ok := h.authorizeHost(c)
+	if !ok {
+		return
+	}
+
+	transferID, err := uuid.Parse(c.Param("transferId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid transfer ID"))
+		return
+	}
+
+	var rejected *model.SpotTransfer
+	err = h.txManager.WithTx(c.Request.Context(), func(tx *sqlx.Tx) error {
+		var txErr error
+		rejected, txErr = h.transferRepo.RejectTx(c.Request.Context(), tx, event.ID, transferID)
+		if txErr != nil {
+			return txErr
+		}
+		if rejected.ToUserID == nil {
+			return nil
+		}
+		return h.outboxRepo.EnqueueNotificationsTx(c.Request.Context(), tx,
+			model.NewTransferRejectedNotification(*rejected.ToUserID, event.ID, event.GetNotificationTitle()))
+	})
+	if err != nil {
+		h.respondWithTransferError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.SuccessResponse(rejected))
+}
+
+// findByToken loads the transfer behind the link token in the URL, responding
+// with an error if there is none
+func (h *TransferHandler) findByToken(c *gin.Context) (*model.SpotTransfer, bool) {
+	spotTransfer, err := h.transferRepo.FindByTokenHash(c.Request.Context(), transfer.Hash(c.Param("token")))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Transfer link not found"))
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch transfer"))
+		return nil, false
+	}
+	return spotTransfer, true
+}
+
+// authorizeHost loads the event in the URL and checks the current user hosts or
+// co-hosts it, responding with an error if not
+func (h *TransferHandler) authorizeHost(c *gin.Context) (*model.Event, bool) {
+	claims, ok := middleware.GetAuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("UNAUTHORIZED", "Not authenticated"))
+		return nil, false
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse("INVALID_TOKEN", "Invalid user ID"))
+		return nil, false
+	}
+
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("VALIDATION_ERROR", "Invalid event ID"))
+		return nil, false
+	}
+
+	event, err := h.eventRepo.FindByID(c.Request.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Event not found"))
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to fetch event"))
+		return nil, false
+	}
+	role, err := eventRole(c.Request.Context(), h.eventRepo, event, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to verify event role"))
+		return nil, false
+	}
+	if !role.CanManage() {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse("FORBIDDEN", "You are not the host of this event"))
+		return nil, false
+	}
+	return event, true
+}
+
+// notifyApprovalRequestTx enqueues a notification asking the host to approve a
+// transfer, so it is sent if and only if the transaction commits
+func (h *TransferHandler) notifyApprovalRequestTx(ctx context.Context, tx *sqlx.Tx, event *model.Event, fromUserID, toUserID uuid.UUID) error {
+	from, err := h.userRepo.FindByID(ctx, fromUserID)
+	if err != nil {
+		return err
+	}
+	to, err := h.userRepo.FindByID(ctx, toUserID)
+	if err != nil {
+		return err
+	}
+	return h.outboxRepo.EnqueueNotificationsTx(ctx, tx,
+		model.NewTransferRequestNotification(event.HostID, event.ID, event.GetNotificationTitle(), from.DisplayName, to.DisplayName))
+}
+
+func (h *TransferHandler) respondWithTransferError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, repository.ErrTransferUnavailable):
+		c.JSON(http.StatusConflict, dto.ErrorResponse("TRANSFER_UNAVAILABLE", "This transfer has been used, revoked or has expired"))
+	case errors.Is(err, repository.ErrEventNotOpen):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("EVENT_CLOSED", "This event is not open for registration"))
+	case errors.Is(err, repository.ErrHostCannotRegister):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("HOST_CANNOT_REGISTER", "You cannot register for your own event"))
+	case errors.Is(err, repository.ErrBlocked):
+		c.JSON(http.StatusForbidden, dto.ErrorResponse("BLOCKED", "The host of this event is not accepting registrations from you"))
+	case errors.Is(err, repository.ErrAlreadyRegistered):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse("ALREADY_REGISTERED", "You are already registered for this event"))
+	case errors.Is(err, sql.ErrNoRows):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse("NOT_FOUND", "Transfer not found"))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse("INTERNAL_ERROR", "Failed to transfer spot"))
+	}
+}
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/internal/service"
+	"github.com/anthropics/pickle-go/apps/api/pkg/transfer"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// transferTestContext holds the transfer handler and its mocked database
+type transferTestContext struct {
+	handler *TransferHandler
+	mock    sqlmock.Sqlmock
+	db      *sqlx.DB
+}
+
+func setupTransferTestContext(t *testing.T) *transferTestContext {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+
+	db := sqlx.NewDb(mockDB, "postgres")
+	regRepo := repository.NewRegistrationRepository(db)
+	reliabilityService := service.NewReliabilityService(regRepo, model.ReliabilityPolicy{Window: 180 * 24 * time.Hour}, "UTC")
+
+	return &transferTestContext{
+		handler: NewTransferHandler(repository.NewTransferRepository(db), regRepo, repository.NewEventRepository(db),
+			repository.NewUserRepository(db), repository.NewClubRepository(db), repository.NewOutboxRepository(db), database.NewTxManager(db),
+			reliabilityService, "https://picklego.tw/", 48*time.Hour, time.UTC),
+		mock: mock,
+		db:   db,
+	}
+}
+
+// expectTransferEvent mocks loading an event starting at start
+func (tc *transferTestContext) expectTransferEvent(eventID, hostID uuid.UUID, start time.Time, requiresApproval bool) {
+	now := time.Now()
+	tc.mock.ExpectQuery("SELECT .* FROM events WHERE id").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "host_id", "short_code", "title", "description", "event_date", "start_time", "end_time",
+			"location_name", "location_address", "latitude", "longitude", "google_place_id",
+			"capacity", "skill_level", "fee", "status", "transfer_requires_approval", "created_at", "updated_at",
+		}).AddRow(
+			eventID, hostID, "abc123", nil, nil, start, start.Format("15:04"), nil,
+			"Test Location", nil, 25.033, 121.565, nil,
+			4, "beginner", 200, "full", requiresApproval, now, now,
+		))
+}
+
+// expectTransferByToken mocks loading a pending transfer by its link token
+func (tc *transferTestContext) expectTransferByToken(token string, transferID, eventID, regID, fromUserID uuid.UUID) {
+	now := time.Now()
+	tc.mock.ExpectQuery("SELECT \\* FROM spot_transfers WHERE token_hash").
+		WithArgs(transfer.Hash(token)).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "event_id", "registration_id", "from_user_id", "to_user_id", "token_hash", "status",
+			"waitlist_bypassed", "expires_at", "created_at", "accepted_at", "resolved_at",
+		}).AddRow(transferID, eventID, regID, fromUserID, nil, transfer.Hash(token), "pending", 0, now.Add(time.Hour), now, nil, nil))
+}
+
+func (tc *transferTestContext) serve(method, path, route string, userID uuid.UUID, handle gin.HandlerFunc) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.Handle(method, route, createAuthContext(userID.String(), "Player"), handle)
+
+	req := httptest.NewRequest(method, path, nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestCreateTransfer(t *testing.T) {
+	tc := setupTransferTestContext(t)
+	defer tc.db.Close()
+
+	userID, eventID, regID, hostID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	start := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Minute)
+
+	tc.mock.ExpectQuery("SELECT \\* FROM registrations WHERE event_id").
+		WithArgs(eventID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status"}).
+			AddRow(regID, eventID, userID, model.RegistrationConfirmed))
+	tc.expectTransferEvent(eventID, hostID, start, false)
+	tc.mock.ExpectBegin()
+	tc.mock.ExpectExec("UPDATE spot_transfers SET status = 'revoked'").
+		WithArgs(regID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	tc.mock.ExpectQuery("INSERT INTO spot_transfers").
+		WillReturnRows(sqlmock.NewRows([]string{"status", "created_at"}).AddRow("pending", time.Now()))
+	tc.mock.ExpectCommit()
+
+	recorder := tc.serve(http.MethodPost, "/events/"+eventID.String()+"/transfer", "/events/:id/transfer", userID, tc.handler.CreateTransfer)
+
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, recorder.Code, recorder.Body.String())
+	}
+	data := parseResponse(t, recorder).Data.(map[string]interface{})
+	if url, _ := data["url"].(string); !strings.HasPrefix(url, "https://picklego.tw/transfer/") {
+		t.Errorf("expected a transfer link, got %v", data["url"])
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCreateTransfer_NotConfirmed(t *testing.T) {
+	tc := setupTransferTestContext(t)
+	defer tc.db.Close()
+
+	userID, eventID := uuid.New(), uuid.New()
+	tc.mock.ExpectQuery("SELECT \\* FROM registrations WHERE event_id").
+		WithArgs(eventID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status", "waitlist_position"}).
+			AddRow(uuid.New(), eventID, userID, model.RegistrationWaitlist, 2))
+
+	recorder := tc.serve(http.MethodPost, "/events/"+eventID.String()+"/transfer", "/events/:id/transfer", userID, tc.handler.CreateTransfer)
+
+	if recorder.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d", http.StatusConflict, recorder.Code)
+	}
+	if code := parseResponse(t, recorder).Error.Code; code != "NOT_CONFIRMED" {
+		t.Errorf("expected NOT_CONFIRMED, got %s", code)
+	}
+}
+
+func TestAcceptTransfer_RequiresApproval(t *testing.T) {
+	tc := setupTransferTestContext(t)
+	defer tc.db.Close()
+
+	token := "test-token"
+	transferID, eventID, regID := uuid.New(), uuid.New(), uuid.New()
+	hostID, fromUserID, toUserID := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+
+	tc.expectTransferByToken(token, transferID, eventID, regID, fromUserID)
+	tc.expectTransferEvent(eventID, hostID, now.UTC().Add(24*time.Hour), true)
+	tc.mock.ExpectBegin()
+	tc.mock.ExpectExec("UPDATE spot_transfers SET status = 'awaiting_approval'").
+		WithArgs(transferID, toUserID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	for _, id := range []uuid.UUID{fromUserID, toUserID} {
+		tc.mock.ExpectQuery("SELECT .* FROM users WHERE id").
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "line_user_id", "display_name"}).AddRow(id, "U"+id.String(), "Player"))
+	}
+	tc.mock.ExpectExec("INSERT INTO outbox").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	tc.mock.ExpectCommit()
+
+	recorder := tc.serve(http.MethodPost, "/transfers/"+token+"/accept", "/transfers/:token/accept", toUserID, tc.handler.AcceptTransfer)
+
+	if recorder.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, recorder.Code, recorder.Body.String())
+	}
+	data := parseResponse(t, recorder).Data.(map[string]interface{})
+	if data["status"] != string(model.TransferAwaitingApproval) {
+		t.Errorf("expected awaiting_approval, got %v", data["status"])
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAcceptTransfer_Unavailable(t *testing.T) {
+	tc := setupTransferTestContext(t)
+	defer tc.db.Close()
+
+	token := "test-token"
+	transferID, eventID, regID := uuid.New(), uuid.New(), uuid.New()
+	hostID, fromUserID, toUserID := uuid.New(), uuid.New(), uuid.New()
+
+	tc.expectTransferByToken(token, transferID, eventID, regID, fromUserID)
+	tc.expectTransferEvent(eventID, hostID, time.Now().UTC().Add(24*time.Hour), false)
+	tc.mock.ExpectBegin()
+	tc.mock.ExpectQuery("SELECT status, host_id FROM events WHERE id = .* FOR UPDATE").
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "host_id"}).AddRow("full", hostID))
+	expectNotBlocked(tc.mock)
+	tc.mock.ExpectQuery("SELECT \\* FROM spot_transfers WHERE id = .* FOR UPDATE").
+		WithArgs(transferID, eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "registration_id", "from_user_id", "status", "expires_at"}).
+			AddRow(transferID, eventID, regID, fromUserID, "completed", time.Now().Add(time.Hour)))
+	tc.mock.ExpectRollback()
+
+	recorder := tc.serve(http.MethodPost, "/transfers/"+token+"/accept", "/transfers/:token/accept", toUserID, tc.handler.AcceptTransfer)
+
+	if recorder.Code != http.StatusConflict {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusConflict, recorder.Code, recorder.Body.String())
+	}
+	if code := parseResponse(t, recorder).Error.Code; code != "TRANSFER_UNAVAILABLE" {
+		t.Errorf("expected TRANSFER_UNAVAILABLE, got %s", code)
+	}
+	if err := tc.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRejectTransfer_NotHost(t *testing.T) {
+	tc := setupTransferTestContext(t)
+	defer tc.db.Close()
+
+	eventID, userID := uuid.New(), uuid.New()
+	tc.expectTransferEvent(eventID, uuid.New(), time.Now().UTC().Add(24*time.Hour), true)
+	expectEventRole(tc.mock, eventID, userID, model.RoleCheckInHelper)
+
+	path := "/events/" + eventID.String() + "/transfers/" + uuid.NewString() + "/reject"
+	recorder := tc.serve(http.MethodPost, path, "/events/:id/transfers/:transferId/reject", userID, tc.handler.RejectTransfer)
+
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d", http.StatusForbidden, recorder.Code)
+	}
+}
@@ -74,3 +74,17 @@ func GetAuthUser(c *gin.Context) (*jwt.Claims, bool) {
 	claims, ok := value.(*jwt.Claims)
 	return claims, ok
 }
+
+// OptionalAuth returns a gin middleware that identifies the user when a valid
+// JWT token is sent, and otherwise lets the request through anonymously
+func OptionalAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+		if len(parts) == 2 && strings.ToLower(parts[0]) == "bearer" {
+			if claims, err := jwt.ValidateToken(parts[1]); err == nil {
+				c.Set(AuthUserKey, claims)
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HostBlock stops a user from registering for any event the host runs
+type HostBlock struct {
+	HostID    uuid.UUID `db:"host_id" json:"-"`
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	Reason    *string   `db:"reason" json:"reason,omitempty"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
+
+// HostBlockWithUser represents a block with the blocked user
+type HostBlockWithUser struct {
+	HostBlock
+	User UserProfile `json:"user"`
+}
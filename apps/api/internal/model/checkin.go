@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// CheckInWindow is when attendance can be checked in, relative to the event start
+type CheckInWindow struct {
+	// OpensBefore is how long before the start check-in opens
+	OpensBefore time.Duration
+	// ClosesAfter is how long after the start check-in closes
+	ClosesAfter time.Duration
+}
+
+// StartsAt returns when an event starts. Event dates and times are wall-clock
+// times in loc.
+func (e *Event) StartsAt(loc *time.Location) time.Time {
+	clock, err := parseClock(e.StartTime)
+	if err != nil {
+		clock = 0
+	}
+	return time.Date(e.EventDate.Year(), e.EventDate.Month(), e.EventDate.Day(), 0, 0, 0, 0, loc).Add(clock)
+}
+
+// IsOpen reports whether check-in for an event starting at start is open at t
+func (w CheckInWindow) IsOpen(start, t time.Time) bool {
+	return !t.Before(start.Add(-w.OpensBefore)) && !t.After(start.Add(w.ClosesAfter))
+}
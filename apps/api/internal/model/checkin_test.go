@@ -0,0 +1,36 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckInWindow_IsOpen(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	event := &Event{EventDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), StartTime: "19:30:00"}
+	start := event.StartsAt(loc)
+	if want := time.Date(2025, 6, 1, 19, 30, 0, 0, loc); !start.Equal(want) {
+		t.Fatalf("expected start %v, got %v", want, start)
+	}
+
+	window := CheckInWindow{OpensBefore: time.Hour, ClosesAfter: 3 * time.Hour}
+	tests := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{"too early", start.Add(-61 * time.Minute), false},
+		{"opens", start.Add(-time.Hour), true},
+		{"at start", start, true},
+		{"closes", start.Add(3 * time.Hour), true},
+		{"too late", start.Add(3*time.Hour + time.Minute), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := window.IsOpen(start, tt.t); got != tt.want {
+				t.Errorf("IsOpen(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
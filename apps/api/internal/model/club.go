@@ -0,0 +1,70 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClubRole is what a member may do in a club
+type ClubRole string
+
+const (
+	ClubRoleOwner  ClubRole = "owner"
+	ClubRoleAdmin  ClubRole = "admin"
+	ClubRoleMember ClubRole = "member"
+)
+
+// CanManage reports whether the role may run the club's events and members
+func (r ClubRole) CanManage() bool {
+	return r == ClubRoleOwner || r == ClubRoleAdmin
+}
+
+// ClubMemberStatus represents how far a user is through joining a club
+type ClubMemberStatus string
+
+const (
+	// ClubMemberInvited members were invited by an admin and have not accepted yet
+	ClubMemberInvited ClubMemberStatus = "invited"
+	// ClubMemberRequested members asked to join and are waiting for an admin
+	ClubMemberRequested ClubMemberStatus = "requested"
+	// ClubMemberActive members belong to the club
+	ClubMemberActive ClubMemberStatus = "active"
+)
+
+// Club is a group of players that runs events together
+type Club struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	Name        string    `db:"name" json:"name"`
+	Description *string   `db:"description" json:"description,omitempty"`
+	CreatedBy   uuid.UUID `db:"created_by" json:"created_by"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// ClubMember is a user's membership of a club, or their pending invite or join request
+type ClubMember struct {
+	ClubID    uuid.UUID        `db:"club_id" json:"club_id"`
+	UserID    uuid.UUID        `db:"user_id" json:"user_id"`
+	Role      ClubRole         `db:"role" json:"role"`
+	Status    ClubMemberStatus `db:"status" json:"status"`
+	InvitedBy *uuid.UUID       `db:"invited_by" json:"invited_by,omitempty"`
+	CreatedAt time.Time        `db:"created_at" json:"created_at"`
+	JoinedAt  *time.Time       `db:"joined_at" json:"joined_at,omitempty"`
+}
+
+// IsActive reports whether the user belongs to the club
+func (m *ClubMember) IsActive() bool {
+	return m.Status == ClubMemberActive
+}
+
+// CanManage reports whether the user is an active owner or admin of the club
+func (m *ClubMember) CanManage() bool {
+	return m.IsActive() && m.Role.CanManage()
+}
+
+// ClubMemberWithUser represents a membership with the user holding it
+type ClubMemberWithUser struct {
+	ClubMember
+	User UserProfile `json:"user"`
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryChannel is a channel notifications are pushed through
+type DeliveryChannel string
+
+const (
+	DeliveryChannelLine DeliveryChannel = "line"
+)
+
+// DeliveryStatus represents the status of a notification delivery
+type DeliveryStatus string
+
+const (
+	DeliveryPending DeliveryStatus = "pending"
+	DeliverySent    DeliveryStatus = "sent"
+	DeliveryFailed  DeliveryStatus = "failed"
+	// DeliverySkipped means the notification could not be pushed, e.g. the user
+	// has not added the LINE bot
+	DeliverySkipped DeliveryStatus = "skipped"
+)
+
+// NotificationDelivery tracks pushing a notification through one channel
+type NotificationDelivery struct {
+	ID             uuid.UUID       `db:"id" json:"id"`
+	NotificationID uuid.UUID       `db:"notification_id" json:"notification_id"`
+	Channel        DeliveryChannel `db:"channel" json:"channel"`
+	Status         DeliveryStatus  `db:"status" json:"status"`
+	Attempts       int             `db:"attempts" json:"attempts"`
+	LastError      *string         `db:"last_error" json:"last_error,omitempty"`
+	SentAt         *time.Time      `db:"sent_at" json:"sent_at,omitempty"`
+	DeliverAfter   time.Time       `db:"deliver_after" json:"deliver_after"`
+	CreatedAt      time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time       `db:"updated_at" json:"updated_at"`
+}
+
+// PendingDelivery is a pending delivery with the notification and recipient it is for
+type PendingDelivery struct {
+	ID             uuid.UUID  `db:"id"`
+	NotificationID uuid.UUID  `db:"notification_id"`
+	Attempts       int        `db:"attempts"`
+	UserID         uuid.UUID  `db:"user_id"`
+	EventID        *uuid.UUID `db:"event_id"`
+	Type           string     `db:"type"`
+	Title          string     `db:"title"`
+	Message        *string    `db:"message"`
+	LineUserID     string     `db:"line_user_id"`
+	LineFriend     bool       `db:"line_friend"`
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DigestItem is a notification waiting for a user's next digest, with the
+// event it is about
+type DigestItem struct {
+	NotificationID uuid.UUID  `db:"id"`
+	EventID        *uuid.UUID `db:"event_id"`
+	Title          string     `db:"title"`
+	CreatedAt      time.Time  `db:"created_at"`
+	EventTitle     *string    `db:"event_title"`
+	EventDate      *time.Time `db:"event_date"`
+	LocationName   *string    `db:"location_name"`
+}
+
+// eventLabel labels the event an item is about, like Event.GetNotificationTitle
+func (i *DigestItem) eventLabel() string {
+	if i.EventDate == nil || i.LocationName == nil {
+		return "Other updates"
+	}
+	e := Event{EventDate: *i.EventDate, LocationName: *i.LocationName, Title: i.EventTitle}
+	return e.GetNotificationTitle()
+}
+
+// NewDigestNotification creates a digest summarizing a user's pending
+// notifications, grouped by event in the order they first appear
+func NewDigestNotification(userID uuid.UUID, frequency DigestFrequency, items []DigestItem) *Notification {
+	var order []string
+	groups := make(map[string][]string)
+	for i := range items {
+		label := items[i].eventLabel()
+		if _, ok := groups[label]; !ok {
+			order = append(order, label)
+		}
+		groups[label] = append(groups[label], "• "+items[i].Title)
+	}
+
+	sections := make([]string, len(order))
+	for i, label := range order {
+		sections[i] = label + "\n" + strings.Join(groups[label], "\n")
+	}
+	message := strings.Join(sections, "\n\n")
+
+	title := "Your daily summary: "
+	if frequency == DigestWeekly {
+		title = "Your weekly summary: "
+	}
+	if len(items) == 1 {
+		title += "1 update"
+	} else {
+		title += strconv.Itoa(len(items)) + " updates"
+	}
+
+	return &Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      NotificationDigest,
+		Title:     title,
+		Message:   &message,
+		IsRead:    false,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewDigestNotification_GroupsByEvent(t *testing.T) {
+	court := "Court A"
+	date := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	eventID := uuid.New()
+
+	items := []DigestItem{
+		{NotificationID: uuid.New(), EventID: &eventID, Title: "Event details have changed", EventDate: &date, LocationName: &court},
+		{NotificationID: uuid.New(), Title: "Welcome"},
+		{NotificationID: uuid.New(), EventID: &eventID, Title: "Your game starts in 24 hours", EventDate: &date, LocationName: &court},
+	}
+
+	n := NewDigestNotification(uuid.New(), DigestWeekly, items)
+
+	if n.Type != NotificationDigest {
+		t.Errorf("expected type %s, got %s", NotificationDigest, n.Type)
+	}
+	if n.Title != "Your weekly summary: 3 updates" {
+		t.Errorf("unexpected title %q", n.Title)
+	}
+	want := "06/01 @ Court A\n• Event details have changed\n• Your game starts in 24 hours\n\nOther updates\n• Welcome"
+	if n.Message == nil || *n.Message != want {
+		t.Errorf("expected message %q, got %v", want, n.Message)
+	}
+}
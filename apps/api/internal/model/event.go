@@ -1,6 +1,7 @@
 package model
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -36,27 +37,105 @@ const (
 	EventStatusCompleted EventStatus = "completed"
 )
 
+// eventTransitions lists the statuses each event status may move to.
+// open and full follow registrations; cancelled and completed are final.
+var eventTransitions = map[EventStatus][]EventStatus{
+	EventStatusOpen:      {EventStatusFull, EventStatusCancelled, EventStatusCompleted},
+	EventStatusFull:      {EventStatusOpen, EventStatusCancelled, EventStatusCompleted},
+	EventStatusCancelled: {},
+	EventStatusCompleted: {},
+}
+
+// CanTransitionTo reports whether an event may move from this status to next
+func (s EventStatus) CanTransitionTo(next EventStatus) bool {
+	for _, allowed := range eventTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal reports whether no further transitions are allowed from this status
+func (s EventStatus) IsFinal() bool {
+	return len(eventTransitions[s]) == 0
+}
+
+// StatusesBefore returns every status that may transition to next
+func StatusesBefore(next EventStatus) []EventStatus {
+	var from []EventStatus
+	for _, s := range []EventStatus{EventStatusOpen, EventStatusFull, EventStatusCancelled, EventStatusCompleted} {
+		if s.CanTransitionTo(next) {
+			from = append(from, s)
+		}
+	}
+	return from
+}
+
+// CapacityPolicy decides what happens when capacity is lowered below the spots already held
+type CapacityPolicy string
+
+const (
+	// CapacityPolicyRefuse rejects the capacity change
+	CapacityPolicyRefuse CapacityPolicy = "refuse"
+	// CapacityPolicyDemote moves the latest confirmed players back to the head of the waitlist
+	CapacityPolicyDemote CapacityPolicy = "demote"
+)
+
+// EventVisibility decides who can find an event and who can register for it
+type EventVisibility string
+
+const (
+	// VisibilityPublic events are listed on the map and open to everyone
+	VisibilityPublic EventVisibility = "public"
+	// VisibilityUnlisted events are only reachable by link
+	VisibilityUnlisted EventVisibility = "unlisted"
+	// VisibilityInviteOnly events are unlisted and need an invite to register
+	VisibilityInviteOnly EventVisibility = "invite_only"
+)
+
+// RegistrationAccess decides whether players outside an event's club may register
+type RegistrationAccess string
+
+const (
+	// AccessEveryone events are open to every player
+	AccessEveryone RegistrationAccess = "everyone"
+	// AccessMembersOnly events are only open to members of the club
+	AccessMembersOnly RegistrationAccess = "members_only"
+	// AccessMembersFirst events open to everyone member_priority_hours before they start
+	AccessMembersFirst RegistrationAccess = "members_first"
+)
+
 // Event represents an event in the system
 type Event struct {
-	ID              uuid.UUID   `db:"id" json:"id"`
-	HostID          uuid.UUID   `db:"host_id" json:"host_id"`
-	ShortCode       string      `db:"short_code" json:"short_code"`
-	Title           *string     `db:"title" json:"title,omitempty"`
-	Description     *string     `db:"description" json:"description,omitempty"`
-	EventDate       time.Time   `db:"event_date" json:"event_date"`
-	StartTime       string      `db:"start_time" json:"start_time"`
-	EndTime         *string     `db:"end_time" json:"end_time,omitempty"`
-	LocationName    string      `db:"location_name" json:"location_name"`
-	LocationAddress *string     `db:"location_address" json:"location_address,omitempty"`
-	Latitude        float64     `db:"latitude" json:"latitude"`
-	Longitude       float64     `db:"longitude" json:"longitude"`
-	GooglePlaceID   *string     `db:"google_place_id" json:"google_place_id,omitempty"`
-	Capacity        int         `db:"capacity" json:"capacity"`
-	SkillLevel      SkillLevel  `db:"skill_level" json:"skill_level"`
-	Fee             int         `db:"fee" json:"fee"`
-	Status          EventStatus `db:"status" json:"status"`
-	CreatedAt       time.Time   `db:"created_at" json:"created_at"`
-	UpdatedAt       time.Time   `db:"updated_at" json:"updated_at"`
+	ID                  uuid.UUID          `db:"id" json:"id"`
+	HostID              uuid.UUID          `db:"host_id" json:"host_id"`
+	ShortCode           string             `db:"short_code" json:"short_code"`
+	Title               *string            `db:"title" json:"title,omitempty"`
+	Description         *string            `db:"description" json:"description,omitempty"`
+	EventDate           time.Time          `db:"event_date" json:"event_date"`
+	StartTime           string             `db:"start_time" json:"start_time"`
+	EndTime             *string            `db:"end_time" json:"end_time,omitempty"`
+	LocationName        string             `db:"location_name" json:"location_name"`
+	LocationAddress     *string            `db:"location_address" json:"location_address,omitempty"`
+	Latitude            float64            `db:"latitude" json:"latitude"`
+	Longitude           float64            `db:"longitude" json:"longitude"`
+	GooglePlaceID       *string            `db:"google_place_id" json:"google_place_id,omitempty"`
+	Capacity            int                `db:"capacity" json:"capacity"`
+	SkillLevel          SkillLevel         `db:"skill_level" json:"skill_level"`
+	Fee                 int                `db:"fee" json:"fee"`
+	Status              EventStatus        `db:"status" json:"status"`
+	MinReliability      *int               `db:"min_reliability" json:"min_reliability,omitempty"`
+	CancelDeadlineHours *int               `db:"cancel_deadline_hours" json:"cancel_deadline_hours,omitempty"`
+	TransferApproval    bool               `db:"transfer_requires_approval" json:"transfer_requires_approval"`
+	MaxGuests           int                `db:"max_guests" json:"max_guests"`
+	RequiresApproval    bool               `db:"requires_approval" json:"requires_approval"`
+	Visibility          EventVisibility    `db:"visibility" json:"visibility"`
+	ClubID              *uuid.UUID         `db:"club_id" json:"club_id,omitempty"`
+	RegistrationAccess  RegistrationAccess `db:"registration_access" json:"registration_access"`
+	MemberPriorityHours *int               `db:"member_priority_hours" json:"member_priority_hours,omitempty"`
+	CreatedAt           time.Time          `db:"created_at" json:"created_at"`
+	UpdatedAt           time.Time          `db:"updated_at" json:"updated_at"`
 }
 
 // EventSummary represents an event with registration counts
@@ -94,3 +173,61 @@ func (e *Event) GetSkillLevelLabel() string {
 	}
 	return string(e.SkillLevel)
 }
+
+// CancelDeadline returns when cancelling starts to count as late, or nil if the
+// event has no deadline. Event dates and times are wall-clock times in loc.
+func (e *Event) CancelDeadline(loc *time.Location) *time.Time {
+	if e.CancelDeadlineHours == nil {
+		return nil
+	}
+	deadline := e.StartsAt(loc).Add(-time.Duration(*e.CancelDeadlineHours) * time.Hour)
+	return &deadline
+}
+
+// MemberPriorityEnds returns when players outside the club may start registering
+// for a members-first event, or nil for other events. Event dates and times are
+// wall-clock times in loc.
+func (e *Event) MemberPriorityEnds(loc *time.Location) *time.Time {
+	if e.RegistrationAccess != AccessMembersFirst || e.MemberPriorityHours == nil {
+		return nil
+	}
+	ends := e.StartsAt(loc).Add(-time.Duration(*e.MemberPriorityHours) * time.Hour)
+	return &ends
+}
+
+// MembersOnlyAt reports whether only members of the event's club may register at t
+func (e *Event) MembersOnlyAt(t time.Time, loc *time.Location) bool {
+	if e.ClubID == nil {
+		return false
+	}
+	switch e.RegistrationAccess {
+	case AccessMembersOnly:
+		return true
+	case AccessMembersFirst:
+		ends := e.MemberPriorityEnds(loc)
+		return ends != nil && t.Before(*ends)
+	default:
+		return false
+	}
+}
+
+// IsPrivate reports whether the event is kept off the map and its participants
+// hidden from players who are not taking part
+func (e *Event) IsPrivate() bool {
+	return e.Visibility == VisibilityUnlisted || e.Visibility == VisibilityInviteOnly
+}
+
+// TransfersNeedApproval reports whether spot transfers wait for the host. Events
+// where the host vets registrations always vet the recipient of a transfer too.
+func (e *Event) TransfersNeedApproval() bool {
+	return e.TransferApproval || e.RequiresApproval
+}
+
+// GetNotificationTitle returns the short "MM/DD @ title" label used in notifications
+func (e *Event) GetNotificationTitle() string {
+	title := e.LocationName
+	if e.Title != nil && *e.Title != "" {
+		title = *e.Title
+	}
+	return fmt.Sprintf("%s @ %s", e.EventDate.Format("01/02"), title)
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EventChange is a change to an event detail that players need to know about
+type EventChange struct {
+	Field string
+	From  string
+	To    string
+}
+
+// String formats the change for display, e.g. "Fee: NT$200 → NT$250"
+func (c EventChange) String() string {
+	return fmt.Sprintf("%s: %s → %s", c.Field, c.From, c.To)
+}
+
+// DiffEvent returns the material changes between two versions of an event:
+// date, time, location, fee and capacity. Edits that do not affect whether or
+// where someone plays, like the title, description or skill level, are left out.
+func DiffEvent(before, after *Event) []EventChange {
+	var changes []EventChange
+	add := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, EventChange{Field: field, From: from, To: to})
+		}
+	}
+
+	add("Date", before.EventDate.Format("2006/01/02 (Mon)"), after.EventDate.Format("2006/01/02 (Mon)"))
+	add("Time", formatTimeRange(before), formatTimeRange(after))
+	add("Location", formatLocation(before), formatLocation(after))
+	add("Fee", formatFee(before.Fee), formatFee(after.Fee))
+	add("Capacity", fmt.Sprintf("%d players", before.Capacity), fmt.Sprintf("%d players", after.Capacity))
+	return changes
+}
+
+// FormatEventChanges formats changes for display, one per line
+func FormatEventChanges(changes []EventChange) string {
+	lines := make([]string, len(changes))
+	for i, c := range changes {
+		lines[i] = c.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func formatTimeRange(e *Event) string {
+	start := e.GetStartTimeLabel()
+	if e.EndTime == nil || *e.EndTime == "" {
+		return start
+	}
+	end := *e.EndTime
+	if len(end) > 5 {
+		end = end[:5]
+	}
+	return start + "-" + end
+}
+
+func formatLocation(e *Event) string {
+	if e.LocationAddress == nil || *e.LocationAddress == "" {
+		return e.LocationName
+	}
+	return e.LocationName + " (" + *e.LocationAddress + ")"
+}
+
+func formatFee(fee int) string {
+	if fee == 0 {
+		return "Free"
+	}
+	return fmt.Sprintf("NT$%d", fee)
+}
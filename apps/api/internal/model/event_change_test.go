@@ -0,0 +1,50 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffEvent(t *testing.T) {
+	title := "Friday Doubles"
+	before := &Event{
+		Title:        &title,
+		EventDate:    time.Date(2024, 6, 7, 0, 0, 0, 0, time.UTC),
+		StartTime:    "19:00:00",
+		LocationName: "Daan Park Courts",
+		Capacity:     8,
+		SkillLevel:   SkillBeginner,
+		Fee:          200,
+	}
+
+	t.Run("trivial edits are suppressed", func(t *testing.T) {
+		after := *before
+		newTitle := "Friday Night Doubles"
+		description := "Bring water"
+		after.Title = &newTitle
+		after.Description = &description
+		after.SkillLevel = SkillIntermediate
+
+		if changes := DiffEvent(before, &after); len(changes) != 0 {
+			t.Errorf("expected no material changes, got %v", changes)
+		}
+	})
+
+	t.Run("material edits are listed", func(t *testing.T) {
+		after := *before
+		endTime := "21:00:00"
+		after.EventDate = time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)
+		after.EndTime = &endTime
+		after.Fee = 0
+		after.Capacity = 10
+
+		got := FormatEventChanges(DiffEvent(before, &after))
+		want := "Date: 2024/06/07 (Fri) → 2024/06/14 (Fri)\n" +
+			"Time: 19:00 → 19:00-21:00\n" +
+			"Fee: NT$200 → Free\n" +
+			"Capacity: 8 players → 10 players"
+		if got != want {
+			t.Errorf("FormatEventChanges() =\n%s\nwant\n%s", got, want)
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventRole is what a user may do on an event they help run
+type EventRole string
+
+const (
+	RoleHost          EventRole = "host"
+	RoleCoHost        EventRole = "co_host"
+	RoleCheckInHelper EventRole = "check_in_helper"
+)
+
+// CanManage reports whether the role may edit, cancel and run registrations for the event
+func (r EventRole) CanManage() bool {
+	return r == RoleHost || r == RoleCoHost
+}
+
+// CanCheckIn reports whether the role may check players in and record attendance
+func (r EventRole) CanCheckIn() bool {
+	return r.CanManage() || r == RoleCheckInHelper
+}
+
+// EventRoleStatus represents whether an invited user has taken up their role
+type EventRoleStatus string
+
+const (
+	EventRoleInvited  EventRoleStatus = "invited"
+	EventRoleAccepted EventRoleStatus = "accepted"
+)
+
+// EventRoleAssignment gives a user other than the host a role on an event
+type EventRoleAssignment struct {
+	ID         uuid.UUID       `db:"id" json:"id"`
+	EventID    uuid.UUID       `db:"event_id" json:"event_id"`
+	UserID     uuid.UUID       `db:"user_id" json:"user_id"`
+	Role       EventRole       `db:"role" json:"role"`
+	Status     EventRoleStatus `db:"status" json:"status"`
+	InvitedBy  uuid.UUID       `db:"invited_by" json:"invited_by"`
+	CreatedAt  time.Time       `db:"created_at" json:"created_at"`
+	AcceptedAt *time.Time      `db:"accepted_at" json:"accepted_at,omitempty"`
+}
+
+// EventRoleWithUser represents a role assignment with the user holding it
+type EventRoleWithUser struct {
+	EventRoleAssignment
+	User UserProfile `json:"user"`
+}
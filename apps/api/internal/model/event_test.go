@@ -0,0 +1,88 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestEventStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from EventStatus
+		to   EventStatus
+		want bool
+	}{
+		{EventStatusOpen, EventStatusFull, true},
+		{EventStatusFull, EventStatusOpen, true},
+		{EventStatusOpen, EventStatusCancelled, true},
+		{EventStatusFull, EventStatusCompleted, true},
+		{EventStatusCancelled, EventStatusOpen, false},
+		{EventStatusCancelled, EventStatusCompleted, false},
+		{EventStatusCompleted, EventStatusOpen, false},
+		{EventStatusCompleted, EventStatusCancelled, false},
+		{EventStatusOpen, EventStatusOpen, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("CanTransitionTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusesBefore(t *testing.T) {
+	got := StatusesBefore(EventStatusCancelled)
+	if len(got) != 2 || got[0] != EventStatusOpen || got[1] != EventStatusFull {
+		t.Errorf("StatusesBefore(cancelled) = %v, want [open full]", got)
+	}
+	if got := StatusesBefore(EventStatusOpen); len(got) != 1 || got[0] != EventStatusFull {
+		t.Errorf("StatusesBefore(open) = %v, want [full]", got)
+	}
+}
+
+func TestEvent_CancelDeadline(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	event := &Event{EventDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), StartTime: "08:00:00"}
+
+	if deadline := event.CancelDeadline(loc); deadline != nil {
+		t.Errorf("expected no deadline, got %v", deadline)
+	}
+
+	hours := 12
+	event.CancelDeadlineHours = &hours
+	want := time.Date(2025, 5, 31, 20, 0, 0, 0, loc)
+	if deadline := event.CancelDeadline(loc); deadline == nil || !deadline.Equal(want) {
+		t.Errorf("expected deadline %v, got %v", want, deadline)
+	}
+}
+
+func TestEvent_MembersOnlyAt(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	clubID := uuid.New()
+	hours := 24
+	start := time.Date(2025, 6, 1, 8, 0, 0, 0, loc)
+
+	tests := []struct {
+		name   string
+		event  Event
+		at     time.Time
+		expect bool
+	}{
+		{"no club", Event{RegistrationAccess: AccessMembersOnly}, start.Add(-48 * time.Hour), false},
+		{"everyone", Event{ClubID: &clubID, RegistrationAccess: AccessEveryone}, start.Add(-48 * time.Hour), false},
+		{"members only", Event{ClubID: &clubID, RegistrationAccess: AccessMembersOnly}, start.Add(-time.Hour), true},
+		{"members first, in window", Event{ClubID: &clubID, RegistrationAccess: AccessMembersFirst, MemberPriorityHours: &hours}, start.Add(-48 * time.Hour), true},
+		{"members first, window over", Event{ClubID: &clubID, RegistrationAccess: AccessMembersFirst, MemberPriorityHours: &hours}, start.Add(-24 * time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		tt.event.EventDate = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		tt.event.StartTime = "08:00:00"
+		if got := tt.event.MembersOnlyAt(tt.at, loc); got != tt.expect {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expect, got)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Guest is a friend without an account who takes a seat on a player's registration.
+// Guests share their player's registration status.
+type Guest struct {
+	ID             uuid.UUID  `db:"id" json:"id"`
+	RegistrationID uuid.UUID  `db:"registration_id" json:"registration_id"`
+	Name           string     `db:"name" json:"name"`
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+	CancelledAt    *time.Time `db:"cancelled_at" json:"cancelled_at,omitempty"`
+}
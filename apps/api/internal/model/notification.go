@@ -0,0 +1,192 @@
+package model
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// newEventNotification creates an unread notification about an event
+func newEventNotification(userID, eventID uuid.UUID, notificationType, title, message string) *Notification {
+	return &Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		EventID:   &eventID,
+		Type:      notificationType,
+		Title:     title,
+		Message:   &message,
+		IsRead:    false,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewWaitlistPromotedNotification creates a notification for when a user is promoted from waitlist
+func NewWaitlistPromotedNotification(userID, eventID uuid.UUID, eventTitle string) *Notification {
+	return newEventNotification(userID, eventID, NotificationWaitlistPromoted,
+		"You have been promoted from the waitlist!",
+		"A spot has opened up. You are now confirmed for: "+eventTitle)
+}
+
+// NewEventCancelledNotification creates a notification for when an event is cancelled
+func NewEventCancelledNotification(userID, eventID uuid.UUID, eventTitle string) *Notification {
+	return newEventNotification(userID, eventID, NotificationEventCancelled,
+		"Event has been cancelled",
+		"The event you registered for has been cancelled: "+eventTitle)
+}
+
+// NewEventCancelledNotifications creates a cancellation notification for each user
+func NewEventCancelledNotifications(userIDs []uuid.UUID, event *Event) []*Notification {
+	notifications := make([]*Notification, len(userIDs))
+	for i, userID := range userIDs {
+		notifications[i] = NewEventCancelledNotification(userID, event.ID, event.GetNotificationTitle())
+	}
+	return notifications
+}
+
+// NewWaitlistOfferNotification creates a notification for when a waitlisted user is offered a spot
+func NewWaitlistOfferNotification(userID, eventID uuid.UUID, eventTitle string, expiresAt time.Time) *Notification {
+	return newEventNotification(userID, eventID, NotificationWaitlistOffered,
+		"A spot has opened up for you!",
+		"Accept before "+expiresAt.Format("01/02 15:04")+" to confirm your spot for: "+eventTitle)
+}
+
+// NewOfferExpiredNotification creates a notification for when a waitlist offer expires unanswered
+func NewOfferExpiredNotification(userID, eventID uuid.UUID, eventTitle string) *Notification {
+	return newEventNotification(userID, eventID, NotificationOfferExpired,
+		"Your waitlist offer has expired",
+		"The spot was offered to the next player on the waitlist for: "+eventTitle)
+}
+
+// NewMovedToWaitlistNotification creates a notification for when a lowered capacity moves a user back to the waitlist
+func NewMovedToWaitlistNotification(userID, eventID uuid.UUID, eventTitle string, position int) *Notification {
+	return newEventNotification(userID, eventID, NotificationMovedToWaitlist,
+		"You have been moved to the waitlist",
+		"The host reduced the number of spots. You are now #"+strconv.Itoa(position)+" on the waitlist for: "+eventTitle)
+}
+
+// NewEventReminderNotification creates a reminder that a confirmed event starts soon
+func NewEventReminderNotification(userID, eventID uuid.UUID, eventTitle, startTime, startsIn string) *Notification {
+	return newEventNotification(userID, eventID, NotificationEventReminder,
+		"Your game starts in "+startsIn,
+		"See you at "+startTime+" for: "+eventTitle)
+}
+
+// NewWaitlistReminderNotification creates a reminder of a user's waitlist position before the event
+func NewWaitlistReminderNotification(userID, eventID uuid.UUID, eventTitle string, position int) *Notification {
+	return newEventNotification(userID, eventID, NotificationWaitlistReminder,
+		"You are still #"+strconv.Itoa(position)+" on the waitlist",
+		"We will let you know if a spot opens up for: "+eventTitle)
+}
+
+// NewEventUpdatedNotification creates a notification listing material changes to an event
+func NewEventUpdatedNotification(userID, eventID uuid.UUID, eventTitle string, changes []EventChange) *Notification {
+	return newEventNotification(userID, eventID, NotificationEventUpdated,
+		"Event details have changed",
+		eventTitle+"\n"+FormatEventChanges(changes))
+}
+
+// NewEventUpdatedNotifications creates a notification listing the changes for each user
+func NewEventUpdatedNotifications(userIDs []uuid.UUID, event *Event, changes []EventChange) []*Notification {
+	notifications := make([]*Notification, len(userIDs))
+	for i, userID := range userIDs {
+		notifications[i] = NewEventUpdatedNotification(userID, event.ID, event.GetNotificationTitle(), changes)
+	}
+	return notifications
+}
+
+// NewTransferRequestNotification asks the host to approve a spot transfer
+func NewTransferRequestNotification(hostID, eventID uuid.UUID, eventTitle, fromName, toName string) *Notification {
+	return newEventNotification(hostID, eventID, NotificationTransferRequest,
+		"A spot transfer needs your approval",
+		fromName+" wants to hand their spot to "+toName+" for: "+eventTitle)
+}
+
+// NewSpotTransferredNotification tells a player a spot transfer has completed
+func NewSpotTransferredNotification(userID, eventID uuid.UUID, eventTitle, message string) *Notification {
+	return newEventNotification(userID, eventID, NotificationSpotTransferred,
+		"Spot transfer completed",
+		message+": "+eventTitle)
+}
+
+// NewTransferRejectedNotification tells the recipient the host turned down a spot transfer
+func NewTransferRejectedNotification(userID, eventID uuid.UUID, eventTitle string) *Notification {
+	return newEventNotification(userID, eventID, NotificationTransferRejected,
+		"Spot transfer was not approved",
+		"The host did not approve the spot transfer for: "+eventTitle)
+}
+
+// NewGroupRegisteredNotification tells a player someone registered them as part of a group
+func NewGroupRegisteredNotification(userID, eventID uuid.UUID, eventTitle, organizerName string, status RegistrationStatus) *Notification {
+	message := organizerName + " registered you for: " + eventTitle
+	if status == RegistrationWaitlist {
+		message = organizerName + " added you to the waitlist with their group for: " + eventTitle
+	}
+	return newEventNotification(userID, eventID, NotificationGroupRegistered,
+		"You have been registered with a group", message)
+}
+
+// NewRequestApprovedNotification tells a player the host approved their registration request
+func NewRequestApprovedNotification(userID, eventID uuid.UUID, eventTitle string, status RegistrationStatus, position *int) *Notification {
+	message := "You are confirmed for: " + eventTitle
+	if status == RegistrationWaitlist && position != nil {
+		message = "The event is full, so you are #" + strconv.Itoa(*position) + " on the waitlist for: " + eventTitle
+	}
+	return newEventNotification(userID, eventID, NotificationRequestApproved,
+		"The host approved your registration", message)
+}
+
+// NewRequestRejectedNotification tells a player the host turned down their registration request
+func NewRequestRejectedNotification(userID, eventID uuid.UUID, eventTitle string) *Notification {
+	return newEventNotification(userID, eventID, NotificationRequestRejected,
+		"Your registration was not approved",
+		"The host did not approve your registration for: "+eventTitle)
+}
+
+// NewRoleInvitedNotification asks a user to help run an event as a co-host or check-in helper
+func NewRoleInvitedNotification(userID, eventID uuid.UUID, eventTitle, inviterName string, role EventRole) *Notification {
+	title := "You have been invited to co-host an event"
+	if role == RoleCheckInHelper {
+		title = "You have been invited to help with check-in"
+	}
+	return newEventNotification(userID, eventID, NotificationRoleInvited, title,
+		inviterName+" invited you to help run: "+eventTitle)
+}
+
+// NewRemovedByHostNotification tells a player the host took them off an event,
+// with the host's reason if they gave one
+func NewRemovedByHostNotification(userID, eventID uuid.UUID, eventTitle, reason string) *Notification {
+	message := "The host removed your registration for: " + eventTitle
+	if reason != "" {
+		message += " (" + reason + ")"
+	}
+	return newEventNotification(userID, eventID, NotificationRemovedByHost,
+		"You have been removed from an event", message)
+}
+
+// NewClubInvitedNotification asks a user to join a club. Club notifications are
+// not about an event, so they have no event ID.
+func NewClubInvitedNotification(userID uuid.UUID, clubName, inviterName string) *Notification {
+	message := inviterName + " invited you to join " + clubName
+	return &Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      NotificationClubInvited,
+		Title:     "You have been invited to a club",
+		Message:   &message,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NewClubJoinedNotification tells a user a club admin accepted their request to join
+func NewClubJoinedNotification(userID uuid.UUID, clubName string) *Notification {
+	message := "Your request to join " + clubName + " was accepted"
+	return &Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      NotificationClubJoined,
+		Title:     "You are now a club member",
+		Message:   &message,
+		CreatedAt: time.Now(),
+	}
+}
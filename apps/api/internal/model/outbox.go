@@ -0,0 +1,38 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxStatus represents the status of an outbox message
+type OutboxStatus string
+
+const (
+	OutboxPending OutboxStatus = "pending"
+	OutboxDone    OutboxStatus = "done"
+	// OutboxDead means delivery was given up on; the message is kept for inspection
+	OutboxDead OutboxStatus = "dead"
+)
+
+// Outbox topics
+const (
+	// OutboxTopicNotification creates an in-app notification; the payload is a Notification
+	OutboxTopicNotification = "notification.create"
+)
+
+// OutboxMessage is a side effect recorded in the same transaction as the state
+// change that caused it, and delivered afterwards by the outbox dispatcher
+type OutboxMessage struct {
+	ID            uuid.UUID       `db:"id" json:"id"`
+	Topic         string          `db:"topic" json:"topic"`
+	Payload       json.RawMessage `db:"payload" json:"payload"`
+	Status        OutboxStatus    `db:"status" json:"status"`
+	Attempts      int             `db:"attempts" json:"attempts"`
+	LastError     *string         `db:"last_error" json:"last_error,omitempty"`
+	NextAttemptAt time.Time       `db:"next_attempt_at" json:"next_attempt_at"`
+	CreatedAt     time.Time       `db:"created_at" json:"created_at"`
+	ProcessedAt   *time.Time      `db:"processed_at" json:"processed_at,omitempty"`
+}
@@ -0,0 +1,165 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryChannelInApp is the notification inbox. In-app notifications are the
+// notification rows themselves, so they have no delivery rows.
+const DeliveryChannelInApp DeliveryChannel = "in_app"
+
+// NotificationChannels lists the channels users can turn notifications on or off for
+var NotificationChannels = []DeliveryChannel{DeliveryChannelInApp, DeliveryChannelLine}
+
+// NotificationTypes lists the notification types users can set preferences for
+var NotificationTypes = []string{
+	NotificationWaitlistPromoted,
+	NotificationWaitlistOffered,
+	NotificationOfferExpired,
+	NotificationMovedToWaitlist,
+	NotificationEventCancelled,
+	NotificationEventUpdated,
+	NotificationEventReminder,
+	NotificationWaitlistReminder,
+	NotificationTransferRequest,
+	NotificationSpotTransferred,
+	NotificationTransferRejected,
+	NotificationGroupRegistered,
+	NotificationRequestApproved,
+	NotificationRequestRejected,
+	NotificationRoleInvited,
+	NotificationRemovedByHost,
+	NotificationClubInvited,
+	NotificationClubJoined,
+}
+
+// IsNotificationType reports whether t is a known notification type
+func IsNotificationType(t string) bool {
+	for _, known := range NotificationTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUrgentNotification reports whether a notification type is delivered even
+// during quiet hours: a spot offer that expires, or an event that is off
+func IsUrgentNotification(t string) bool {
+	return t == NotificationWaitlistOffered || t == NotificationEventCancelled
+}
+
+// NotificationPreference records whether a user wants one type of notification on one channel
+type NotificationPreference struct {
+	UserID  uuid.UUID       `db:"user_id" json:"-"`
+	Type    string          `db:"type" json:"type"`
+	Channel DeliveryChannel `db:"channel" json:"channel"`
+	Enabled bool            `db:"enabled" json:"enabled"`
+}
+
+// DigestFrequency is how often a user's LINE notifications are batched into a digest
+type DigestFrequency string
+
+const (
+	DigestOff    DigestFrequency = "off"
+	DigestDaily  DigestFrequency = "daily"
+	DigestWeekly DigestFrequency = "weekly"
+)
+
+// digestHour is the local hour digests are sent at; weekly digests go out on Mondays
+const digestHour = 8
+
+// NotificationSettings holds a user's quiet hours and digest mode. Start and end
+// are wall-clock times (HH:MM) in the user's time zone; an end before the start
+// runs overnight.
+type NotificationSettings struct {
+	UserID          uuid.UUID       `db:"user_id" json:"-"`
+	Timezone        string          `db:"timezone" json:"timezone"`
+	QuietHoursStart *string         `db:"quiet_hours_start" json:"quiet_hours_start"`
+	QuietHoursEnd   *string         `db:"quiet_hours_end" json:"quiet_hours_end"`
+	Digest          DigestFrequency `db:"digest" json:"digest"`
+	LastDigestAt    *time.Time      `db:"last_digest_at" json:"-"`
+}
+
+// DeliveryPreferences is how a single notification should be delivered to its recipient
+type DeliveryPreferences struct {
+	InApp bool
+	Line  bool
+	// Digest holds the LINE delivery for the user's next digest instead
+	Digest bool
+	// DeliverAfter defers external deliveries until quiet hours end
+	DeliverAfter time.Time
+}
+
+// DigestSlot returns the most recent scheduled digest time at or before t
+func (s *NotificationSettings) DigestSlot(t time.Time) time.Time {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := t.In(loc)
+	slot := time.Date(local.Year(), local.Month(), local.Day(), digestHour, 0, 0, 0, loc)
+	if slot.After(local) {
+		slot = slot.AddDate(0, 0, -1)
+	}
+	if s.Digest == DigestWeekly {
+		// Back up to Monday
+		slot = slot.AddDate(0, 0, -((int(slot.Weekday()) + 6) % 7))
+	}
+	return slot
+}
+
+// DigestDue reports whether the notifications waiting for a digest should be
+// sent at t. Any left waiting after digest mode is turned off are sent right away.
+func (s *NotificationSettings) DigestDue(t time.Time) bool {
+	if s.Digest != DigestDaily && s.Digest != DigestWeekly {
+		return true
+	}
+	return s.LastDigestAt == nil || s.LastDigestAt.Before(s.DigestSlot(t))
+}
+
+// QuietUntil returns when the quiet hours that t falls in end, or t itself if
+// t is outside quiet hours or none are set
+func (s *NotificationSettings) QuietUntil(t time.Time) time.Time {
+	if s == nil || s.QuietHoursStart == nil || s.QuietHoursEnd == nil {
+		return t
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return t
+	}
+	start, err1 := parseClock(*s.QuietHoursStart)
+	end, err2 := parseClock(*s.QuietHoursEnd)
+	if err1 != nil || err2 != nil || start == end {
+		return t
+	}
+
+	local := t.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	now := local.Sub(midnight)
+
+	switch {
+	case start < end && now >= start && now < end:
+		return midnight.Add(end)
+	case start > end && now >= start:
+		// Overnight window, ends tomorrow morning
+		return midnight.AddDate(0, 0, 1).Add(end)
+	case start > end && now < end:
+		return midnight.Add(end)
+	}
+	return t
+}
+
+// parseClock parses an HH:MM or HH:MM:SS wall-clock time as an offset from midnight
+func parseClock(s string) (time.Duration, error) {
+	if len(s) > 5 {
+		s = s[:5]
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotificationSettings_QuietUntil(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Taipei")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2025, 6, day, hour, min, 0, 0, loc)
+	}
+	clock := func(s string) *string { return &s }
+
+	overnight := &NotificationSettings{Timezone: "Asia/Taipei", QuietHoursStart: clock("22:00:00"), QuietHoursEnd: clock("07:00:00")}
+	daytime := &NotificationSettings{Timezone: "Asia/Taipei", QuietHoursStart: clock("13:00"), QuietHoursEnd: clock("14:30")}
+
+	tests := []struct {
+		name     string
+		settings *NotificationSettings
+		t        time.Time
+		want     time.Time
+	}{
+		{"no settings", nil, at(1, 23, 0), at(1, 23, 0)},
+		{"no quiet hours", &NotificationSettings{Timezone: "Asia/Taipei"}, at(1, 23, 0), at(1, 23, 0)},
+		{"overnight before midnight", overnight, at(1, 23, 0), at(2, 7, 0)},
+		{"overnight after midnight", overnight, at(2, 3, 15), at(2, 7, 0)},
+		{"overnight at end", overnight, at(2, 7, 0), at(2, 7, 0)},
+		{"overnight outside", overnight, at(1, 12, 0), at(1, 12, 0)},
+		{"same day inside", daytime, at(1, 13, 45), at(1, 14, 30)},
+		{"same day outside", daytime, at(1, 15, 0), at(1, 15, 0)},
+		{"other time zone", overnight, at(1, 23, 0).UTC(), at(2, 7, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.settings.QuietUntil(tt.t)
+			if !got.Equal(tt.want) {
+				t.Errorf("QuietUntil(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationSettings_DigestDue(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Taipei")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	// 2025-06-04 is a Wednesday
+	at := func(day, hour int) *time.Time {
+		t := time.Date(2025, 6, day, hour, 0, 0, 0, loc)
+		return &t
+	}
+
+	tests := []struct {
+		name   string
+		digest DigestFrequency
+		last   *time.Time
+		now    time.Time
+		want   bool
+	}{
+		{"never sent", DigestDaily, nil, *at(4, 9), true},
+		{"daily sent this morning", DigestDaily, at(4, 8), *at(4, 20), false},
+		{"daily before today's slot", DigestDaily, at(3, 8), *at(4, 7), false},
+		{"daily after today's slot", DigestDaily, at(3, 8), *at(4, 8), true},
+		{"weekly sent on Monday", DigestWeekly, at(2, 8), *at(8, 23), false},
+		{"weekly next Monday", DigestWeekly, at(2, 8), *at(9, 8), true},
+		{"turned off flushes right away", DigestOff, at(4, 8), *at(4, 9), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &NotificationSettings{Timezone: "Asia/Taipei", Digest: tt.digest, LastDigestAt: tt.last}
+			if got := s.DigestDue(tt.now); got != tt.want {
+				t.Errorf("DigestDue(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
@@ -12,6 +12,9 @@ type RegistrationStatus string
 const (
 	RegistrationConfirmed RegistrationStatus = "confirmed"
 	RegistrationWaitlist  RegistrationStatus = "waitlist"
+	RegistrationOffered   RegistrationStatus = "offered"
+	RegistrationPending   RegistrationStatus = "pending"
+	RegistrationRejected  RegistrationStatus = "rejected"
 	RegistrationCancelled RegistrationStatus = "cancelled"
 )
 
@@ -25,6 +28,20 @@ type Registration struct {
 	RegisteredAt     time.Time          `db:"registered_at" json:"registered_at"`
 	ConfirmedAt      *time.Time         `db:"confirmed_at" json:"confirmed_at,omitempty"`
 	CancelledAt      *time.Time         `db:"cancelled_at" json:"cancelled_at,omitempty"`
+	OfferExpiresAt   *time.Time         `db:"offer_expires_at" json:"offer_expires_at,omitempty"`
+	CheckedInAt      *time.Time         `db:"checked_in_at" json:"checked_in_at,omitempty"`
+	Attendance       *Attendance        `db:"attendance" json:"attendance,omitempty"`
+	LateCancelled    bool               `db:"late_cancelled" json:"late_cancelled"`
+	GuestCount       int                `db:"guest_count" json:"guest_count"`
+	GroupID          *uuid.UUID         `db:"group_id" json:"group_id,omitempty"`
+	SkillLevel       *SkillLevel        `db:"skill_level" json:"skill_level,omitempty"`
+	// Guests is only filled in by RegisterWithLock; other queries leave it empty
+	Guests []Guest `db:"-" json:"guests,omitempty"`
+}
+
+// Seats returns how many seats the registration takes: the player and their guests
+func (r *Registration) Seats() int {
+	return 1 + r.GuestCount
 }
 
 // RegistrationWithUser represents a registration with user details
@@ -43,6 +60,8 @@ type Notification struct {
 	Message   *string    `db:"message" json:"message,omitempty"`
 	IsRead    bool       `db:"is_read" json:"is_read"`
 	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+	// DigestID is the digest this notification was delivered in, if any
+	DigestID *uuid.UUID `db:"digest_id" json:"digest_id,omitempty"`
 }
 
 // NotificationType constants
@@ -51,4 +70,19 @@ const (
 	NotificationEventCancelled   = "event_cancelled"
 	NotificationEventUpdated     = "event_updated"
 	NotificationEventReminder    = "event_reminder"
+	NotificationWaitlistOffered  = "waitlist_offered"
+	NotificationOfferExpired     = "waitlist_offer_expired"
+	NotificationMovedToWaitlist  = "moved_to_waitlist"
+	NotificationWaitlistReminder = "waitlist_reminder"
+	NotificationDigest           = "digest"
+	NotificationTransferRequest  = "transfer_requested"
+	NotificationSpotTransferred  = "spot_transferred"
+	NotificationTransferRejected = "transfer_rejected"
+	NotificationGroupRegistered  = "group_registered"
+	NotificationRequestApproved  = "registration_approved"
+	NotificationRequestRejected  = "registration_rejected"
+	NotificationRoleInvited      = "event_role_invited"
+	NotificationRemovedByHost    = "registration_removed"
+	NotificationClubInvited      = "club_invited"
+	NotificationClubJoined       = "club_joined"
 )
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// Attendance records whether a confirmed player turned up
+type Attendance string
+
+const (
+	AttendanceAttended Attendance = "attended"
+	AttendanceNoShow   Attendance = "no_show"
+)
+
+// IsAttendance reports whether s is a known attendance value
+func IsAttendance(s string) bool {
+	switch Attendance(s) {
+	case AttendanceAttended, AttendanceNoShow:
+		return true
+	}
+	return false
+}
+
+// ReliabilityPolicy decides which registrations count towards reliability
+type ReliabilityPolicy struct {
+	// Window is how far back events count
+	Window time.Duration
+}
+
+// Reliability summarises how a player has honoured the spots they confirmed
+type Reliability struct {
+	Attended      int `db:"attended" json:"attended"`
+	LateCancelled int `db:"late_cancelled" json:"late_cancelled"`
+	NoShows       int `db:"no_shows" json:"no_shows"`
+}
+
+// Score returns the percentage of spots the player honoured, or nil if they
+// have no history yet
+func (r Reliability) Score() *int {
+	total := r.Attended + r.LateCancelled + r.NoShows
+	if total == 0 {
+		return nil
+	}
+	score := r.Attended * 100 / total
+	return &score
+}
+
+// Meets reports whether the player may register for an event requiring min.
+// Players without history are always allowed so newcomers can join.
+func (r Reliability) Meets(min int) bool {
+	score := r.Score()
+	return score == nil || *score >= min
+}
@@ -0,0 +1,36 @@
+package model
+
+import "testing"
+
+func TestReliability_Score(t *testing.T) {
+	tests := []struct {
+		name        string
+		reliability Reliability
+		wantScore   *int
+		meets80     bool
+	}{
+		{"no history", Reliability{}, nil, true},
+		{"always attended", Reliability{Attended: 5}, intPtr(100), true},
+		{"one no-show", Reliability{Attended: 4, NoShows: 1}, intPtr(80), true},
+		{"late cancels count against", Reliability{Attended: 2, LateCancelled: 1, NoShows: 1}, intPtr(50), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := tt.reliability.Score()
+			switch {
+			case tt.wantScore == nil && score != nil:
+				t.Errorf("expected no score, got %d", *score)
+			case tt.wantScore != nil && (score == nil || *score != *tt.wantScore):
+				t.Errorf("expected score %d, got %v", *tt.wantScore, score)
+			}
+			if got := tt.reliability.Meets(80); got != tt.meets80 {
+				t.Errorf("Meets(80) = %v, want %v", got, tt.meets80)
+			}
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DueReminder is a registration that is due an event reminder
+type DueReminder struct {
+	Event
+	UserID             uuid.UUID          `db:"user_id"`
+	RegistrationStatus RegistrationStatus `db:"registration_status"`
+	WaitlistPosition   *int               `db:"waitlist_position"`
+}
+
+// ReminderKind identifies a reminder for dedupe, e.g. "confirmed_24h" or "waitlist_2h"
+func ReminderKind(status RegistrationStatus, offset time.Duration) string {
+	return string(status) + "_" + FormatOffset(offset)
+}
+
+// FormatOffset formats a reminder offset compactly, e.g. "24h" or "90m"
+func FormatOffset(offset time.Duration) string {
+	if offset%time.Hour == 0 {
+		return fmt.Sprintf("%dh", offset/time.Hour)
+	}
+	return fmt.Sprintf("%dm", offset/time.Minute)
+}
+
+// GetStartTimeLabel returns the event start time as HH:MM
+func (e *Event) GetStartTimeLabel() string {
+	if len(e.StartTime) > 5 {
+		return e.StartTime[:5]
+	}
+	return e.StartTime
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReminderKind(t *testing.T) {
+	tests := []struct {
+		status RegistrationStatus
+		offset time.Duration
+		want   string
+	}{
+		{RegistrationConfirmed, 24 * time.Hour, "confirmed_24h"},
+		{RegistrationConfirmed, 2 * time.Hour, "confirmed_2h"},
+		{RegistrationWaitlist, 90 * time.Minute, "waitlist_90m"},
+	}
+
+	for _, tt := range tests {
+		if got := ReminderKind(tt.status, tt.offset); got != tt.want {
+			t.Errorf("ReminderKind(%s, %s) = %s, want %s", tt.status, tt.offset, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/pkg/recurrence"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// SeriesStatus represents the status of an event series
+type SeriesStatus string
+
+const (
+	SeriesStatusActive SeriesStatus = "active"
+	SeriesStatusEnded  SeriesStatus = "ended"
+)
+
+// EventSeries represents a recurring event template and its recurrence rule
+type EventSeries struct {
+	ID                  uuid.UUID            `db:"id" json:"id"`
+	HostID              uuid.UUID            `db:"host_id" json:"host_id"`
+	Title               *string              `db:"title" json:"title,omitempty"`
+	Description         *string              `db:"description" json:"description,omitempty"`
+	StartTime           string               `db:"start_time" json:"start_time"`
+	EndTime             *string              `db:"end_time" json:"end_time,omitempty"`
+	LocationName        string               `db:"location_name" json:"location_name"`
+	LocationAddress     *string              `db:"location_address" json:"location_address,omitempty"`
+	Latitude            float64              `db:"latitude" json:"latitude"`
+	Longitude           float64              `db:"longitude" json:"longitude"`
+	GooglePlaceID       *string              `db:"google_place_id" json:"google_place_id,omitempty"`
+	Capacity            int                  `db:"capacity" json:"capacity"`
+	SkillLevel          SkillLevel           `db:"skill_level" json:"skill_level"`
+	Fee                 int                  `db:"fee" json:"fee"`
+	Frequency           recurrence.Frequency `db:"frequency" json:"frequency"`
+	Weekdays            pq.Int64Array        `db:"weekdays" json:"weekdays"`
+	MonthDay            *int                 `db:"month_day" json:"month_day,omitempty"`
+	StartsOn            time.Time            `db:"starts_on" json:"starts_on"`
+	EndsOn              *time.Time           `db:"ends_on" json:"ends_on,omitempty"`
+	MaterializedThrough *time.Time           `db:"materialized_through" json:"materialized_through,omitempty"`
+	Status              SeriesStatus         `db:"status" json:"status"`
+	CreatedAt           time.Time            `db:"created_at" json:"created_at"`
+	UpdatedAt           time.Time            `db:"updated_at" json:"updated_at"`
+}
+
+// SeriesOccurrence links a series date to the event materialized for it
+type SeriesOccurrence struct {
+	ID             uuid.UUID  `db:"id" json:"id"`
+	SeriesID       uuid.UUID  `db:"series_id" json:"series_id"`
+	OccurrenceDate time.Time  `db:"occurrence_date" json:"occurrence_date"`
+	EventID        *uuid.UUID `db:"event_id" json:"event_id,omitempty"`
+	IsException    bool       `db:"is_exception" json:"is_exception"`
+	IsDetached     bool       `db:"is_detached" json:"is_detached"`
+	CreatedAt      time.Time  `db:"created_at" json:"created_at"`
+}
+
+// SeriesOccurrenceWithEvent represents an occurrence with its event status
+type SeriesOccurrenceWithEvent struct {
+	SeriesOccurrence
+	EventStatus *EventStatus `db:"event_status" json:"event_status,omitempty"`
+}
+
+// Rule returns the recurrence rule of the series
+func (s *EventSeries) Rule(exceptions []time.Time) recurrence.Rule {
+	rule := recurrence.Rule{
+		Frequency:  s.Frequency,
+		Start:      s.StartsOn,
+		Exceptions: exceptions,
+	}
+	for _, wd := range s.Weekdays {
+		rule.Weekdays = append(rule.Weekdays, time.Weekday(wd))
+	}
+	if s.MonthDay != nil {
+		rule.MonthDay = *s.MonthDay
+	}
+	if s.EndsOn != nil {
+		rule.Until = *s.EndsOn
+	}
+	return rule
+}
+
+// NewOccurrence builds an event for the given date from the series template
+func (s *EventSeries) NewOccurrence(date time.Time, shortCode string) *Event {
+	return &Event{
+		ID:                 uuid.New(),
+		HostID:             s.HostID,
+		ShortCode:          shortCode,
+		Title:              s.Title,
+		Description:        s.Description,
+		EventDate:          date,
+		StartTime:          s.StartTime,
+		EndTime:            s.EndTime,
+		LocationName:       s.LocationName,
+		LocationAddress:    s.LocationAddress,
+		Latitude:           s.Latitude,
+		Longitude:          s.Longitude,
+		GooglePlaceID:      s.GooglePlaceID,
+		Capacity:           s.Capacity,
+		SkillLevel:         s.SkillLevel,
+		Fee:                s.Fee,
+		Status:             EventStatusOpen,
+		Visibility:         VisibilityPublic,
+		RegistrationAccess: AccessEveryone,
+	}
+}
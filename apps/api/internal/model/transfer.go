@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransferStatus represents the status of a spot transfer
+type TransferStatus string
+
+const (
+	TransferPending          TransferStatus = "pending"
+	TransferAwaitingApproval TransferStatus = "awaiting_approval"
+	TransferCompleted        TransferStatus = "completed"
+	TransferRejected         TransferStatus = "rejected"
+	TransferRevoked          TransferStatus = "revoked"
+)
+
+// SpotTransfer is a confirmed player's one-time link handing their spot to someone else
+type SpotTransfer struct {
+	ID               uuid.UUID      `db:"id" json:"id"`
+	EventID          uuid.UUID      `db:"event_id" json:"event_id"`
+	RegistrationID   uuid.UUID      `db:"registration_id" json:"registration_id"`
+	FromUserID       uuid.UUID      `db:"from_user_id" json:"from_user_id"`
+	ToUserID         *uuid.UUID     `db:"to_user_id" json:"to_user_id,omitempty"`
+	TokenHash        string         `db:"token_hash" json:"-"`
+	Status           TransferStatus `db:"status" json:"status"`
+	WaitlistBypassed int            `db:"waitlist_bypassed" json:"waitlist_bypassed"`
+	ExpiresAt        time.Time      `db:"expires_at" json:"expires_at"`
+	CreatedAt        time.Time      `db:"created_at" json:"created_at"`
+	AcceptedAt       *time.Time     `db:"accepted_at" json:"accepted_at,omitempty"`
+	ResolvedAt       *time.Time     `db:"resolved_at" json:"resolved_at,omitempty"`
+}
+
+// IsOpen reports whether the transfer can still be accepted or approved at now
+func (t *SpotTransfer) IsOpen(now time.Time) bool {
+	if t.Status != TransferPending && t.Status != TransferAwaitingApproval {
+		return false
+	}
+	return now.Before(t.ExpiresAt)
+}
+
+// SpotTransferWithUsers represents a spot transfer with the players involved
+type SpotTransferWithUsers struct {
+	SpotTransfer
+	FromUser UserProfile  `json:"from_user"`
+	ToUser   *UserProfile `json:"to_user,omitempty"`
+}
+
+// TransferExpiry returns when a transfer link created at now expires: after ttl,
+// but never later than the event start
+func TransferExpiry(now, start time.Time, ttl time.Duration) time.Time {
+	expires := now.Add(ttl)
+	if expires.After(start) {
+		return start
+	}
+	return expires
+}
@@ -13,6 +13,7 @@ type User struct {
 	DisplayName string     `db:"display_name" json:"display_name"`
 	AvatarURL   *string    `db:"avatar_url" json:"avatar_url,omitempty"`
 	Email       *string    `db:"email" json:"email,omitempty"`
+	LineFriend  bool       `db:"line_friend" json:"-"`
 	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt   time.Time  `db:"updated_at" json:"updated_at"`
 }
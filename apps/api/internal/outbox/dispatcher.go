@@ -0,0 +1,191 @@
+// Package outbox delivers side effects recorded in the transactional outbox.
+//
+// State changes enqueue outbox messages in the same transaction (see
+// repository.OutboxRepository), so a side effect is recorded if and only if the
+// change commits. The dispatcher then hands each message to the handler
+// registered for its topic, at least once, retrying failures with exponential
+// backoff and dead-lettering messages that keep failing. Handlers must
+// therefore be idempotent.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/google/uuid"
+)
+
+// Default dispatcher settings
+const (
+	DefaultBatchSize   = 100
+	DefaultMaxAttempts = 8
+	DefaultLease       = 5 * time.Minute
+	DefaultBaseBackoff = 10 * time.Second
+	DefaultMaxBackoff  = time.Hour
+)
+
+// Handler delivers outbox messages of one topic
+type Handler interface {
+	Handle(ctx context.Context, msg *model.OutboxMessage) error
+}
+
+// HandlerFunc adapts a function to a Handler
+type HandlerFunc func(ctx context.Context, msg *model.OutboxMessage) error
+
+// Handle calls f(ctx, msg)
+func (f HandlerFunc) Handle(ctx context.Context, msg *model.OutboxMessage) error {
+	return f(ctx, msg)
+}
+
+// permanentError marks an error that retrying will not fix
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps an error so the message is dead-lettered without further retries
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// IsPermanent reports whether err was wrapped with Permanent
+func IsPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
+
+// Store persists outbox messages
+type Store interface {
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.OutboxMessage, error)
+	MarkDone(ctx context.Context, id uuid.UUID) error
+	MarkRetry(ctx context.Context, id uuid.UUID, reason string, next time.Time) error
+	MarkDead(ctx context.Context, id uuid.UUID, reason string) error
+}
+
+// Options configures a Dispatcher. Zero values use the defaults.
+type Options struct {
+	// BatchSize is how many messages are claimed per Dispatch
+	BatchSize int
+	// MaxAttempts is how many attempts are made before a message is dead-lettered
+	MaxAttempts int
+	// Lease is how long a claimed message is hidden from other dispatchers
+	Lease time.Duration
+	// BaseBackoff is the delay after the first failure, doubled for each further failure
+	BaseBackoff time.Duration
+	// MaxBackoff caps the delay between attempts
+	MaxBackoff time.Duration
+}
+
+// Dispatcher delivers due outbox messages to the handlers registered for their topics
+type Dispatcher struct {
+	store    Store
+	opts     Options
+	handlers map[string]Handler
+	now      func() time.Time
+}
+
+// NewDispatcher creates a dispatcher
+func NewDispatcher(store Store, opts Options) *Dispatcher {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = DefaultLease
+	}
+	if opts.BaseBackoff <= 0 {
+		opts.BaseBackoff = DefaultBaseBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = DefaultMaxBackoff
+	}
+	return &Dispatcher{
+		store:    store,
+		opts:     opts,
+		handlers: make(map[string]Handler),
+		now:      time.Now,
+	}
+}
+
+// Register sets the handler for a topic. Handlers must be registered before dispatching.
+func (d *Dispatcher) Register(topic string, h Handler) {
+	d.handlers[topic] = h
+}
+
+// Dispatch delivers one batch of due messages. Handler failures are recorded
+// on the message and do not stop the batch. Returns the number of messages delivered.
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	messages, err := d.store.ClaimDue(ctx, d.opts.BatchSize, d.opts.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for i := range messages {
+		if ctx.Err() != nil {
+			// Unprocessed messages become due again once their lease runs out
+			return delivered, ctx.Err()
+		}
+
+		msg := &messages[i]
+		ok, err := d.deliver(ctx, msg)
+		if err != nil {
+			return delivered, err
+		}
+		if ok {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// deliver runs the handler for a message and records the outcome.
+// Returns whether the message was delivered.
+func (d *Dispatcher) deliver(ctx context.Context, msg *model.OutboxMessage) (bool, error) {
+	h, ok := d.handlers[msg.Topic]
+	if !ok {
+		return false, d.store.MarkDead(ctx, msg.ID, "no handler for topic "+msg.Topic)
+	}
+
+	handleErr := d.handle(ctx, h, msg)
+	if handleErr == nil {
+		return true, d.store.MarkDone(ctx, msg.ID)
+	}
+
+	if IsPermanent(handleErr) || msg.Attempts >= d.opts.MaxAttempts {
+		log.Printf("Outbox: dead-lettering %s message %s after %d attempts: %v", msg.Topic, msg.ID, msg.Attempts, handleErr)
+		return false, d.store.MarkDead(ctx, msg.ID, handleErr.Error())
+	}
+	return false, d.store.MarkRetry(ctx, msg.ID, handleErr.Error(), d.now().Add(d.backoff(msg.Attempts)))
+}
+
+// handle runs a handler, turning panics into errors
+func (d *Dispatcher) handle(ctx context.Context, h Handler, msg *model.OutboxMessage) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return h.Handle(ctx, msg)
+}
+
+// backoff returns the delay before the next attempt after the given number of attempts
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.opts.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= d.opts.MaxBackoff {
+			return d.opts.MaxBackoff
+		}
+	}
+	return delay
+}
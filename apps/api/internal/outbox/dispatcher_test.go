@@ -0,0 +1,113 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/google/uuid"
+)
+
+type fakeStore struct {
+	due   []model.OutboxMessage
+	done  []uuid.UUID
+	retry map[uuid.UUID]time.Time
+	dead  map[uuid.UUID]string
+}
+
+func newFakeStore(messages ...model.OutboxMessage) *fakeStore {
+	return &fakeStore{due: messages, retry: map[uuid.UUID]time.Time{}, dead: map[uuid.UUID]string{}}
+}
+
+func (s *fakeStore) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.OutboxMessage, error) {
+	claimed := s.due
+	for i := range claimed {
+		claimed[i].Attempts++
+	}
+	s.due = nil
+	return claimed, nil
+}
+
+func (s *fakeStore) MarkDone(ctx context.Context, id uuid.UUID) error {
+	s.done = append(s.done, id)
+	return nil
+}
+
+func (s *fakeStore) MarkRetry(ctx context.Context, id uuid.UUID, reason string, next time.Time) error {
+	s.retry[id] = next
+	return nil
+}
+
+func (s *fakeStore) MarkDead(ctx context.Context, id uuid.UUID, reason string) error {
+	s.dead[id] = reason
+	return nil
+}
+
+func newMessage(topic string, attempts int) model.OutboxMessage {
+	return model.OutboxMessage{ID: uuid.New(), Topic: topic, Payload: []byte(`{}`), Attempts: attempts}
+}
+
+func TestDispatch_Outcomes(t *testing.T) {
+	ok := newMessage("ok", 0)
+	flaky := newMessage("flaky", 0)
+	broken := newMessage("broken", 0)
+	exhausted := newMessage("flaky", 2)
+	unknown := newMessage("unknown", 0)
+	panics := newMessage("panics", 0)
+	store := newFakeStore(ok, flaky, broken, exhausted, unknown, panics)
+
+	d := NewDispatcher(store, Options{MaxAttempts: 3, BaseBackoff: time.Minute})
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+	d.Register("ok", HandlerFunc(func(ctx context.Context, msg *model.OutboxMessage) error { return nil }))
+	d.Register("flaky", HandlerFunc(func(ctx context.Context, msg *model.OutboxMessage) error {
+		return errors.New("temporary failure")
+	}))
+	d.Register("broken", HandlerFunc(func(ctx context.Context, msg *model.OutboxMessage) error {
+		return Permanent(errors.New("bad payload"))
+	}))
+	d.Register("panics", HandlerFunc(func(ctx context.Context, msg *model.OutboxMessage) error {
+		panic("boom")
+	}))
+
+	n, err := d.Dispatch(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 1 || len(store.done) != 1 || store.done[0] != ok.ID {
+		t.Errorf("expected only %s delivered, got n=%d done=%v", ok.ID, n, store.done)
+	}
+	if next, retried := store.retry[flaky.ID]; !retried || !next.Equal(now.Add(time.Minute)) {
+		t.Errorf("expected flaky message retried at %v, got %v", now.Add(time.Minute), next)
+	}
+	if len(store.retry) != 2 {
+		t.Errorf("expected flaky and panicking messages retried, got %v", store.retry)
+	}
+	for _, id := range []uuid.UUID{broken.ID, exhausted.ID, unknown.ID} {
+		if _, dead := store.dead[id]; !dead {
+			t.Errorf("expected message %s dead-lettered", id)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	d := NewDispatcher(newFakeStore(), Options{BaseBackoff: 10 * time.Second, MaxBackoff: time.Minute})
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{3, 40 * time.Second},
+		{4, time.Minute},
+		{20, time.Minute},
+	}
+	for _, tt := range tests {
+		if got := d.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+)
+
+// NotificationHandler creates the in-app notifications enqueued under
+// model.OutboxTopicNotification. Creating a notification is idempotent on its
+// ID, so redelivery never creates duplicates.
+type NotificationHandler struct {
+	notificationRepo *repository.NotificationRepository
+}
+
+// NewNotificationHandler creates a new NotificationHandler
+func NewNotificationHandler(notificationRepo *repository.NotificationRepository) *NotificationHandler {
+	return &NotificationHandler{notificationRepo: notificationRepo}
+}
+
+// Handle implements Handler
+func (h *NotificationHandler) Handle(ctx context.Context, msg *model.OutboxMessage) error {
+	var n model.Notification
+	if err := json.Unmarshal(msg.Payload, &n); err != nil {
+		return Permanent(err)
+	}
+	return h.notificationRepo.Create(ctx, &n)
+}
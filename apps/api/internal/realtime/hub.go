@@ -0,0 +1,144 @@
+// Package realtime fans out database changes to live Server-Sent Event streams.
+//
+// Triggers on registrations, events and notifications publish a Change on the
+// realtime_changes channel with pg_notify (see migration 000011). Every API
+// replica listens on that channel, so a stream sees changes made through any
+// replica or background job. A change only says what changed; streams reload
+// the current state themselves, which keeps payloads small and lets a stream
+// that missed changes catch up on the next one.
+package realtime
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Channel is the Postgres notification channel changes are published on
+const Channel = "realtime_changes"
+
+// subscriberBuffer is how many changes a subscriber can fall behind before
+// further changes are dropped for it
+const subscriberBuffer = 16
+
+// ChangeType is what kind of row changed
+type ChangeType string
+
+const (
+	// ChangeEvent is a change to an event's status, capacity or registration counts
+	ChangeEvent ChangeType = "event"
+	// ChangeRegistration is a change to a user's registration status or waitlist position
+	ChangeRegistration ChangeType = "registration"
+	// ChangeNotification is a new notification in a user's inbox
+	ChangeNotification ChangeType = "notification"
+	// ChangeResync is sent to every subscriber after the connection to the
+	// database was re-established, since changes may have been missed
+	ChangeResync ChangeType = "resync"
+)
+
+// Change is a change published by the database triggers
+type Change struct {
+	Type           ChangeType `json:"type"`
+	EventID        *uuid.UUID `json:"event_id,omitempty"`
+	UserID         *uuid.UUID `json:"user_id,omitempty"`
+	NotificationID *uuid.UUID `json:"notification_id,omitempty"`
+}
+
+// EventTopic is the topic for changes to an event
+func EventTopic(eventID uuid.UUID) string {
+	return "event:" + eventID.String()
+}
+
+// UserTopic is the topic for changes to a user's registrations and notifications
+func UserTopic(userID uuid.UUID) string {
+	return "user:" + userID.String()
+}
+
+// topic returns the topic a change is delivered to, or "" if it has none
+func (c *Change) topic() string {
+	switch c.Type {
+	case ChangeEvent:
+		if c.EventID != nil {
+			return EventTopic(*c.EventID)
+		}
+	case ChangeRegistration, ChangeNotification:
+		if c.UserID != nil {
+			return UserTopic(*c.UserID)
+		}
+	}
+	return ""
+}
+
+// Hub delivers changes to the streams subscribed to them
+type Hub struct {
+	mu   sync.RWMutex
+	subs map[string]map[chan Change]struct{}
+}
+
+// NewHub creates a new Hub
+func NewHub() *Hub {
+	return &Hub{subs: make(map[string]map[chan Change]struct{})}
+}
+
+// Subscribe starts receiving changes for a topic. Call the returned function
+// to stop; the channel is not closed.
+func (h *Hub) Subscribe(topic string) (<-chan Change, func()) {
+	ch := make(chan Change, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subs[topic] == nil {
+		h.subs[topic] = make(map[chan Change]struct{})
+	}
+	h.subs[topic][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subs[topic], ch)
+			if len(h.subs[topic]) == 0 {
+				delete(h.subs, topic)
+			}
+			h.mu.Unlock()
+		})
+	}
+}
+
+// Publish delivers a change to its topic's subscribers, or to every subscriber
+// for a resync. It never blocks: a subscriber that has fallen behind misses the change.
+func (h *Hub) Publish(change Change) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if change.Type == ChangeResync {
+		for _, subs := range h.subs {
+			for ch := range subs {
+				send(ch, change)
+			}
+		}
+		return
+	}
+
+	for ch := range h.subs[change.topic()] {
+		send(ch, change)
+	}
+}
+
+// Subscribers returns the number of open subscriptions
+func (h *Hub) Subscribers() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	n := 0
+	for _, subs := range h.subs {
+		n += len(subs)
+	}
+	return n
+}
+
+func send(ch chan Change, change Change) {
+	select {
+	case ch <- change:
+	default:
+	}
+}
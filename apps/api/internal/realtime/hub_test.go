@@ -0,0 +1,77 @@
+package realtime
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestHub_RoutesChangesByTopic(t *testing.T) {
+	hub := NewHub()
+	eventID, userID, otherUser := uuid.New(), uuid.New(), uuid.New()
+
+	eventChanges, unsubscribeEvent := hub.Subscribe(EventTopic(eventID))
+	defer unsubscribeEvent()
+	userChanges, unsubscribeUser := hub.Subscribe(UserTopic(userID))
+	defer unsubscribeUser()
+
+	hub.Publish(Change{Type: ChangeEvent, EventID: &eventID})
+	hub.Publish(Change{Type: ChangeRegistration, EventID: &eventID, UserID: &userID})
+	hub.Publish(Change{Type: ChangeNotification, UserID: &otherUser})
+
+	if got := len(eventChanges); got != 1 {
+		t.Errorf("expected 1 event change, got %d", got)
+	}
+	if got := len(userChanges); got != 1 {
+		t.Fatalf("expected 1 user change, got %d", got)
+	}
+	if change := <-userChanges; change.Type != ChangeRegistration {
+		t.Errorf("expected registration change, got %s", change.Type)
+	}
+}
+
+func TestHub_ResyncReachesEverySubscriber(t *testing.T) {
+	hub := NewHub()
+	first, unsubscribeFirst := hub.Subscribe(EventTopic(uuid.New()))
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := hub.Subscribe(UserTopic(uuid.New()))
+	defer unsubscribeSecond()
+
+	hub.Publish(Change{Type: ChangeResync})
+
+	if len(first) != 1 || len(second) != 1 {
+		t.Errorf("expected every subscriber to get the resync, got %d and %d", len(first), len(second))
+	}
+}
+
+func TestHub_Unsubscribe(t *testing.T) {
+	hub := NewHub()
+	eventID := uuid.New()
+	changes, unsubscribe := hub.Subscribe(EventTopic(eventID))
+
+	unsubscribe()
+	unsubscribe()
+	hub.Publish(Change{Type: ChangeEvent, EventID: &eventID})
+
+	if len(changes) != 0 {
+		t.Errorf("expected no changes after unsubscribing, got %d", len(changes))
+	}
+	if n := hub.Subscribers(); n != 0 {
+		t.Errorf("expected no subscribers, got %d", n)
+	}
+}
+
+func TestHub_SlowSubscriberDoesNotBlock(t *testing.T) {
+	hub := NewHub()
+	eventID := uuid.New()
+	changes, unsubscribe := hub.Subscribe(EventTopic(eventID))
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBuffer*2; i++ {
+		hub.Publish(Change{Type: ChangeEvent, EventID: &eventID})
+	}
+
+	if got := len(changes); got != subscriberBuffer {
+		t.Errorf("expected %d buffered changes, got %d", subscriberBuffer, got)
+	}
+}
@@ -0,0 +1,61 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Listener connection settings
+const (
+	minReconnectInterval = 10 * time.Second
+	maxReconnectInterval = time.Minute
+	// pingInterval checks an idle connection is still alive
+	pingInterval = 90 * time.Second
+)
+
+// Listen relays changes published on Channel to the hub until ctx is done.
+// The connection is re-established automatically if it drops.
+func Listen(ctx context.Context, dsn string, hub *Hub) error {
+	listener := pq.NewListener(dsn, minReconnectInterval, maxReconnectInterval, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("Realtime listener: %v", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(Channel); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-listener.Notify:
+			if n == nil {
+				// Reconnected; anything published meanwhile was lost
+				hub.Publish(Change{Type: ChangeResync})
+				continue
+			}
+			var change Change
+			if err := json.Unmarshal([]byte(n.Extra), &change); err != nil {
+				log.Printf("Realtime listener: invalid payload %q: %v", n.Extra, err)
+				continue
+			}
+			hub.Publish(change)
+		case <-ticker.C:
+			go func() {
+				if err := listener.Ping(); err != nil {
+					log.Printf("Realtime listener: ping failed: %v", err)
+				}
+			}()
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// BlockRepository handles host block list data access
+type BlockRepository struct {
+	db *sqlx.DB
+}
+
+// NewBlockRepository creates a new BlockRepository
+func NewBlockRepository(db *sqlx.DB) *BlockRepository {
+	return &BlockRepository{db: db}
+}
+
+// BlockTx adds a user to the host's block list within a transaction. Blocking
+// someone who is already blocked updates the reason and keeps when they were blocked.
+func (r *BlockRepository) BlockTx(ctx context.Context, tx *sqlx.Tx, block *model.HostBlock) error {
+	query := `
+		INSERT INTO host_blocks (host_id, user_id, reason)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (host_id, user_id) DO UPDATE SET reason = EXCLUDED.reason
+		RETURNING created_at`
+
+	return tx.QueryRowxContext(ctx, query, block.HostID, block.UserID, block.Reason).Scan(&block.CreatedAt)
+}
+
+// Unblock removes a user from the host's block list
+func (r *BlockRepository) Unblock(ctx context.Context, hostID, userID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM host_blocks WHERE host_id = $1 AND user_id = $2`, hostID, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// FindByHostID finds the users a host has blocked, most recently blocked first
+func (r *BlockRepository) FindByHostID(ctx context.Context, hostID uuid.UUID) ([]model.HostBlockWithUser, error) {
+	query := `
+		SELECT b.host_id, b.user_id, b.reason, b.created_at, u.display_name, u.avatar_url
+		FROM host_blocks b
+		JOIN users u ON u.id = b.user_id
+		WHERE b.host_id = $1
+		ORDER BY b.created_at DESC`
+
+	rows, err := r.db.QueryxContext(ctx, query, hostID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []model.HostBlockWithUser
+	for rows.Next() {
+		var block model.HostBlockWithUser
+		err := rows.Scan(
+			&block.HostID, &block.UserID, &block.Reason, &block.CreatedAt,
+			&block.User.DisplayName, &block.User.AvatarURL,
+		)
+		if err != nil {
+			return nil, err
+		}
+		block.User.ID = block.UserID
+		results = append(results, block)
+	}
+
+	return results, rows.Err()
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// ClubRepository handles club and club membership data access
+type ClubRepository struct {
+	db *sqlx.DB
+}
+
+// NewClubRepository creates a new ClubRepository
+func NewClubRepository(db *sqlx.DB) *ClubRepository {
+	return &ClubRepository{db: db}
+}
+
+// CreateTx creates a club within a transaction, making its creator an active owner
+func (r *ClubRepository) CreateTx(ctx context.Context, tx *sqlx.Tx, club *model.Club) error {
+	query := `
+		INSERT INTO clubs (id, name, description, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at, updated_at`
+
+	err := tx.QueryRowxContext(ctx, query, club.ID, club.Name, club.Description, club.CreatedBy).
+		Scan(&club.CreatedAt, &club.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO club_members (club_id, user_id, role, status, joined_at)
+		VALUES ($1, $2, 'owner', 'active', NOW())`,
+		club.ID, club.CreatedBy)
+	return err
+}
+
+// FindByID finds a club by ID
+func (r *ClubRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Club, error) {
+	var club model.Club
+	err := r.db.GetContext(ctx, &club, `SELECT * FROM clubs WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	return &club, nil
+}
+
+// Update updates a club's name and description
+func (r *ClubRepository) Update(ctx context.Context, club *model.Club) error {
+	query := `
+		UPDATE clubs SET name = $2, description = $3
+		WHERE id = $1
+		RETURNING updated_at`
+
+	return r.db.QueryRowxContext(ctx, query, club.ID, club.Name, club.Description).Scan(&club.UpdatedAt)
+}
+
+// FindMember finds a user's membership of a club, including a pending invite or
+// join request. It returns sql.ErrNoRows if the user has neither.
+func (r *ClubRepository) FindMember(ctx context.Context, clubID, userID uuid.UUID) (*model.ClubMember, error) {
+	var member model.ClubMember
+	err := r.db.GetContext(ctx, &member,
+		`SELECT * FROM club_members WHERE club_id = $1 AND user_id = $2`,
+		clubID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// FindMembers finds a club's members, owners and admins first. Pending invites
+// and join requests are only included when includePending is set.
+func (r *ClubRepository) FindMembers(ctx context.Context, clubID uuid.UUID, includePending bool) ([]model.ClubMemberWithUser, error) {
+	query := `
+		SELECT
+			m.club_id, m.user_id, m.role, m.status, m.invited_by, m.created_at, m.joined_at,
+			u.display_name, u.avatar_url
+		FROM club_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.club_id = $1 AND ($2 OR m.status = 'active')
+		ORDER BY m.status = 'active' DESC, m.role = 'owner' DESC, m.role = 'admin' DESC, m.created_at`
+
+	rows, err := r.db.QueryxContext(ctx, query, clubID, includePending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []model.ClubMemberWithUser
+	for rows.Next() {
+		var member model.ClubMemberWithUser
+		err := rows.Scan(
+			&member.ClubID, &member.UserID, &member.Role, &member.Status, &member.InvitedBy,
+			&member.CreatedAt, &member.JoinedAt,
+			&member.User.DisplayName, &member.User.AvatarURL,
+		)
+		if err != nil {
+			return nil, err
+		}
+		member.User.ID = member.UserID
+		results = append(results, member)
+	}
+
+	return results, rows.Err()
+}
+
+// CountMembers counts a club's active members
+func (r *ClubRepository) CountMembers(ctx context.Context, clubID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.GetContext(ctx, &count,
+		`SELECT COUNT(*) FROM club_members WHERE club_id = $1 AND status = 'active'`,
+		clubID)
+	return count, err
+}
+
+// CountOwners counts a club's active owners
+func (r *ClubRepository) CountOwners(ctx context.Context, clubID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.GetContext(ctx, &count,
+		`SELECT COUNT(*) FROM club_members WHERE club_id = $1 AND role = 'owner' AND status = 'active'`,
+		clubID)
+	return count, err
+}
+
+// JoinTx records one side of a user joining a club within a transaction:
+// member.Status is invited when a club admin asks the user to join and
+// requested when the user asks to join. Once both sides have asked, the
+// membership becomes active. An invite sets the role; a join request keeps the
+// role it was invited with. member is filled in with the stored membership.
+func (r *ClubRepository) JoinTx(ctx context.Context, tx *sqlx.Tx, member *model.ClubMember) error {
+	query := `
+		INSERT INTO club_members (club_id, user_id, role, status, invited_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (club_id, user_id) DO UPDATE SET
+			status = CASE WHEN club_members.status IN ('active', EXCLUDED.status)
+				THEN club_members.status ELSE 'active' END,
+			role = CASE WHEN EXCLUDED.status = 'invited' AND club_members.status <> 'active'
+				THEN EXCLUDED.role ELSE club_members.role END,
+			invited_by = COALESCE(club_members.invited_by, EXCLUDED.invited_by),
+			joined_at = CASE WHEN club_members.status NOT IN ('active', EXCLUDED.status)
+				THEN NOW() ELSE club_members.joined_at END
+		RETURNING *`
+
+	return tx.GetContext(ctx, member, query,
+		member.ClubID, member.UserID, member.Role, member.Status, member.InvitedBy)
+}
+
+// UpdateRole changes an active member's role. It returns ErrNotFound if the
+// user is not an active member of the club.
+func (r *ClubRepository) UpdateRole(ctx context.Context, clubID, userID uuid.UUID, role model.ClubRole) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE club_members SET role = $3 WHERE club_id = $1 AND user_id = $2 AND status = 'active'`,
+		clubID, userID, role)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RemoveMember removes a user's membership, invite or join request
+func (r *ClubRepository) RemoveMember(ctx context.Context, clubID, userID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM club_members WHERE club_id = $1 AND user_id = $2`,
+		clubID, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// AreMembers reports whether every one of userIDs is an active member of the club
+func (r *ClubRepository) AreMembers(ctx context.Context, clubID uuid.UUID, userIDs []uuid.UUID) (bool, error) {
+	var count int
+	err := r.db.GetContext(ctx, &count,
+		`SELECT COUNT(*) FROM club_members WHERE club_id = $1 AND user_id = ANY($2) AND status = 'active'`,
+		clubID, pq.Array(userIDs))
+	if err != nil {
+		return false, err
+	}
+	return count == len(userIDs), nil
+}
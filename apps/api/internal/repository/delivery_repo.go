@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// DeliveryRepository handles notification delivery data access
+type DeliveryRepository struct {
+	db *sqlx.DB
+}
+
+// NewDeliveryRepository creates a new DeliveryRepository
+func NewDeliveryRepository(db *sqlx.DB) *DeliveryRepository {
+	return &DeliveryRepository{db: db}
+}
+
+// FindPending finds pending deliveries for a channel that are no longer held
+// back by quiet hours, oldest first, together with their notification and recipient
+func (r *DeliveryRepository) FindPending(ctx context.Context, channel model.DeliveryChannel, limit int) ([]model.PendingDelivery, error) {
+	var deliveries []model.PendingDelivery
+	query := `
+		SELECT d.id, d.notification_id, d.attempts,
+			   n.user_id, n.event_id, n.type, n.title, n.message,
+			   u.line_user_id, u.line_friend
+		FROM notification_deliveries d
+		JOIN notifications n ON n.id = d.notification_id
+		JOIN users u ON u.id = n.user_id
+		WHERE d.channel = $1 AND d.status = 'pending' AND d.deliver_after <= NOW()
+		ORDER BY d.created_at ASC
+		LIMIT $2`
+	err := r.db.SelectContext(ctx, &deliveries, query, channel, limit)
+	return deliveries, err
+}
+
+// FindByNotificationID finds every delivery of a notification
+func (r *DeliveryRepository) FindByNotificationID(ctx context.Context, notificationID uuid.UUID) ([]model.NotificationDelivery, error) {
+	var deliveries []model.NotificationDelivery
+	query := `SELECT * FROM notification_deliveries WHERE notification_id = $1 ORDER BY channel`
+	err := r.db.SelectContext(ctx, &deliveries, query, notificationID)
+	return deliveries, err
+}
+
+// MarkSent records a successful delivery
+func (r *DeliveryRepository) MarkSent(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE notification_deliveries
+		SET status = 'sent', attempts = attempts + 1, last_error = NULL, sent_at = NOW()
+		WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// MarkRetry records a failed delivery attempt that is worth retrying. The
+// delivery stays pending and is picked up again at retryAt.
+func (r *DeliveryRepository) MarkRetry(ctx context.Context, id uuid.UUID, reason string, retryAt time.Time) error {
+	query := `
+		UPDATE notification_deliveries
+		SET attempts = attempts + 1, last_error = $2, deliver_after = $3
+		WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id, reason, retryAt)
+	return err
+}
+
+// MarkFailed records a failed delivery attempt that will not be retried
+func (r *DeliveryRepository) MarkFailed(ctx context.Context, id uuid.UUID, reason string) error {
+	query := `
+		UPDATE notification_deliveries
+		SET status = 'failed', attempts = attempts + 1, last_error = $2
+		WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id, reason)
+	return err
+}
+
+// MarkSkipped records that a notification was not pushed and why
+func (r *DeliveryRepository) MarkSkipped(ctx context.Context, id uuid.UUID, reason string) error {
+	query := `
+		UPDATE notification_deliveries
+		SET status = 'skipped', last_error = $2
+		WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id, reason)
+	return err
+}
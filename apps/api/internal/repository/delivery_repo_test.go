@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/google/uuid"
+)
+
+func TestDeliveryFindPending(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	repo := NewDeliveryRepository(db)
+	deliveryID := uuid.New()
+	eventID := uuid.New()
+
+	rows := sqlmock.NewRows([]string{
+		"id", "notification_id", "attempts", "user_id", "event_id",
+		"type", "title", "message", "line_user_id", "line_friend",
+	}).AddRow(deliveryID, uuid.New(), 0, uuid.New(), eventID,
+		model.NotificationEventReminder, "Your game starts in 2 hours", nil, "U123", true)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE d.channel = $1 AND d.status = 'pending'`)).
+		WithArgs(model.DeliveryChannelLine, 50).
+		WillReturnRows(rows)
+
+	pending, err := repo.FindPending(context.Background(), model.DeliveryChannelLine, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != deliveryID {
+		t.Fatalf("unexpected pending deliveries %+v", pending)
+	}
+	if pending[0].EventID == nil || *pending[0].EventID != eventID || !pending[0].LineFriend {
+		t.Errorf("unexpected delivery %+v", pending[0])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDeliveryMarkFailed(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	repo := NewDeliveryRepository(db)
+	deliveryID := uuid.New()
+
+	mock.ExpectExec(regexp.QuoteMeta(`SET status = 'failed', attempts = attempts + 1, last_error = $2`)).
+		WithArgs(deliveryID, "line api error 400: invalid").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.MarkFailed(context.Background(), deliveryID, "line api error 400: invalid"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDeliveryMarkRetry(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	repo := NewDeliveryRepository(db)
+	deliveryID := uuid.New()
+	retryAt := time.Now().Add(time.Minute)
+
+	mock.ExpectExec(regexp.QuoteMeta(`SET attempts = attempts + 1, last_error = $2, deliver_after = $3`)).
+		WithArgs(deliveryID, "line api error 429: rate limited", retryAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := repo.MarkRetry(context.Background(), deliveryID, "line api error 429: rate limited", retryAt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
@@ -30,4 +30,32 @@ var (
 
 	// ErrNoWaitlist is returned when there's no one in the waitlist to promote
 	ErrNoWaitlist = errors.New("no one in waitlist")
+
+	// ErrInvalidStatusTransition is returned when an event cannot move to the requested status
+	ErrInvalidStatusTransition = errors.New("invalid event status transition")
+
+	// ErrNoOffer is returned when a registration has no pending waitlist offer
+	ErrNoOffer = errors.New("no pending waitlist offer")
+
+	// ErrOfferExpired is returned when accepting a waitlist offer after it has expired
+	ErrOfferExpired = errors.New("waitlist offer has expired")
+
+	// ErrCapacityBelowHeld is returned when capacity is lowered below the spots already held
+	ErrCapacityBelowHeld = errors.New("capacity is below the number of held spots")
+
+	// ErrTransferUnavailable is returned when a spot transfer has been used, revoked or has expired,
+	// or the spot it hands over is no longer confirmed
+	ErrTransferUnavailable = errors.New("spot transfer is no longer available")
+
+	// ErrNotPending is returned when approving or rejecting a registration that is not awaiting approval
+	ErrNotPending = errors.New("registration is not awaiting approval")
+
+	// ErrNotWaitlisted is returned when promoting or moving a registration that is not on the waitlist
+	ErrNotWaitlisted = errors.New("registration is not on the waitlist")
+
+	// ErrNotEnoughSeats is returned when promoting a waitlisted party that does not fit in the free seats
+	ErrNotEnoughSeats = errors.New("not enough free seats")
+
+	// ErrBlocked is returned when a user registers for an event whose host has blocked them
+	ErrBlocked = errors.New("user is blocked by the host")
 )
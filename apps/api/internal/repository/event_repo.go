@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
 	"github.com/anthropics/pickle-go/apps/api/internal/model"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 // EventRepository handles event data access
@@ -20,15 +22,41 @@ func NewEventRepository(db *sqlx.DB) *EventRepository {
 
 // FindByID finds an event by ID
 func (r *EventRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Event, error) {
+	return r.findByID(ctx, r.db, id)
+}
+
+// FindByIDTx finds an event by ID within a transaction
+func (r *EventRepository) FindByIDTx(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (*model.Event, error) {
+	return r.findByID(ctx, tx, id)
+}
+
+func (r *EventRepository) findByID(ctx context.Context, db database.DBTX, id uuid.UUID) (*model.Event, error) {
 	var event model.Event
 	query := `
 		SELECT id, host_id, COALESCE(short_code, '') as short_code, title, description, event_date, start_time, end_time,
 			   location_name, location_address,
 			   ST_Y(location_point::geometry) as latitude,
 			   ST_X(location_point::geometry) as longitude,
-			   google_place_id, capacity, skill_level, fee, status, created_at, updated_at
+			   google_place_id, capacity, skill_level, fee, status, min_reliability, cancel_deadline_hours, transfer_requires_approval, max_guests, requires_approval, visibility, club_id, registration_access, member_priority_hours, created_at, updated_at
 		FROM events WHERE id = $1`
-	err := r.db.GetContext(ctx, &event, query, id)
+	err := db.GetContext(ctx, &event, query, id)
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// FindByIDForUpdate finds and locks an event row within a transaction
+func (r *EventRepository) FindByIDForUpdate(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (*model.Event, error) {
+	var event model.Event
+	query := `
+		SELECT id, host_id, COALESCE(short_code, '') as short_code, title, description, event_date, start_time, end_time,
+			   location_name, location_address,
+			   ST_Y(location_point::geometry) as latitude,
+			   ST_X(location_point::geometry) as longitude,
+			   google_place_id, capacity, skill_level, fee, status, min_reliability, cancel_deadline_hours, transfer_requires_approval, max_guests, requires_approval, visibility, club_id, registration_access, member_priority_hours, created_at, updated_at
+		FROM events WHERE id = $1 FOR UPDATE`
+	err := tx.GetContext(ctx, &event, query, id)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +74,7 @@ type EventFilter struct {
 	Offset     int
 }
 
-// FindNearby finds events near a given location
+// FindNearby finds public events near a given location
 func (r *EventRepository) FindNearby(ctx context.Context, filter EventFilter) ([]model.EventSummary, error) {
 	var events []model.EventSummary
 
@@ -56,15 +84,16 @@ func (r *EventRepository) FindNearby(ctx context.Context, filter EventFilter) ([
 			e.location_name, e.location_address,
 			ST_Y(e.location_point::geometry) as latitude,
 			ST_X(e.location_point::geometry) as longitude,
-			e.google_place_id, e.capacity, e.skill_level, e.fee, e.status, e.created_at, e.updated_at,
-			COALESCE(COUNT(CASE WHEN r.status = 'confirmed' THEN 1 END), 0) as confirmed_count,
-			COALESCE(COUNT(CASE WHEN r.status = 'waitlist' THEN 1 END), 0) as waitlist_count
+			e.google_place_id, e.capacity, e.skill_level, e.fee, e.status, e.min_reliability, e.cancel_deadline_hours, e.transfer_requires_approval, e.max_guests, e.requires_approval, e.visibility, e.club_id, e.registration_access, e.member_priority_hours, e.created_at, e.updated_at,
+			COALESCE(SUM(CASE WHEN r.status = 'confirmed' THEN 1 + r.guest_count END), 0) as confirmed_count,
+			COALESCE(SUM(CASE WHEN r.status = 'waitlist' THEN 1 + r.guest_count END), 0) as waitlist_count
 		FROM events e
 		LEFT JOIN registrations r ON e.id = r.event_id
 		WHERE ST_DWithin(e.location_point, ST_MakePoint($1, $2)::geography, $3)
 		AND ($4 = '' OR e.skill_level = $4)
 		AND ($5 = '' OR e.status = $5)
 		AND e.event_date >= CURRENT_DATE
+		AND e.visibility = 'public'
 		GROUP BY e.id
 		ORDER BY e.event_date ASC, e.start_time ASC
 		LIMIT $6 OFFSET $7`
@@ -88,7 +117,7 @@ func (r *EventRepository) FindByHostID(ctx context.Context, hostID uuid.UUID) ([
 			   location_name, location_address,
 			   ST_Y(location_point::geometry) as latitude,
 			   ST_X(location_point::geometry) as longitude,
-			   google_place_id, capacity, skill_level, fee, status, created_at, updated_at
+			   google_place_id, capacity, skill_level, fee, status, min_reliability, cancel_deadline_hours, transfer_requires_approval, max_guests, requires_approval, visibility, club_id, registration_access, member_priority_hours, created_at, updated_at
 		FROM events
 		WHERE host_id = $1
 		ORDER BY event_date DESC, start_time DESC`
@@ -101,46 +130,128 @@ func (r *EventRepository) FindByHostID(ctx context.Context, hostID uuid.UUID) ([
 
 // Create creates a new event
 func (r *EventRepository) Create(ctx context.Context, event *model.Event) error {
+	return r.create(ctx, r.db, event)
+}
+
+// CreateTx creates a new event within a transaction
+func (r *EventRepository) CreateTx(ctx context.Context, tx *sqlx.Tx, event *model.Event) error {
+	return r.create(ctx, tx, event)
+}
+
+func (r *EventRepository) create(ctx context.Context, db database.DBTX, event *model.Event) error {
 	query := `
 		INSERT INTO events (
 			id, host_id, short_code, title, description, event_date, start_time, end_time,
 			location_name, location_address, location_point, google_place_id,
-			capacity, skill_level, fee, status, created_at, updated_at
+			capacity, skill_level, fee, min_reliability, cancel_deadline_hours, transfer_requires_approval, max_guests, requires_approval, visibility,
+			club_id, registration_access, member_priority_hours, status, created_at, updated_at
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
 			ST_SetSRID(ST_MakePoint($11, $12), 4326)::geography,
-			$13, $14, $15, $16, 'open', NOW(), NOW()
+			$13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, 'open', NOW(), NOW()
 		)
 		RETURNING created_at, updated_at`
-	return r.db.QueryRowxContext(ctx, query,
+	return db.QueryRowxContext(ctx, query,
 		event.ID, event.HostID, event.ShortCode, event.Title, event.Description,
 		event.EventDate, event.StartTime, event.EndTime,
 		event.LocationName, event.LocationAddress,
 		event.Longitude, event.Latitude, event.GooglePlaceID,
-		event.Capacity, event.SkillLevel, event.Fee,
+		event.Capacity, event.SkillLevel, event.Fee, event.MinReliability, event.CancelDeadlineHours, event.TransferApproval,
+		event.MaxGuests, event.RequiresApproval, event.Visibility,
+		event.ClubID, event.RegistrationAccess, event.MemberPriorityHours,
 	).StructScan(event)
 }
 
-// Update updates an existing event
+// Update updates an existing event's details. Status is left alone; it only
+// changes through UpdateStatus, SyncEventStatusTx and CompletePastEvents so it
+// always follows the event state machine.
 func (r *EventRepository) Update(ctx context.Context, event *model.Event) error {
+	return r.update(ctx, r.db, event)
+}
+
+// UpdateTx updates an existing event within a transaction
+func (r *EventRepository) UpdateTx(ctx context.Context, tx *sqlx.Tx, event *model.Event) error {
+	return r.update(ctx, tx, event)
+}
+
+func (r *EventRepository) update(ctx context.Context, db database.DBTX, event *model.Event) error {
 	query := `
 		UPDATE events SET
 			title = $2, description = $3, event_date = $4, start_time = $5, end_time = $6,
-			capacity = $7, skill_level = $8, fee = $9, status = $10, updated_at = NOW()
+			capacity = $7, skill_level = $8, fee = $9, min_reliability = $10,
+			cancel_deadline_hours = $11, transfer_requires_approval = $12, max_guests = $13, requires_approval = $14, visibility = $15,
+			registration_access = $16, member_priority_hours = $17, updated_at = NOW()
 		WHERE id = $1
 		RETURNING updated_at`
-	return r.db.QueryRowxContext(ctx, query,
+	return db.QueryRowxContext(ctx, query,
 		event.ID, event.Title, event.Description, event.EventDate,
 		event.StartTime, event.EndTime, event.Capacity,
-		event.SkillLevel, event.Fee, event.Status,
+		event.SkillLevel, event.Fee, event.MinReliability, event.CancelDeadlineHours, event.TransferApproval,
+		event.MaxGuests, event.RequiresApproval, event.Visibility,
+		event.RegistrationAccess, event.MemberPriorityHours,
 	).Scan(&event.UpdatedAt)
 }
 
-// UpdateStatus updates the status of an event
+// UpdateStatus moves an event to a new status, enforcing the event state machine.
+// Returns ErrInvalidStatusTransition if the event's current status cannot move to status.
 func (r *EventRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status model.EventStatus) error {
-	query := `UPDATE events SET status = $2, updated_at = NOW() WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id, status)
-	return err
+	return r.updateStatus(ctx, r.db, id, status)
+}
+
+// UpdateStatusTx moves an event to a new status within a transaction, enforcing the event state machine
+func (r *EventRepository) UpdateStatusTx(ctx context.Context, tx *sqlx.Tx, id uuid.UUID, status model.EventStatus) error {
+	return r.updateStatus(ctx, tx, id, status)
+}
+
+func (r *EventRepository) updateStatus(ctx context.Context, db database.DBTX, id uuid.UUID, status model.EventStatus) error {
+	query := `UPDATE events SET status = $2, updated_at = NOW() WHERE id = $1 AND status = ANY($3)`
+	result, err := db.ExecContext(ctx, query, id, status, statusArray(model.StatusesBefore(status)))
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := db.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM events WHERE id = $1)`, id); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNotFound
+	}
+	return ErrInvalidStatusTransition
+}
+
+// statusArray converts event statuses to a Postgres text array
+func statusArray(statuses []model.EventStatus) pq.StringArray {
+	arr := make(pq.StringArray, len(statuses))
+	for i, s := range statuses {
+		arr[i] = string(s)
+	}
+	return arr
+}
+
+// CompletePastEvents marks open and full events as completed once they have ended.
+// Event dates and times are wall-clock times in the given time zone; an end time
+// before the start time means the event runs past midnight, and events without
+// an end time are completed once they start. Returns the number of events completed.
+func (r *EventRepository) CompletePastEvents(ctx context.Context, timezone string) (int64, error) {
+	query := `
+		UPDATE events SET status = 'completed', updated_at = NOW()
+		WHERE status IN ('open', 'full')
+		AND (event_date + COALESCE(end_time, start_time)
+			+ CASE WHEN end_time < start_time THEN INTERVAL '1 day' ELSE INTERVAL '0' END
+		) AT TIME ZONE $1 <= NOW()`
+	result, err := r.db.ExecContext(ctx, query, timezone)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 // Delete deletes an event by ID
@@ -168,7 +279,7 @@ func (r *EventRepository) FindByShortCode(ctx context.Context, shortCode string)
 			   location_name, location_address,
 			   ST_Y(location_point::geometry) as latitude,
 			   ST_X(location_point::geometry) as longitude,
-			   google_place_id, capacity, skill_level, fee, status, created_at, updated_at
+			   google_place_id, capacity, skill_level, fee, status, min_reliability, cancel_deadline_hours, transfer_requires_approval, max_guests, requires_approval, visibility, club_id, registration_access, member_priority_hours, created_at, updated_at
 		FROM events WHERE short_code = $1`
 	err := r.db.GetContext(ctx, &event, query, shortCode)
 	if err != nil {
@@ -186,9 +297,9 @@ func (r *EventRepository) FindWithHost(ctx context.Context, id uuid.UUID) (*mode
 			e.location_name, e.location_address,
 			ST_Y(e.location_point::geometry) as latitude,
 			ST_X(e.location_point::geometry) as longitude,
-			e.google_place_id, e.capacity, e.skill_level, e.fee, e.status, e.created_at, e.updated_at,
-			COALESCE(COUNT(CASE WHEN r.status = 'confirmed' THEN 1 END), 0) as confirmed_count,
-			COALESCE(COUNT(CASE WHEN r.status = 'waitlist' THEN 1 END), 0) as waitlist_count
+			e.google_place_id, e.capacity, e.skill_level, e.fee, e.status, e.min_reliability, e.cancel_deadline_hours, e.transfer_requires_approval, e.max_guests, e.requires_approval, e.visibility, e.club_id, e.registration_access, e.member_priority_hours, e.created_at, e.updated_at,
+			COALESCE(SUM(CASE WHEN r.status = 'confirmed' THEN 1 + r.guest_count END), 0) as confirmed_count,
+			COALESCE(SUM(CASE WHEN r.status = 'waitlist' THEN 1 + r.guest_count END), 0) as waitlist_count
 		FROM events e
 		LEFT JOIN registrations r ON e.id = r.event_id AND r.status != 'cancelled'
 		WHERE e.id = $1
@@ -208,8 +319,17 @@ func (r *EventRepository) CountByHostID(ctx context.Context, hostID uuid.UUID) (
 	return count, err
 }
 
-// FindUpcoming finds upcoming events (future events that are open)
+// FindUpcoming finds upcoming public events (future events that are open)
 func (r *EventRepository) FindUpcoming(ctx context.Context, limit, offset int) ([]model.EventSummary, error) {
+	return r.findUpcoming(ctx, nil, limit, offset)
+}
+
+// FindUpcomingByClubID finds a club's upcoming public events
+func (r *EventRepository) FindUpcomingByClubID(ctx context.Context, clubID uuid.UUID, limit, offset int) ([]model.EventSummary, error) {
+	return r.findUpcoming(ctx, &clubID, limit, offset)
+}
+
+func (r *EventRepository) findUpcoming(ctx context.Context, clubID *uuid.UUID, limit, offset int) ([]model.EventSummary, error) {
 	var events []model.EventSummary
 	query := `
 		SELECT
@@ -217,17 +337,19 @@ func (r *EventRepository) FindUpcoming(ctx context.Context, limit, offset int) (
 			e.location_name, e.location_address,
 			ST_Y(e.location_point::geometry) as latitude,
 			ST_X(e.location_point::geometry) as longitude,
-			e.google_place_id, e.capacity, e.skill_level, e.fee, e.status, e.created_at, e.updated_at,
-			COALESCE(COUNT(CASE WHEN r.status = 'confirmed' THEN 1 END), 0) as confirmed_count,
-			COALESCE(COUNT(CASE WHEN r.status = 'waitlist' THEN 1 END), 0) as waitlist_count
+			e.google_place_id, e.capacity, e.skill_level, e.fee, e.status, e.min_reliability, e.cancel_deadline_hours, e.transfer_requires_approval, e.max_guests, e.requires_approval, e.visibility, e.club_id, e.registration_access, e.member_priority_hours, e.created_at, e.updated_at,
+			COALESCE(SUM(CASE WHEN r.status = 'confirmed' THEN 1 + r.guest_count END), 0) as confirmed_count,
+			COALESCE(SUM(CASE WHEN r.status = 'waitlist' THEN 1 + r.guest_count END), 0) as waitlist_count
 		FROM events e
 		LEFT JOIN registrations r ON e.id = r.event_id AND r.status != 'cancelled'
 		WHERE e.event_date >= CURRENT_DATE
 		AND e.status IN ('open', 'full')
+		AND e.visibility = 'public'
+		AND ($3::uuid IS NULL OR e.club_id = $3)
 		GROUP BY e.id
 		ORDER BY e.event_date ASC, e.start_time ASC
 		LIMIT $1 OFFSET $2`
-	err := r.db.SelectContext(ctx, &events, query, limit, offset)
+	err := r.db.SelectContext(ctx, &events, query, limit, offset, clubID)
 	if err != nil {
 		return nil, err
 	}
@@ -263,3 +385,19 @@ func (r *EventRepository) IsHost(ctx context.Context, eventID, userID uuid.UUID)
 	err := r.db.GetContext(ctx, &isHost, query, eventID, userID)
 	return isHost, err
 }
+
+// FindRole returns the role a user holds on an event: host, an accepted co-host
+// or check-in helper role, or "" if they have none. Owners and admins of the
+// event's club host it alongside host_id. It returns sql.ErrNoRows if the event
+// does not exist.
+func (r *EventRepository) FindRole(ctx context.Context, eventID, userID uuid.UUID) (model.EventRole, error) {
+	var role model.EventRole
+	query := `
+		SELECT CASE WHEN e.host_id = $2 THEN 'host' WHEN m.role IN ('owner', 'admin') THEN 'host' ELSE COALESCE(r.role, '') END
+		FROM events e
+		LEFT JOIN event_roles r ON r.event_id = e.id AND r.user_id = $2 AND r.status = 'accepted'
+		LEFT JOIN club_members m ON m.club_id = e.club_id AND m.user_id = $2 AND m.status = 'active'
+		WHERE e.id = $1`
+	err := r.db.GetContext(ctx, &role, query, eventID, userID)
+	return role, err
+}
@@ -45,11 +45,12 @@ func TestEventRepository_Create(t *testing.T) {
 		INSERT INTO events (
 			id, host_id, short_code, title, description, event_date, start_time, end_time,
 			location_name, location_address, location_point, google_place_id,
-			capacity, skill_level, fee, status, created_at, updated_at
+			capacity, skill_level, fee, min_reliability, cancel_deadline_hours, transfer_requires_approval, max_guests, requires_approval, visibility,
+			club_id, registration_access, member_priority_hours, status, created_at, updated_at
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
 			ST_SetSRID(ST_MakePoint($11, $12), 4326)::geography,
-			$13, $14, $15, $16, 'open', NOW(), NOW()
+			$13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, 'open', NOW(), NOW()
 		)
 		RETURNING created_at, updated_at`)).
 					WithArgs(
@@ -57,7 +58,9 @@ func TestEventRepository_Create(t *testing.T) {
 						event.EventDate, event.StartTime, event.EndTime,
 						event.LocationName, event.LocationAddress,
 						event.Longitude, event.Latitude, event.GooglePlaceID,
-						event.Capacity, event.SkillLevel, event.Fee,
+						event.Capacity, event.SkillLevel, event.Fee, event.MinReliability, event.CancelDeadlineHours, event.TransferApproval,
+						event.MaxGuests, event.RequiresApproval, event.Visibility,
+						event.ClubID, event.RegistrationAccess, event.MemberPriorityHours,
 					).
 					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).
 						AddRow(time.Now(), time.Now()))
@@ -84,11 +87,12 @@ func TestEventRepository_Create(t *testing.T) {
 		INSERT INTO events (
 			id, host_id, short_code, title, description, event_date, start_time, end_time,
 			location_name, location_address, location_point, google_place_id,
-			capacity, skill_level, fee, status, created_at, updated_at
+			capacity, skill_level, fee, min_reliability, cancel_deadline_hours, transfer_requires_approval, max_guests, requires_approval, visibility,
+			club_id, registration_access, member_priority_hours, status, created_at, updated_at
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
 			ST_SetSRID(ST_MakePoint($11, $12), 4326)::geography,
-			$13, $14, $15, $16, 'open', NOW(), NOW()
+			$13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, 'open', NOW(), NOW()
 		)
 		RETURNING created_at, updated_at`)).
 					WithArgs(
@@ -96,7 +100,9 @@ func TestEventRepository_Create(t *testing.T) {
 						event.EventDate, event.StartTime, event.EndTime,
 						event.LocationName, event.LocationAddress,
 						event.Longitude, event.Latitude, event.GooglePlaceID,
-						event.Capacity, event.SkillLevel, event.Fee,
+						event.Capacity, event.SkillLevel, event.Fee, event.MinReliability, event.CancelDeadlineHours, event.TransferApproval,
+						event.MaxGuests, event.RequiresApproval, event.Visibility,
+						event.ClubID, event.RegistrationAccess, event.MemberPriorityHours,
 					).
 					WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).
 						AddRow(time.Now(), time.Now()))
@@ -123,11 +129,12 @@ func TestEventRepository_Create(t *testing.T) {
 		INSERT INTO events (
 			id, host_id, short_code, title, description, event_date, start_time, end_time,
 			location_name, location_address, location_point, google_place_id,
-			capacity, skill_level, fee, status, created_at, updated_at
+			capacity, skill_level, fee, min_reliability, cancel_deadline_hours, transfer_requires_approval, max_guests, requires_approval, visibility,
+			club_id, registration_access, member_priority_hours, status, created_at, updated_at
 		) VALUES (
 			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
 			ST_SetSRID(ST_MakePoint($11, $12), 4326)::geography,
-			$13, $14, $15, $16, 'open', NOW(), NOW()
+			$13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, 'open', NOW(), NOW()
 		)
 		RETURNING created_at, updated_at`)).
 					WithArgs(
@@ -135,7 +142,9 @@ func TestEventRepository_Create(t *testing.T) {
 						event.EventDate, event.StartTime, event.EndTime,
 						event.LocationName, event.LocationAddress,
 						event.Longitude, event.Latitude, event.GooglePlaceID,
-						event.Capacity, event.SkillLevel, event.Fee,
+						event.Capacity, event.SkillLevel, event.Fee, event.MinReliability, event.CancelDeadlineHours, event.TransferApproval,
+						event.MaxGuests, event.RequiresApproval, event.Visibility,
+						event.ClubID, event.RegistrationAccess, event.MemberPriorityHours,
 					).
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -199,7 +208,7 @@ func TestEventRepository_FindByID(t *testing.T) {
 			   location_name, location_address,
 			   ST_Y(location_point::geometry) as latitude,
 			   ST_X(location_point::geometry) as longitude,
-			   google_place_id, capacity, skill_level, fee, status, created_at, updated_at
+			   google_place_id, capacity, skill_level, fee, status, min_reliability, cancel_deadline_hours, transfer_requires_approval, max_guests, requires_approval, visibility, club_id, registration_access, member_priority_hours, created_at, updated_at
 		FROM events WHERE id = $1`)).
 					WithArgs(eventID).
 					WillReturnRows(rows)
@@ -220,7 +229,7 @@ func TestEventRepository_FindByID(t *testing.T) {
 			   location_name, location_address,
 			   ST_Y(location_point::geometry) as latitude,
 			   ST_X(location_point::geometry) as longitude,
-			   google_place_id, capacity, skill_level, fee, status, created_at, updated_at
+			   google_place_id, capacity, skill_level, fee, status, min_reliability, cancel_deadline_hours, transfer_requires_approval, max_guests, requires_approval, visibility, club_id, registration_access, member_priority_hours, created_at, updated_at
 		FROM events WHERE id = $1`)).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -310,15 +319,16 @@ func TestEventRepository_FindNearby(t *testing.T) {
 			e.location_name, e.location_address,
 			ST_Y(e.location_point::geometry) as latitude,
 			ST_X(e.location_point::geometry) as longitude,
-			e.google_place_id, e.capacity, e.skill_level, e.fee, e.status, e.created_at, e.updated_at,
-			COALESCE(COUNT(CASE WHEN r.status = 'confirmed' THEN 1 END), 0) as confirmed_count,
-			COALESCE(COUNT(CASE WHEN r.status = 'waitlist' THEN 1 END), 0) as waitlist_count
+			e.google_place_id, e.capacity, e.skill_level, e.fee, e.status, e.min_reliability, e.cancel_deadline_hours, e.transfer_requires_approval, e.max_guests, e.requires_approval, e.visibility, e.club_id, e.registration_access, e.member_priority_hours, e.created_at, e.updated_at,
+			COALESCE(SUM(CASE WHEN r.status = 'confirmed' THEN 1 + r.guest_count END), 0) as confirmed_count,
+			COALESCE(SUM(CASE WHEN r.status = 'waitlist' THEN 1 + r.guest_count END), 0) as waitlist_count
 		FROM events e
 		LEFT JOIN registrations r ON e.id = r.event_id
 		WHERE ST_DWithin(e.location_point, ST_MakePoint($1, $2)::geography, $3)
 		AND ($4 = '' OR e.skill_level = $4)
 		AND ($5 = '' OR e.status = $5)
 		AND e.event_date >= CURRENT_DATE
+		AND e.visibility = 'public'
 		GROUP BY e.id
 		ORDER BY e.event_date ASC, e.start_time ASC
 		LIMIT $6 OFFSET $7`)).
@@ -360,15 +370,16 @@ func TestEventRepository_FindNearby(t *testing.T) {
 			e.location_name, e.location_address,
 			ST_Y(e.location_point::geometry) as latitude,
 			ST_X(e.location_point::geometry) as longitude,
-			e.google_place_id, e.capacity, e.skill_level, e.fee, e.status, e.created_at, e.updated_at,
-			COALESCE(COUNT(CASE WHEN r.status = 'confirmed' THEN 1 END), 0) as confirmed_count,
-			COALESCE(COUNT(CASE WHEN r.status = 'waitlist' THEN 1 END), 0) as waitlist_count
+			e.google_place_id, e.capacity, e.skill_level, e.fee, e.status, e.min_reliability, e.cancel_deadline_hours, e.transfer_requires_approval, e.max_guests, e.requires_approval, e.visibility, e.club_id, e.registration_access, e.member_priority_hours, e.created_at, e.updated_at,
+			COALESCE(SUM(CASE WHEN r.status = 'confirmed' THEN 1 + r.guest_count END), 0) as confirmed_count,
+			COALESCE(SUM(CASE WHEN r.status = 'waitlist' THEN 1 + r.guest_count END), 0) as waitlist_count
 		FROM events e
 		LEFT JOIN registrations r ON e.id = r.event_id
 		WHERE ST_DWithin(e.location_point, ST_MakePoint($1, $2)::geography, $3)
 		AND ($4 = '' OR e.skill_level = $4)
 		AND ($5 = '' OR e.status = $5)
 		AND e.event_date >= CURRENT_DATE
+		AND e.visibility = 'public'
 		GROUP BY e.id
 		ORDER BY e.event_date ASC, e.start_time ASC
 		LIMIT $6 OFFSET $7`)).
@@ -403,15 +414,16 @@ func TestEventRepository_FindNearby(t *testing.T) {
 			e.location_name, e.location_address,
 			ST_Y(e.location_point::geometry) as latitude,
 			ST_X(e.location_point::geometry) as longitude,
-			e.google_place_id, e.capacity, e.skill_level, e.fee, e.status, e.created_at, e.updated_at,
-			COALESCE(COUNT(CASE WHEN r.status = 'confirmed' THEN 1 END), 0) as confirmed_count,
-			COALESCE(COUNT(CASE WHEN r.status = 'waitlist' THEN 1 END), 0) as waitlist_count
+			e.google_place_id, e.capacity, e.skill_level, e.fee, e.status, e.min_reliability, e.cancel_deadline_hours, e.transfer_requires_approval, e.max_guests, e.requires_approval, e.visibility, e.club_id, e.registration_access, e.member_priority_hours, e.created_at, e.updated_at,
+			COALESCE(SUM(CASE WHEN r.status = 'confirmed' THEN 1 + r.guest_count END), 0) as confirmed_count,
+			COALESCE(SUM(CASE WHEN r.status = 'waitlist' THEN 1 + r.guest_count END), 0) as waitlist_count
 		FROM events e
 		LEFT JOIN registrations r ON e.id = r.event_id
 		WHERE ST_DWithin(e.location_point, ST_MakePoint($1, $2)::geography, $3)
 		AND ($4 = '' OR e.skill_level = $4)
 		AND ($5 = '' OR e.status = $5)
 		AND e.event_date >= CURRENT_DATE
+		AND e.visibility = 'public'
 		GROUP BY e.id
 		ORDER BY e.event_date ASC, e.start_time ASC
 		LIMIT $6 OFFSET $7`)).
@@ -446,15 +458,16 @@ func TestEventRepository_FindNearby(t *testing.T) {
 			e.location_name, e.location_address,
 			ST_Y(e.location_point::geometry) as latitude,
 			ST_X(e.location_point::geometry) as longitude,
-			e.google_place_id, e.capacity, e.skill_level, e.fee, e.status, e.created_at, e.updated_at,
-			COALESCE(COUNT(CASE WHEN r.status = 'confirmed' THEN 1 END), 0) as confirmed_count,
-			COALESCE(COUNT(CASE WHEN r.status = 'waitlist' THEN 1 END), 0) as waitlist_count
+			e.google_place_id, e.capacity, e.skill_level, e.fee, e.status, e.min_reliability, e.cancel_deadline_hours, e.transfer_requires_approval, e.max_guests, e.requires_approval, e.visibility, e.club_id, e.registration_access, e.member_priority_hours, e.created_at, e.updated_at,
+			COALESCE(SUM(CASE WHEN r.status = 'confirmed' THEN 1 + r.guest_count END), 0) as confirmed_count,
+			COALESCE(SUM(CASE WHEN r.status = 'waitlist' THEN 1 + r.guest_count END), 0) as waitlist_count
 		FROM events e
 		LEFT JOIN registrations r ON e.id = r.event_id
 		WHERE ST_DWithin(e.location_point, ST_MakePoint($1, $2)::geography, $3)
 		AND ($4 = '' OR e.skill_level = $4)
 		AND ($5 = '' OR e.status = $5)
 		AND e.event_date >= CURRENT_DATE
+		AND e.visibility = 'public'
 		GROUP BY e.id
 		ORDER BY e.event_date ASC, e.start_time ASC
 		LIMIT $6 OFFSET $7`)).
@@ -480,15 +493,16 @@ func TestEventRepository_FindNearby(t *testing.T) {
 			e.location_name, e.location_address,
 			ST_Y(e.location_point::geometry) as latitude,
 			ST_X(e.location_point::geometry) as longitude,
-			e.google_place_id, e.capacity, e.skill_level, e.fee, e.status, e.created_at, e.updated_at,
-			COALESCE(COUNT(CASE WHEN r.status = 'confirmed' THEN 1 END), 0) as confirmed_count,
-			COALESCE(COUNT(CASE WHEN r.status = 'waitlist' THEN 1 END), 0) as waitlist_count
+			e.google_place_id, e.capacity, e.skill_level, e.fee, e.status, e.min_reliability, e.cancel_deadline_hours, e.transfer_requires_approval, e.max_guests, e.requires_approval, e.visibility, e.club_id, e.registration_access, e.member_priority_hours, e.created_at, e.updated_at,
+			COALESCE(SUM(CASE WHEN r.status = 'confirmed' THEN 1 + r.guest_count END), 0) as confirmed_count,
+			COALESCE(SUM(CASE WHEN r.status = 'waitlist' THEN 1 + r.guest_count END), 0) as waitlist_count
 		FROM events e
 		LEFT JOIN registrations r ON e.id = r.event_id
 		WHERE ST_DWithin(e.location_point, ST_MakePoint($1, $2)::geography, $3)
 		AND ($4 = '' OR e.skill_level = $4)
 		AND ($5 = '' OR e.status = $5)
 		AND e.event_date >= CURRENT_DATE
+		AND e.visibility = 'public'
 		GROUP BY e.id
 		ORDER BY e.event_date ASC, e.start_time ASC
 		LIMIT $6 OFFSET $7`)).
@@ -555,13 +569,17 @@ func TestEventRepository_Update(t *testing.T) {
 				mock.ExpectQuery(regexp.QuoteMeta(`
 		UPDATE events SET
 			title = $2, description = $3, event_date = $4, start_time = $5, end_time = $6,
-			capacity = $7, skill_level = $8, fee = $9, status = $10, updated_at = NOW()
+			capacity = $7, skill_level = $8, fee = $9, min_reliability = $10,
+			cancel_deadline_hours = $11, transfer_requires_approval = $12, max_guests = $13, requires_approval = $14, visibility = $15,
+			registration_access = $16, member_priority_hours = $17, updated_at = NOW()
 		WHERE id = $1
 		RETURNING updated_at`)).
 					WithArgs(
 						event.ID, event.Title, event.Description, event.EventDate,
 						event.StartTime, event.EndTime, event.Capacity,
-						event.SkillLevel, event.Fee, event.Status,
+						event.SkillLevel, event.Fee, event.MinReliability, event.CancelDeadlineHours, event.TransferApproval,
+						event.MaxGuests, event.RequiresApproval, event.Visibility,
+						event.RegistrationAccess, event.MemberPriorityHours,
 					).
 					WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(now))
 			},
@@ -584,13 +602,17 @@ func TestEventRepository_Update(t *testing.T) {
 				mock.ExpectQuery(regexp.QuoteMeta(`
 		UPDATE events SET
 			title = $2, description = $3, event_date = $4, start_time = $5, end_time = $6,
-			capacity = $7, skill_level = $8, fee = $9, status = $10, updated_at = NOW()
+			capacity = $7, skill_level = $8, fee = $9, min_reliability = $10,
+			cancel_deadline_hours = $11, transfer_requires_approval = $12, max_guests = $13, requires_approval = $14, visibility = $15,
+			registration_access = $16, member_priority_hours = $17, updated_at = NOW()
 		WHERE id = $1
 		RETURNING updated_at`)).
 					WithArgs(
 						event.ID, event.Title, event.Description, event.EventDate,
 						event.StartTime, event.EndTime, event.Capacity,
-						event.SkillLevel, event.Fee, event.Status,
+						event.SkillLevel, event.Fee, event.MinReliability, event.CancelDeadlineHours, event.TransferApproval,
+						event.MaxGuests, event.RequiresApproval, event.Visibility,
+						event.RegistrationAccess, event.MemberPriorityHours,
 					).
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -634,8 +656,8 @@ func TestEventRepository_UpdateStatus(t *testing.T) {
 			eventID: eventID,
 			status:  model.EventStatusFull,
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta(`UPDATE events SET status = $2, updated_at = NOW() WHERE id = $1`)).
-					WithArgs(eventID, model.EventStatusFull).
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE events SET status = $2, updated_at = NOW() WHERE id = $1 AND status = ANY($3)`)).
+					WithArgs(eventID, model.EventStatusFull, sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			wantErr: false,
@@ -645,8 +667,8 @@ func TestEventRepository_UpdateStatus(t *testing.T) {
 			eventID: eventID,
 			status:  model.EventStatusCancelled,
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta(`UPDATE events SET status = $2, updated_at = NOW() WHERE id = $1`)).
-					WithArgs(eventID, model.EventStatusCancelled).
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE events SET status = $2, updated_at = NOW() WHERE id = $1 AND status = ANY($3)`)).
+					WithArgs(eventID, model.EventStatusCancelled, sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			wantErr: false,
@@ -656,8 +678,8 @@ func TestEventRepository_UpdateStatus(t *testing.T) {
 			eventID: eventID,
 			status:  model.EventStatusCompleted,
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta(`UPDATE events SET status = $2, updated_at = NOW() WHERE id = $1`)).
-					WithArgs(eventID, model.EventStatusCompleted).
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE events SET status = $2, updated_at = NOW() WHERE id = $1 AND status = ANY($3)`)).
+					WithArgs(eventID, model.EventStatusCompleted, sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			wantErr: false,
@@ -667,19 +689,33 @@ func TestEventRepository_UpdateStatus(t *testing.T) {
 			eventID: eventID,
 			status:  model.EventStatusOpen,
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta(`UPDATE events SET status = $2, updated_at = NOW() WHERE id = $1`)).
-					WithArgs(eventID, model.EventStatusOpen).
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE events SET status = $2, updated_at = NOW() WHERE id = $1 AND status = ANY($3)`)).
+					WithArgs(eventID, model.EventStatusOpen, sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			wantErr: false,
 		},
+		{
+			name:    "reopen cancelled event is rejected",
+			eventID: eventID,
+			status:  model.EventStatusOpen,
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE events SET status = $2, updated_at = NOW() WHERE id = $1 AND status = ANY($3)`)).
+					WithArgs(eventID, model.EventStatusOpen, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS(SELECT 1 FROM events WHERE id = $1)`)).
+					WithArgs(eventID).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+			wantErr: true,
+		},
 		{
 			name:    "database error",
 			eventID: eventID,
 			status:  model.EventStatusFull,
 			mockSetup: func(mock sqlmock.Sqlmock) {
-				mock.ExpectExec(regexp.QuoteMeta(`UPDATE events SET status = $2, updated_at = NOW() WHERE id = $1`)).
-					WithArgs(eventID, model.EventStatusFull).
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE events SET status = $2, updated_at = NOW() WHERE id = $1 AND status = ANY($3)`)).
+					WithArgs(eventID, model.EventStatusFull, sqlmock.AnyArg()).
 					WillReturnError(sql.ErrConnDone)
 			},
 			wantErr: true,
@@ -706,6 +742,50 @@ func TestEventRepository_UpdateStatus(t *testing.T) {
 	}
 }
 
+// TestEventRepository_CompletePastEvents tests the CompletePastEvents method
+func TestEventRepository_CompletePastEvents(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	repo := NewEventRepository(db)
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE events SET status = 'completed', updated_at = NOW()`)).
+		WithArgs("Asia/Taipei").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	n, err := repo.CompletePastEvents(context.Background(), "Asia/Taipei")
+	if err != nil {
+		t.Fatalf("CompletePastEvents() error = %v", err)
+	}
+	if n != 3 {
+		t.Errorf("CompletePastEvents() = %d, want 3", n)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+// TestEventRepository_CompletePastEvents_Overnight tests that an event ending
+// after midnight is only completed once its end time on the next day has passed
+func TestEventRepository_CompletePastEvents_Overnight(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	repo := NewEventRepository(db)
+	mock.ExpectExec(`UPDATE events SET status = 'completed'.*` +
+		regexp.QuoteMeta(`CASE WHEN end_time < start_time THEN INTERVAL '1 day'`)).
+		WithArgs("Asia/Taipei").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if _, err := repo.CompletePastEvents(context.Background(), "Asia/Taipei"); err != nil {
+		t.Fatalf("CompletePastEvents() error = %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
 // TestEventRepository_Delete tests the Delete method
 func TestEventRepository_Delete(t *testing.T) {
 	eventID := uuid.New()
@@ -809,7 +889,7 @@ func TestEventRepository_FindByShortCode(t *testing.T) {
 			   location_name, location_address,
 			   ST_Y(location_point::geometry) as latitude,
 			   ST_X(location_point::geometry) as longitude,
-			   google_place_id, capacity, skill_level, fee, status, created_at, updated_at
+			   google_place_id, capacity, skill_level, fee, status, min_reliability, cancel_deadline_hours, transfer_requires_approval, max_guests, requires_approval, visibility, club_id, registration_access, member_priority_hours, created_at, updated_at
 		FROM events WHERE short_code = $1`)).
 					WithArgs("abc123").
 					WillReturnRows(rows)
@@ -826,7 +906,7 @@ func TestEventRepository_FindByShortCode(t *testing.T) {
 			   location_name, location_address,
 			   ST_Y(location_point::geometry) as latitude,
 			   ST_X(location_point::geometry) as longitude,
-			   google_place_id, capacity, skill_level, fee, status, created_at, updated_at
+			   google_place_id, capacity, skill_level, fee, status, min_reliability, cancel_deadline_hours, transfer_requires_approval, max_guests, requires_approval, visibility, club_id, registration_access, member_priority_hours, created_at, updated_at
 		FROM events WHERE short_code = $1`)).
 					WithArgs("nonexistent").
 					WillReturnError(sql.ErrNoRows)
@@ -938,6 +1018,69 @@ func TestEventRepository_IsHost(t *testing.T) {
 	}
 }
 
+// TestEventRepository_FindRole tests the FindRole method
+func TestEventRepository_FindRole(t *testing.T) {
+	eventID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		mockSetup func(mock sqlmock.Sqlmock)
+		wantRole  model.EventRole
+		wantErr   error
+	}{
+		{
+			name: "accepted co-host",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT CASE WHEN e.host_id = \$2 THEN 'host'.*r.status = 'accepted'`).
+					WithArgs(eventID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow("co_host"))
+			},
+			wantRole: model.RoleCoHost,
+		},
+		{
+			name: "no role",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT CASE WHEN e.host_id`).
+					WithArgs(eventID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow(""))
+			},
+			wantRole: "",
+		},
+		{
+			name: "event not found",
+			mockSetup: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT CASE WHEN e.host_id`).
+					WithArgs(eventID, userID).
+					WillReturnRows(sqlmock.NewRows([]string{"role"}))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			defer db.Close()
+
+			repo := NewEventRepository(db)
+			tt.mockSetup(mock)
+
+			role, err := repo.FindRole(context.Background(), eventID, userID)
+			if err != tt.wantErr {
+				t.Fatalf("FindRole() error = %v, want %v", err, tt.wantErr)
+			}
+			if role != tt.wantRole {
+				t.Errorf("FindRole() = %q, want %q", role, tt.wantRole)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
 // TestEventRepository_Exists tests the Exists method
 func TestEventRepository_Exists(t *testing.T) {
 	eventID := uuid.New()
@@ -1042,7 +1185,7 @@ func TestEventRepository_FindByHostID(t *testing.T) {
 			   location_name, location_address,
 			   ST_Y(location_point::geometry) as latitude,
 			   ST_X(location_point::geometry) as longitude,
-			   google_place_id, capacity, skill_level, fee, status, created_at, updated_at
+			   google_place_id, capacity, skill_level, fee, status, min_reliability, cancel_deadline_hours, transfer_requires_approval, max_guests, requires_approval, visibility, club_id, registration_access, member_priority_hours, created_at, updated_at
 		FROM events
 		WHERE host_id = $1
 		ORDER BY event_date DESC, start_time DESC`)).
@@ -1068,7 +1211,7 @@ func TestEventRepository_FindByHostID(t *testing.T) {
 			   location_name, location_address,
 			   ST_Y(location_point::geometry) as latitude,
 			   ST_X(location_point::geometry) as longitude,
-			   google_place_id, capacity, skill_level, fee, status, created_at, updated_at
+			   google_place_id, capacity, skill_level, fee, status, min_reliability, cancel_deadline_hours, transfer_requires_approval, max_guests, requires_approval, visibility, club_id, registration_access, member_priority_hours, created_at, updated_at
 		FROM events
 		WHERE host_id = $1
 		ORDER BY event_date DESC, start_time DESC`)).
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// EventRoleRepository handles co-host and check-in helper data access
+type EventRoleRepository struct {
+	db *sqlx.DB
+}
+
+// NewEventRoleRepository creates a new EventRoleRepository
+func NewEventRoleRepository(db *sqlx.DB) *EventRoleRepository {
+	return &EventRoleRepository{db: db}
+}
+
+// InviteTx invites a user to a role on an event within a transaction. Inviting
+// someone who already has a role changes it and keeps whether they accepted.
+func (r *EventRoleRepository) InviteTx(ctx context.Context, tx *sqlx.Tx, assignment *model.EventRoleAssignment) error {
+	query := `
+		INSERT INTO event_roles (id, event_id, user_id, role, invited_by)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (event_id, user_id) DO UPDATE
+			SET role = EXCLUDED.role, invited_by = EXCLUDED.invited_by
+		RETURNING id, status, created_at, accepted_at`
+
+	return tx.QueryRowxContext(ctx, query,
+		assignment.ID, assignment.EventID, assignment.UserID, assignment.Role, assignment.InvitedBy,
+	).Scan(&assignment.ID, &assignment.Status, &assignment.CreatedAt, &assignment.AcceptedAt)
+}
+
+// Accept takes up a pending role invitation. It returns ErrNotFound if the user
+// has no invitation waiting on the event.
+func (r *EventRoleRepository) Accept(ctx context.Context, eventID, userID uuid.UUID) (*model.EventRoleAssignment, error) {
+	var assignment model.EventRoleAssignment
+	query := `
+		UPDATE event_roles SET status = 'accepted', accepted_at = NOW()
+		WHERE event_id = $1 AND user_id = $2 AND status = 'invited'
+		RETURNING *`
+	err := r.db.GetContext(ctx, &assignment, query, eventID, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+// Delete removes a user's role or pending invitation on an event
+func (r *EventRoleRepository) Delete(ctx context.Context, eventID, userID uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM event_roles WHERE event_id = $1 AND user_id = $2`, eventID, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// FindByEventID finds everyone invited to help run an event, co-hosts first
+func (r *EventRoleRepository) FindByEventID(ctx context.Context, eventID uuid.UUID) ([]model.EventRoleWithUser, error) {
+	query := `
+		SELECT
+			r.id, r.event_id, r.user_id, r.role, r.status, r.invited_by, r.created_at, r.accepted_at,
+			u.display_name, u.avatar_url
+		FROM event_roles r
+		JOIN users u ON u.id = r.user_id
+		WHERE r.event_id = $1
+		ORDER BY r.role = 'co_host' DESC, r.created_at`
+
+	rows, err := r.db.QueryxContext(ctx, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []model.EventRoleWithUser
+	for rows.Next() {
+		var role model.EventRoleWithUser
+		err := rows.Scan(
+			&role.ID, &role.EventID, &role.UserID, &role.Role, &role.Status, &role.InvitedBy, &role.CreatedAt, &role.AcceptedAt,
+			&role.User.DisplayName, &role.User.AvatarURL,
+		)
+		if err != nil {
+			return nil, err
+		}
+		role.User.ID = role.UserID
+		results = append(results, role)
+	}
+
+	return results, rows.Err()
+}
+
+// FindCoHosts finds the users who have accepted a co-host role on an event
+func (r *EventRoleRepository) FindCoHosts(ctx context.Context, eventID uuid.UUID) ([]model.UserProfile, error) {
+	query := `
+		SELECT u.id, u.display_name, u.avatar_url
+		FROM event_roles r
+		JOIN users u ON u.id = r.user_id
+		WHERE r.event_id = $1 AND r.role = 'co_host' AND r.status = 'accepted'
+		ORDER BY r.accepted_at`
+
+	rows, err := r.db.QueryxContext(ctx, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var coHosts []model.UserProfile
+	for rows.Next() {
+		var profile model.UserProfile
+		if err := rows.Scan(&profile.ID, &profile.DisplayName, &profile.AvatarURL); err != nil {
+			return nil, err
+		}
+		coHosts = append(coHosts, profile)
+	}
+
+	return coHosts, rows.Err()
+}
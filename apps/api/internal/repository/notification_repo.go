@@ -2,11 +2,16 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
 	"github.com/anthropics/pickle-go/apps/api/internal/model"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 // NotificationRepository handles notification data access
@@ -21,11 +26,15 @@ func NewNotificationRepository(db *sqlx.DB) *NotificationRepository {
 
 // Create creates a new notification
 func (r *NotificationRepository) Create(ctx context.Context, notification *model.Notification) error {
-	query := `
-		INSERT INTO notifications (id, user_id, event_id, type, title, message, is_read, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING created_at`
+	return r.create(ctx, r.db, notification)
+}
 
+// CreateTx creates a new notification within a transaction
+func (r *NotificationRepository) CreateTx(ctx context.Context, tx *sqlx.Tx, notification *model.Notification) error {
+	return r.create(ctx, tx, notification)
+}
+
+func (r *NotificationRepository) create(ctx context.Context, db database.DBTX, notification *model.Notification) error {
 	if notification.ID == uuid.Nil {
 		notification.ID = uuid.New()
 	}
@@ -33,20 +42,89 @@ func (r *NotificationRepository) Create(ctx context.Context, notification *model
 		notification.CreatedAt = time.Now()
 	}
 
-	return r.db.QueryRowxContext(ctx, query,
+	prefs, err := r.deliveryPreferences(ctx, db, notification)
+	if err != nil {
+		return err
+	}
+	if !prefs.InApp && !prefs.Line && !prefs.Digest {
+		// Turned off on every channel
+		return nil
+	}
+
+	// Queue the notification for LINE push delivery in the same statement.
+	// Creating a notification that already exists is a no-op, so the outbox
+	// may deliver it more than once.
+	query := `
+		WITH n AS (
+			INSERT INTO notifications (id, user_id, event_id, type, title, message, is_read, created_at, in_app, digest_pending)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			ON CONFLICT (id) DO NOTHING
+			RETURNING id, created_at
+		), d AS (
+			INSERT INTO notification_deliveries (notification_id, channel, deliver_after)
+			SELECT id, 'line', $12::timestamptz FROM n WHERE $11::boolean
+		)
+		SELECT created_at FROM n`
+
+	err = db.QueryRowxContext(ctx, query,
 		notification.ID, notification.UserID, notification.EventID,
 		notification.Type, notification.Title, notification.Message,
-		notification.IsRead, notification.CreatedAt,
+		notification.IsRead, notification.CreatedAt, prefs.InApp,
+		prefs.Digest, prefs.Line, prefs.DeliverAfter,
 	).Scan(&notification.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Already created
+		return nil
+	}
+	return err
+}
+
+// deliveryPreferences works out which channels a notification goes to from the
+// recipient's preferences, and whether LINE delivery waits for quiet hours to
+// end or for the recipient's next digest
+func (r *NotificationRepository) deliveryPreferences(ctx context.Context, db database.DBTX, notification *model.Notification) (*model.DeliveryPreferences, error) {
+	prefs := &model.DeliveryPreferences{InApp: true, Line: true, DeliverAfter: notification.CreatedAt}
+
+	var stored []model.NotificationPreference
+	query := `SELECT user_id, type, channel, enabled FROM notification_preferences WHERE user_id = $1 AND type = $2`
+	if err := db.SelectContext(ctx, &stored, query, notification.UserID, notification.Type); err != nil {
+		return nil, err
+	}
+	for _, p := range stored {
+		switch p.Channel {
+		case model.DeliveryChannelInApp:
+			prefs.InApp = p.Enabled
+		case model.DeliveryChannelLine:
+			prefs.Line = p.Enabled
+		}
+	}
+
+	if prefs.Line && !model.IsUrgentNotification(notification.Type) {
+		var settings model.NotificationSettings
+		query = `SELECT user_id, timezone, quiet_hours_start, quiet_hours_end, digest, last_digest_at FROM notification_settings WHERE user_id = $1`
+		err := db.GetContext(ctx, &settings, query, notification.UserID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if err == nil {
+			if settings.Digest == model.DigestDaily || settings.Digest == model.DigestWeekly {
+				prefs.Line = false
+				prefs.Digest = true
+			} else {
+				prefs.DeliverAfter = settings.QuietUntil(notification.CreatedAt)
+			}
+		}
+	}
+	return prefs, nil
 }
 
 // FindByUserID finds notifications for a user
 func (r *NotificationRepository) FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]model.Notification, error) {
 	var notifications []model.Notification
 	query := `
-		SELECT id, user_id, event_id, type, title, message, is_read, created_at
+		SELECT id, user_id, event_id, type, title, message, is_read, created_at, digest_id
 		FROM notifications
-		WHERE user_id = $1
+		WHERE user_id = $1 AND in_app
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3`
 	err := r.db.SelectContext(ctx, &notifications, query, userID, limit, offset)
@@ -56,32 +134,109 @@ func (r *NotificationRepository) FindByUserID(ctx context.Context, userID uuid.U
 	return notifications, nil
 }
 
+// FindByID finds one of a user's inbox notifications by ID
+func (r *NotificationRepository) FindByID(ctx context.Context, id, userID uuid.UUID) (*model.Notification, error) {
+	var notification model.Notification
+	query := `
+		SELECT id, user_id, event_id, type, title, message, is_read, created_at, digest_id
+		FROM notifications
+		WHERE id = $1 AND user_id = $2 AND in_app`
+	err := r.db.GetContext(ctx, &notification, query, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// NotificationCursor marks a position in a user's notification list, which is
+// ordered newest first
+type NotificationCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// NotificationFilter represents filter options for listing notifications
+type NotificationFilter struct {
+	Types      []string
+	UnreadOnly bool
+	// After returns only notifications older than the cursor
+	After *NotificationCursor
+	Limit int
+}
+
+// List finds a page of a user's notifications, newest first
+func (r *NotificationRepository) List(ctx context.Context, userID uuid.UUID, filter NotificationFilter) ([]model.Notification, error) {
+	var notifications []model.Notification
+	query := `
+		SELECT id, user_id, event_id, type, title, message, is_read, created_at, digest_id
+		FROM notifications
+		WHERE user_id = $1 AND in_app`
+	args := []interface{}{userID}
+
+	if len(filter.Types) > 0 {
+		args = append(args, pq.StringArray(filter.Types))
+		query += fmt.Sprintf(" AND type = ANY($%d)", len(args))
+	}
+	if filter.UnreadOnly {
+		query += " AND is_read = false"
+	}
+	if filter.After != nil {
+		args = append(args, filter.After.CreatedAt, filter.After.ID)
+		query += fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", len(args)-1, len(args))
+	}
+
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args))
+
+	err := r.db.SelectContext(ctx, &notifications, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
 // CountUnread counts unread notifications for a user
 func (r *NotificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
 	var count int
-	query := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND is_read = false`
+	query := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND in_app AND is_read = false`
 	err := r.db.GetContext(ctx, &count, query, userID)
 	return count, err
 }
 
-// MarkAsRead marks a notification as read
-func (r *NotificationRepository) MarkAsRead(ctx context.Context, id uuid.UUID) error {
-	query := `UPDATE notifications SET is_read = true WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
-	return err
+// MarkAsRead marks a user's notification as read.
+// Returns ErrNotFound if the notification does not exist or belongs to another user.
+func (r *NotificationRepository) MarkAsRead(ctx context.Context, id, userID uuid.UUID) error {
+	query := `UPDATE notifications SET is_read = true WHERE id = $1 AND user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
 }
 
-// MarkAllAsRead marks all notifications for a user as read
-func (r *NotificationRepository) MarkAllAsRead(ctx context.Context, userID uuid.UUID) error {
+// MarkAllAsRead marks all notifications for a user as read.
+// Returns the number of notifications marked.
+func (r *NotificationRepository) MarkAllAsRead(ctx context.Context, userID uuid.UUID) (int64, error) {
 	query := `UPDATE notifications SET is_read = true WHERE user_id = $1 AND is_read = false`
-	_, err := r.db.ExecContext(ctx, query, userID)
-	return err
+	result, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-// Delete deletes a notification by ID
-func (r *NotificationRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM notifications WHERE id = $1`
-	result, err := r.db.ExecContext(ctx, query, id)
+// Delete deletes a user's notification.
+// Returns ErrNotFound if the notification does not exist or belongs to another user.
+func (r *NotificationRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	query := `DELETE FROM notifications WHERE id = $1 AND user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, id, userID)
 	if err != nil {
 		return err
 	}
@@ -95,40 +250,62 @@ func (r *NotificationRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
-// CreateWaitlistPromotedNotification creates a notification for when a user is promoted from waitlist
-func (r *NotificationRepository) CreateWaitlistPromotedNotification(ctx context.Context, userID uuid.UUID, eventID uuid.UUID, eventTitle string) error {
-	title := "You have been promoted from the waitlist!"
-	message := "A spot has opened up. You are now confirmed for: " + eventTitle
-
-	notification := &model.Notification{
-		ID:        uuid.New(),
-		UserID:    userID,
-		EventID:   &eventID,
-		Type:      model.NotificationWaitlistPromoted,
-		Title:     title,
-		Message:   &message,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+// DeleteReadBefore deletes read notifications, and notifications hidden from the
+// inbox, created before the given time. Notifications still waiting for a digest
+// are kept. Returns the number of notifications deleted.
+func (r *NotificationRepository) DeleteReadBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM notifications WHERE (is_read = true OR NOT in_app) AND NOT digest_pending AND created_at < $1`
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
 	}
-
-	return r.Create(ctx, notification)
+	return result.RowsAffected()
 }
 
-// CreateEventCancelledNotification creates a notification for when an event is cancelled
-func (r *NotificationRepository) CreateEventCancelledNotification(ctx context.Context, userID uuid.UUID, eventID uuid.UUID, eventTitle string) error {
-	title := "Event has been cancelled"
-	message := "The event you registered for has been cancelled: " + eventTitle
+// FindDigestItemsTx finds and locks the notifications waiting for a user's
+// next digest, oldest first
+func (r *NotificationRepository) FindDigestItemsTx(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID) ([]model.DigestItem, error) {
+	var items []model.DigestItem
+	query := `
+		SELECT n.id, n.event_id, n.title, n.created_at,
+			   e.title AS event_title, e.event_date, e.location_name
+		FROM notifications n
+		LEFT JOIN events e ON e.id = n.event_id
+		WHERE n.user_id = $1 AND n.digest_pending
+		ORDER BY n.created_at, n.id
+		FOR UPDATE OF n`
+	err := tx.SelectContext(ctx, &items, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
 
-	notification := &model.Notification{
-		ID:        uuid.New(),
-		UserID:    userID,
-		EventID:   &eventID,
-		Type:      model.NotificationEventCancelled,
-		Title:     title,
-		Message:   &message,
-		IsRead:    false,
-		CreatedAt: time.Now(),
+// CreateDigestTx creates a digest notification, queues it for LINE delivery and
+// marks the notifications it summarizes as delivered in it. The digest itself is
+// not shown in the inbox, where its notifications already are.
+func (r *NotificationRepository) CreateDigestTx(ctx context.Context, tx *sqlx.Tx, digest *model.Notification, itemIDs []uuid.UUID, deliverAfter time.Time) error {
+	query := `
+		WITH n AS (
+			INSERT INTO notifications (id, user_id, event_id, type, title, message, is_read, created_at, in_app)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, false)
+			RETURNING id
+		)
+		INSERT INTO notification_deliveries (notification_id, channel, deliver_after)
+		SELECT id, 'line', $9 FROM n`
+	_, err := tx.ExecContext(ctx, query,
+		digest.ID, digest.UserID, digest.EventID,
+		digest.Type, digest.Title, digest.Message,
+		digest.IsRead, digest.CreatedAt, deliverAfter,
+	)
+	if err != nil {
+		return err
 	}
 
-	return r.Create(ctx, notification)
+	query = `
+		UPDATE notifications
+		SET digest_pending = false, digest_id = $1
+		WHERE id = ANY($2)`
+	_, err = tx.ExecContext(ctx, query, digest.ID, pq.Array(itemIDs))
+	return err
 }
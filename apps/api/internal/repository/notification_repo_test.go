@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/google/uuid"
+)
+
+func TestNotificationCreate_Preferences(t *testing.T) {
+	prefColumns := []string{"user_id", "type", "channel", "enabled"}
+	settingsColumns := []string{"user_id", "timezone", "quiet_hours_start", "quiet_hours_end", "digest", "last_digest_at"}
+
+	// 23:00 in Taipei, inside 22:00-07:00 quiet hours
+	createdAt := time.Date(2025, 6, 1, 15, 0, 0, 0, time.UTC)
+	morning := time.Date(2025, 6, 1, 23, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		notification func(userID uuid.UUID) *model.Notification
+		prefs        [][2]interface{} // channel, enabled
+		settings     bool
+		digest       model.DigestFrequency
+		wantInsert   bool
+		wantInApp    bool
+		wantDigest   bool
+		wantLine     bool
+		wantAfter    time.Time
+	}{
+		{
+			name:         "defaults to every channel",
+			notification: func(u uuid.UUID) *model.Notification { return model.NewOfferExpiredNotification(u, uuid.New(), "Game") },
+			wantInsert:   true,
+			wantInApp:    true,
+			wantLine:     true,
+			wantAfter:    createdAt,
+		},
+		{
+			name:         "LINE turned off skips quiet hours lookup",
+			notification: func(u uuid.UUID) *model.Notification { return model.NewOfferExpiredNotification(u, uuid.New(), "Game") },
+			prefs:        [][2]interface{}{{"line", false}},
+			wantInsert:   true,
+			wantInApp:    true,
+			wantLine:     false,
+			wantAfter:    createdAt,
+		},
+		{
+			name:         "quiet hours defer LINE delivery",
+			notification: func(u uuid.UUID) *model.Notification { return model.NewOfferExpiredNotification(u, uuid.New(), "Game") },
+			settings:     true,
+			wantInsert:   true,
+			wantInApp:    true,
+			wantLine:     true,
+			wantAfter:    morning,
+		},
+		{
+			name: "urgent notifications ignore quiet hours",
+			notification: func(u uuid.UUID) *model.Notification {
+				return model.NewEventCancelledNotification(u, uuid.New(), "Game")
+			},
+			wantInsert: true,
+			wantInApp:  true,
+			wantLine:   true,
+			wantAfter:  createdAt,
+		},
+		{
+			name:         "turned off everywhere is not stored",
+			notification: func(u uuid.UUID) *model.Notification { return model.NewOfferExpiredNotification(u, uuid.New(), "Game") },
+			prefs:        [][2]interface{}{{"in_app", false}, {"line", false}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			defer db.Close()
+			repo := NewNotificationRepository(db)
+
+			userID := uuid.New()
+			n := tt.notification(userID)
+			n.CreatedAt = createdAt
+
+			rows := sqlmock.NewRows(prefColumns)
+			for _, p := range tt.prefs {
+				rows.AddRow(userID, n.Type, p[0], p[1])
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(`FROM notification_preferences`)).
+				WithArgs(userID, n.Type).
+				WillReturnRows(rows)
+
+			if (tt.wantLine || tt.wantDigest) && !model.IsUrgentNotification(n.Type) {
+				settings := sqlmock.NewRows(settingsColumns)
+				if tt.settings {
+					digest := tt.digest
+					if digest == "" {
+						digest = model.DigestOff
+					}
+					settings.AddRow(userID, "Asia/Taipei", "22:00:00", "07:00:00", digest, nil)
+				}
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM notification_settings`)).
+					WithArgs(userID).
+					WillReturnRows(settings)
+			}
+
+			if tt.wantInsert {
+				mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO notifications`)).
+					WithArgs(n.ID, userID, n.EventID, n.Type, n.Title, n.Message, false, createdAt, tt.wantInApp, tt.wantDigest, tt.wantLine, sameTime(tt.wantAfter)).
+					WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(createdAt))
+			}
+
+			if err := repo.Create(context.Background(), n); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
+// sameTime matches a time.Time argument at the same instant in any location
+type sameTime time.Time
+
+func (s sameTime) Match(v driver.Value) bool {
+	t, ok := v.(time.Time)
+	return ok && t.Equal(time.Time(s))
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// OutboxRepository handles outbox data access
+type OutboxRepository struct {
+	db *sqlx.DB
+}
+
+// NewOutboxRepository creates a new OutboxRepository
+func NewOutboxRepository(db *sqlx.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// EnqueueTx records a message within the transaction that causes it
+func (r *OutboxRepository) EnqueueTx(ctx context.Context, tx *sqlx.Tx, topic string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO outbox (id, topic, payload) VALUES ($1, $2, $3)`
+	_, err = tx.ExecContext(ctx, query, uuid.New(), topic, data)
+	return err
+}
+
+// EnqueueNotificationsTx records in-app notifications to be created once the transaction commits
+func (r *OutboxRepository) EnqueueNotificationsTx(ctx context.Context, tx *sqlx.Tx, notifications ...*model.Notification) error {
+	for _, n := range notifications {
+		if err := r.EnqueueTx(ctx, tx, model.OutboxTopicNotification, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClaimDue claims up to limit pending messages that are due, oldest first.
+// Each claim counts as an attempt and hides the message from other claims for
+// the lease, after which it becomes due again unless marked done, retried or dead.
+func (r *OutboxRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]model.OutboxMessage, error) {
+	var messages []model.OutboxMessage
+	query := `
+		UPDATE outbox
+		SET attempts = attempts + 1, next_attempt_at = NOW() + $2 * INTERVAL '1 second'
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at, created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`
+	err := r.db.SelectContext(ctx, &messages, query, limit, int64(lease/time.Second))
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].CreatedAt.Before(messages[j].CreatedAt) })
+	return messages, nil
+}
+
+// MarkDone records that a message was delivered
+func (r *OutboxRepository) MarkDone(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE outbox SET status = 'done', last_error = NULL, processed_at = NOW() WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// MarkRetry records a failed delivery attempt and when to try again
+func (r *OutboxRepository) MarkRetry(ctx context.Context, id uuid.UUID, reason string, next time.Time) error {
+	query := `UPDATE outbox SET last_error = $2, next_attempt_at = $3 WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id, reason, next)
+	return err
+}
+
+// MarkDead gives up on a message, keeping it as a dead letter
+func (r *OutboxRepository) MarkDead(ctx context.Context, id uuid.UUID, reason string) error {
+	query := `UPDATE outbox SET status = 'dead', last_error = $2, processed_at = NOW() WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id, reason)
+	return err
+}
+
+// DeleteDoneBefore deletes delivered messages processed before the given time.
+// Dead letters are kept. Returns the number of messages deleted.
+func (r *OutboxRepository) DeleteDoneBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM outbox WHERE status = 'done' AND processed_at < $1`
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
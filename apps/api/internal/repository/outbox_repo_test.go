@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/google/uuid"
+)
+
+func TestOutboxEnqueueNotificationsTx(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	repo := NewOutboxRepository(db)
+	first := model.NewWaitlistPromotedNotification(uuid.New(), uuid.New(), "06/01 @ Court")
+	second := model.NewOfferExpiredNotification(uuid.New(), uuid.New(), "06/01 @ Court")
+
+	mock.ExpectBegin()
+	for range []*model.Notification{first, second} {
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox (id, topic, payload)`)).
+			WithArgs(sqlmock.AnyArg(), model.OutboxTopicNotification, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	if err := repo.EnqueueNotificationsTx(context.Background(), tx, first, second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestOutboxClaimDue(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	repo := NewOutboxRepository(db)
+	now := time.Now()
+	older, newer := uuid.New(), uuid.New()
+
+	columns := []string{"id", "topic", "payload", "status", "attempts", "last_error", "next_attempt_at", "created_at", "processed_at"}
+	rows := sqlmock.NewRows(columns).
+		AddRow(newer, model.OutboxTopicNotification, []byte(`{}`), "pending", 1, nil, now, now, nil).
+		AddRow(older, model.OutboxTopicNotification, []byte(`{}`), "pending", 2, "timeout", now, now.Add(-time.Minute), nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FOR UPDATE SKIP LOCKED`)).
+		WithArgs(50, int64(300)).
+		WillReturnRows(rows)
+
+	messages, err := repo.ClaimDue(context.Background(), 50, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(messages) != 2 || messages[0].ID != older || messages[1].ID != newer {
+		t.Errorf("expected messages oldest first, got %+v", messages)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// PreferenceRepository handles notification preference data access
+type PreferenceRepository struct {
+	db *sqlx.DB
+}
+
+// NewPreferenceRepository creates a new PreferenceRepository
+func NewPreferenceRepository(db *sqlx.DB) *PreferenceRepository {
+	return &PreferenceRepository{db: db}
+}
+
+// FindByUserID finds the notification preferences a user has set.
+// Types and channels without a row are enabled.
+func (r *PreferenceRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]model.NotificationPreference, error) {
+	var prefs []model.NotificationPreference
+	query := `
+		SELECT user_id, type, channel, enabled
+		FROM notification_preferences
+		WHERE user_id = $1
+		ORDER BY type, channel`
+	err := r.db.SelectContext(ctx, &prefs, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// FindSettings finds a user's notification settings.
+// Returns sql.ErrNoRows if the user has never saved any.
+func (r *PreferenceRepository) FindSettings(ctx context.Context, userID uuid.UUID) (*model.NotificationSettings, error) {
+	var settings model.NotificationSettings
+	query := `
+		SELECT user_id, timezone, quiet_hours_start, quiet_hours_end, digest, last_digest_at
+		FROM notification_settings
+		WHERE user_id = $1`
+	err := r.db.GetContext(ctx, &settings, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// UpsertTx saves a notification preference within a transaction
+func (r *PreferenceRepository) UpsertTx(ctx context.Context, tx *sqlx.Tx, pref *model.NotificationPreference) error {
+	query := `
+		INSERT INTO notification_preferences (user_id, type, channel, enabled)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, type, channel) DO UPDATE SET enabled = EXCLUDED.enabled`
+	_, err := tx.ExecContext(ctx, query, pref.UserID, pref.Type, pref.Channel, pref.Enabled)
+	return err
+}
+
+// UpsertSettingsTx saves a user's notification settings within a transaction
+func (r *PreferenceRepository) UpsertSettingsTx(ctx context.Context, tx *sqlx.Tx, settings *model.NotificationSettings) error {
+	query := `
+		INSERT INTO notification_settings (user_id, timezone, quiet_hours_start, quiet_hours_end, digest)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE SET
+			timezone = EXCLUDED.timezone,
+			quiet_hours_start = EXCLUDED.quiet_hours_start,
+			quiet_hours_end = EXCLUDED.quiet_hours_end,
+			digest = EXCLUDED.digest`
+	_, err := tx.ExecContext(ctx, query, settings.UserID, settings.Timezone, settings.QuietHoursStart, settings.QuietHoursEnd, settings.Digest)
+	return err
+}
+
+// FindDigestSubscribers finds the settings of users who have notifications
+// waiting for a digest
+func (r *PreferenceRepository) FindDigestSubscribers(ctx context.Context) ([]model.NotificationSettings, error) {
+	var settings []model.NotificationSettings
+	query := `
+		SELECT s.user_id, s.timezone, s.quiet_hours_start, s.quiet_hours_end, s.digest, s.last_digest_at
+		FROM notification_settings s
+		WHERE EXISTS (
+			SELECT 1 FROM notifications n
+			WHERE n.user_id = s.user_id AND n.digest_pending
+		)`
+	err := r.db.SelectContext(ctx, &settings, query)
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// MarkDigestSentTx records when a user's last digest was sent within a transaction
+func (r *PreferenceRepository) MarkDigestSentTx(ctx context.Context, tx *sqlx.Tx, userID uuid.UUID, sentAt time.Time) error {
+	query := `UPDATE notification_settings SET last_digest_at = $2 WHERE user_id = $1`
+	_, err := tx.ExecContext(ctx, query, userID, sentAt)
+	return err
+}
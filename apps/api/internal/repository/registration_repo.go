@@ -3,10 +3,12 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/anthropics/pickle-go/apps/api/internal/model"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 // RegistrationRepository handles registration data access
@@ -50,7 +52,8 @@ func (r *RegistrationRepository) FindByEventID(ctx context.Context, eventID uuid
 		ORDER BY
 			CASE status
 				WHEN 'confirmed' THEN 0
-				WHEN 'waitlist' THEN 1
+				WHEN 'offered' THEN 1
+				WHEN 'waitlist' THEN 2
 			END,
 			waitlist_position NULLS LAST,
 			registered_at ASC`
@@ -75,14 +78,37 @@ func (r *RegistrationRepository) FindByUserID(ctx context.Context, userID uuid.U
 	return regs, nil
 }
 
-// CountConfirmed counts confirmed registrations for an event
+// CountConfirmed counts the seats taken by confirmed registrations and their guests for an event
 func (r *RegistrationRepository) CountConfirmed(ctx context.Context, eventID uuid.UUID) (int, error) {
 	var count int
-	query := `SELECT COUNT(*) FROM registrations WHERE event_id = $1 AND status = 'confirmed'`
+	query := `SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations WHERE event_id = $1 AND status = 'confirmed'`
 	err := r.db.GetContext(ctx, &count, query, eventID)
 	return count, err
 }
 
+// CountHeldSpots counts the seats held by confirmed and offered registrations and their guests for an event
+func (r *RegistrationRepository) CountHeldSpots(ctx context.Context, eventID uuid.UUID) (int, error) {
+	var count int
+	query := `SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations WHERE event_id = $1 AND status IN ('confirmed', 'offered')`
+	err := r.db.GetContext(ctx, &count, query, eventID)
+	return count, err
+}
+
+// FindExpiredOffers finds waitlist offers whose hold window has passed
+func (r *RegistrationRepository) FindExpiredOffers(ctx context.Context, limit int) ([]model.Registration, error) {
+	var regs []model.Registration
+	query := `
+		SELECT * FROM registrations
+		WHERE status = 'offered' AND offer_expires_at <= NOW()
+		ORDER BY offer_expires_at ASC
+		LIMIT $1`
+	err := r.db.SelectContext(ctx, &regs, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	return regs, nil
+}
+
 // GetNextWaitlistPosition gets the next waitlist position for an event
 func (r *RegistrationRepository) GetNextWaitlistPosition(ctx context.Context, eventID uuid.UUID) (int, error) {
 	var maxPos *int
@@ -131,6 +157,162 @@ func (r *RegistrationRepository) UpdateStatus(ctx context.Context, id uuid.UUID,
 	return err
 }
 
+// CheckIn records that a confirmed player has arrived. Checking in again keeps
+// the original time. Returns ErrNotFound if the registration is not confirmed.
+func (r *RegistrationRepository) CheckIn(ctx context.Context, id uuid.UUID) (time.Time, error) {
+	var checkedInAt time.Time
+	query := `
+		UPDATE registrations
+		SET checked_in_at = COALESCE(checked_in_at, NOW())
+		WHERE id = $1 AND status = 'confirmed'
+		RETURNING checked_in_at`
+	err := r.db.GetContext(ctx, &checkedInAt, query, id)
+	if err == sql.ErrNoRows {
+		return time.Time{}, ErrNotFound
+	}
+	return checkedInAt, err
+}
+
+// UndoCheckIn clears a check-in made by mistake
+func (r *RegistrationRepository) UndoCheckIn(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE registrations SET checked_in_at = NULL WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// SetAttendanceTx records whether a confirmed player attended an event within a transaction.
+// Returns ErrNotFound if the registration is not a confirmed registration for the event.
+func (r *RegistrationRepository) SetAttendanceTx(ctx context.Context, tx *sqlx.Tx, eventID, id uuid.UUID, attendance model.Attendance) error {
+	query := `UPDATE registrations SET attendance = $3 WHERE id = $2 AND event_id = $1 AND status = 'confirmed'`
+	result, err := tx.ExecContext(ctx, query, eventID, id, attendance)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// MarkLateCancelTx flags a cancelled registration as cancelled after the event's
+// cancellation deadline within a transaction
+func (r *RegistrationRepository) MarkLateCancelTx(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `UPDATE registrations SET late_cancelled = TRUE WHERE id = $1 AND status = 'cancelled'`, id)
+	return err
+}
+
+// FindLateCancellations finds the registrations for an event that were cancelled
+// after its cancellation deadline, with user details, most recent first
+func (r *RegistrationRepository) FindLateCancellations(ctx context.Context, eventID uuid.UUID) ([]model.RegistrationWithUser, error) {
+	query := `
+		SELECT
+			r.id, r.event_id, r.user_id, r.status, r.registered_at, r.confirmed_at, r.cancelled_at,
+			u.id as "user.id", u.display_name as "user.display_name", u.avatar_url as "user.avatar_url"
+		FROM registrations r
+		JOIN users u ON r.user_id = u.id
+		WHERE r.event_id = $1 AND r.status = 'cancelled' AND r.late_cancelled
+		ORDER BY r.cancelled_at DESC`
+
+	rows, err := r.db.QueryxContext(ctx, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []model.RegistrationWithUser
+	for rows.Next() {
+		var reg model.RegistrationWithUser
+		err := rows.Scan(
+			&reg.ID, &reg.EventID, &reg.UserID, &reg.Status, &reg.RegisteredAt, &reg.ConfirmedAt, &reg.CancelledAt,
+			&reg.User.ID, &reg.User.DisplayName, &reg.User.AvatarURL,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reg.LateCancelled = true
+		results = append(results, reg)
+	}
+
+	return results, rows.Err()
+}
+
+// FindPendingByEventID finds the registration requests waiting for the host's
+// approval, with user details, oldest first
+func (r *RegistrationRepository) FindPendingByEventID(ctx context.Context, eventID uuid.UUID) ([]model.RegistrationWithUser, error) {
+	query := `
+		SELECT
+			r.id, r.event_id, r.user_id, r.status, r.registered_at, r.guest_count, r.skill_level,
+			u.id as "user.id", u.display_name as "user.display_name", u.avatar_url as "user.avatar_url"
+		FROM registrations r
+		JOIN users u ON r.user_id = u.id
+		WHERE r.event_id = $1 AND r.status = 'pending'
+		ORDER BY r.registered_at ASC`
+
+	rows, err := r.db.QueryxContext(ctx, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []model.RegistrationWithUser
+	for rows.Next() {
+		var reg model.RegistrationWithUser
+		err := rows.Scan(
+			&reg.ID, &reg.EventID, &reg.UserID, &reg.Status, &reg.RegisteredAt, &reg.GuestCount, &reg.SkillLevel,
+			&reg.User.ID, &reg.User.DisplayName, &reg.User.AvatarURL,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, reg)
+	}
+
+	return results, rows.Err()
+}
+
+// FindReliability counts how the given users honoured the spots they confirmed
+// for events that started within the policy window. Checked-in players count as
+// attended unless the host marked them otherwise. Event dates and times are
+// wall-clock times in the given time zone. Users without history are omitted.
+func (r *RegistrationRepository) FindReliability(ctx context.Context, userIDs []uuid.UUID, policy model.ReliabilityPolicy, timezone string) (map[uuid.UUID]model.Reliability, error) {
+	if len(userIDs) == 0 {
+		return map[uuid.UUID]model.Reliability{}, nil
+	}
+
+	query := `
+		SELECT r.user_id,
+			COUNT(*) FILTER (WHERE r.status = 'confirmed'
+				AND COALESCE(r.attendance, CASE WHEN r.checked_in_at IS NOT NULL THEN 'attended' END) = 'attended') AS attended,
+			COUNT(*) FILTER (WHERE r.status = 'cancelled' AND r.late_cancelled) AS late_cancelled,
+			COUNT(*) FILTER (WHERE r.status = 'confirmed' AND r.attendance = 'no_show') AS no_shows
+		FROM registrations r
+		JOIN events e ON e.id = r.event_id
+		WHERE r.user_id = ANY($1) AND e.status != 'cancelled'
+		AND (e.event_date + e.start_time) AT TIME ZONE $2 BETWEEN NOW() - $3 * INTERVAL '1 second' AND NOW()
+		GROUP BY r.user_id`
+
+	rows, err := r.db.QueryxContext(ctx, query, pq.Array(userIDs), timezone, int64(policy.Window/time.Second))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make(map[uuid.UUID]model.Reliability)
+	for rows.Next() {
+		var userID uuid.UUID
+		var rel model.Reliability
+		if err := rows.Scan(&userID, &rel.Attended, &rel.LateCancelled, &rel.NoShows); err != nil {
+			return nil, err
+		}
+		results[userID] = rel
+	}
+	return results, rows.Err()
+}
+
 // GetFirstWaitlist gets the first person in the waitlist for an event
 func (r *RegistrationRepository) GetFirstWaitlist(ctx context.Context, eventID uuid.UUID) (*model.Registration, error) {
 	var reg model.Registration
@@ -193,15 +375,16 @@ func (r *RegistrationRepository) FindWithUsersByEventID(ctx context.Context, eve
 	query := `
 		SELECT
 			r.id, r.event_id, r.user_id, r.status, r.waitlist_position,
-			r.registered_at, r.confirmed_at, r.cancelled_at,
+			r.registered_at, r.confirmed_at, r.cancelled_at, r.checked_in_at, r.attendance, r.guest_count, r.group_id,
 			u.id as "user.id", u.display_name as "user.display_name", u.avatar_url as "user.avatar_url"
 		FROM registrations r
 		JOIN users u ON r.user_id = u.id
-		WHERE r.event_id = $1 AND r.status != 'cancelled'
+		WHERE r.event_id = $1 AND r.status IN ('confirmed', 'offered', 'waitlist')
 		ORDER BY
 			CASE r.status
 				WHEN 'confirmed' THEN 0
-				WHEN 'waitlist' THEN 1
+				WHEN 'offered' THEN 1
+				WHEN 'waitlist' THEN 2
 			END,
 			r.waitlist_position NULLS LAST,
 			r.registered_at ASC`
@@ -221,7 +404,7 @@ func (r *RegistrationRepository) FindWithUsersByEventID(ctx context.Context, eve
 
 		err := rows.Scan(
 			&reg.ID, &reg.EventID, &reg.UserID, &reg.Status, &reg.WaitlistPosition,
-			&reg.RegisteredAt, &reg.ConfirmedAt, &reg.CancelledAt,
+			&reg.RegisteredAt, &reg.ConfirmedAt, &reg.CancelledAt, &reg.CheckedInAt, &reg.Attendance, &reg.GuestCount, &reg.GroupID,
 			&userID, &displayName, &avatarURL,
 		)
 		if err != nil {
@@ -268,7 +451,7 @@ func (r *RegistrationRepository) FindEventsByUserID(ctx context.Context, userID
 	var eventIDs []uuid.UUID
 	var query string
 	if includeWaitlist {
-		query = `SELECT event_id FROM registrations WHERE user_id = $1 AND status IN ('confirmed', 'waitlist') ORDER BY registered_at DESC`
+		query = `SELECT event_id FROM registrations WHERE user_id = $1 AND status IN ('confirmed', 'offered', 'waitlist') ORDER BY registered_at DESC`
 	} else {
 		query = `SELECT event_id FROM registrations WHERE user_id = $1 AND status = 'confirmed' ORDER BY registered_at DESC`
 	}
@@ -283,6 +466,56 @@ func (r *RegistrationRepository) CancelAllByEventID(ctx context.Context, eventID
 	return err
 }
 
+// CancelAllByEventIDTx cancels all registrations for an event within a transaction.
+// Returns the IDs of the users whose registrations were cancelled.
+func (r *RegistrationRepository) CancelAllByEventIDTx(ctx context.Context, tx *sqlx.Tx, eventID uuid.UUID) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	query := `
+		UPDATE registrations
+		SET status = 'cancelled', cancelled_at = NOW(), waitlist_position = NULL, offer_expires_at = NULL
+		WHERE event_id = $1 AND status NOT IN ('cancelled', 'rejected')
+		RETURNING user_id`
+	err := tx.SelectContext(ctx, &userIDs, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
+
+// FindParticipantIDsTx finds the users who are confirmed, offered or waitlisted
+// for an event within a transaction
+func (r *RegistrationRepository) FindParticipantIDsTx(ctx context.Context, tx *sqlx.Tx, eventID uuid.UUID) ([]uuid.UUID, error) {
+	var userIDs []uuid.UUID
+	query := `
+		SELECT user_id FROM registrations
+		WHERE event_id = $1 AND status IN ('confirmed', 'offered', 'waitlist')
+		ORDER BY registered_at`
+	err := tx.SelectContext(ctx, &userIDs, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
+
+// FindUpcomingByHostTx finds a user's active registrations for the events a host
+// has coming up, within a transaction
+func (r *RegistrationRepository) FindUpcomingByHostTx(ctx context.Context, tx *sqlx.Tx, hostID, userID uuid.UUID) ([]model.Registration, error) {
+	var registrations []model.Registration
+	query := `
+		SELECT r.* FROM registrations r
+		JOIN events e ON e.id = r.event_id
+		WHERE e.host_id = $1 AND r.user_id = $2
+			AND r.status IN ('confirmed', 'offered', 'waitlist', 'pending')
+			AND e.status NOT IN ('cancelled', 'completed')
+			AND e.event_date >= CURRENT_DATE
+		ORDER BY e.event_date, e.start_time`
+	err := tx.SelectContext(ctx, &registrations, query, hostID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return registrations, nil
+}
+
 // GetRegistrationStats gets registration statistics for an event
 type RegistrationStats struct {
 	ConfirmedCount int `db:"confirmed_count"`
@@ -293,8 +526,8 @@ func (r *RegistrationRepository) GetRegistrationStats(ctx context.Context, event
 	var stats RegistrationStats
 	query := `
 		SELECT
-			COUNT(CASE WHEN status = 'confirmed' THEN 1 END) as confirmed_count,
-			COUNT(CASE WHEN status = 'waitlist' THEN 1 END) as waitlist_count
+			COALESCE(SUM(CASE WHEN status = 'confirmed' THEN 1 + guest_count END), 0) as confirmed_count,
+			COALESCE(SUM(CASE WHEN status = 'waitlist' THEN 1 + guest_count END), 0) as waitlist_count
 		FROM registrations
 		WHERE event_id = $1 AND status != 'cancelled'`
 	err := r.db.GetContext(ctx, &stats, query, eventID)
@@ -311,10 +544,14 @@ func (r *RegistrationRepository) GetRegistrationStats(ctx context.Context, event
 // RegisterWithLock atomically registers a user for an event using row-level locking.
 // This prevents race conditions by locking the event row during the registration process.
 // It handles both new registrations and re-registrations (when a cancelled registration exists).
+// Guests take seats alongside the player: the registration is confirmed only if there is
+// room for the whole party, otherwise the party is waitlisted together.
+// Returns ErrBlocked if the event's host has blocked the user.
 func (r *RegistrationRepository) RegisterWithLock(
 	ctx context.Context,
 	tx *sqlx.Tx,
 	eventID, userID uuid.UUID,
+	guests []string,
 ) (*model.Registration, error) {
 	// 1. Lock the event record to prevent concurrent modifications
 	var event struct {
@@ -336,6 +573,9 @@ func (r *RegistrationRepository) RegisterWithLock(
 	if event.HostID == userID {
 		return nil, ErrHostCannotRegister
 	}
+	if err := r.checkNotBlockedTx(ctx, tx, event.HostID, userID); err != nil {
+		return nil, err
+	}
 
 	// 3. Check for existing registration (including cancelled)
 	var existingReg model.Registration
@@ -353,11 +593,8 @@ func (r *RegistrationRepository) RegisterWithLock(
 		return nil, ErrAlreadyRegistered
 	}
 
-	// 4. Count confirmed registrations (within the same locked context)
-	var confirmedCount int
-	err = tx.GetContext(ctx, &confirmedCount,
-		`SELECT COUNT(*) FROM registrations WHERE event_id = $1 AND status = 'confirmed'`,
-		eventID)
+	// 4. Count seats held by confirmed and offered registrations (within the same locked context)
+	heldSeats, err := r.heldSeatsTx(ctx, tx, eventID)
 	if err != nil {
 		return nil, err
 	}
@@ -366,7 +603,7 @@ func (r *RegistrationRepository) RegisterWithLock(
 	var status model.RegistrationStatus
 	var waitlistPos *int
 
-	if confirmedCount < event.Capacity {
+	if heldSeats+1+len(guests) <= event.Capacity {
 		status = model.RegistrationConfirmed
 	} else {
 		status = model.RegistrationWaitlist
@@ -399,10 +636,10 @@ func (r *RegistrationRepository) RegisterWithLock(
 		reg.ID = existingReg.ID
 		err = tx.QueryRowxContext(ctx, `
 			UPDATE registrations
-			SET status = $2, waitlist_position = $3,
+			SET status = $2, waitlist_position = $3, group_id = NULL,
 				registered_at = NOW(),
 				confirmed_at = CASE WHEN $2 = 'confirmed' THEN NOW() ELSE NULL END,
-				cancelled_at = NULL
+				cancelled_at = NULL, checked_in_at = NULL, attendance = NULL, late_cancelled = FALSE
 			WHERE id = $1
 			RETURNING registered_at, confirmed_at`,
 			reg.ID, status, waitlistPos).Scan(&reg.RegisteredAt, &reg.ConfirmedAt)
@@ -428,99 +665,1259 @@ func (r *RegistrationRepository) RegisterWithLock(
 		return nil, err
 	}
 
+	// 7. Seat the guests, dropping any left over from a cancelled registration
+	if len(guests) > 0 || (hasExisting && existingReg.GuestCount > 0) {
+		reg.Guests, err = r.setGuestsTx(ctx, tx, reg.ID, guests)
+		if err != nil {
+			return nil, err
+		}
+		reg.GuestCount = len(guests)
+	}
+
+	// 8. Recalculate open/full while the event row is still locked
+	if err := r.SyncEventStatusTx(ctx, tx, eventID); err != nil {
+		return nil, err
+	}
+
 	return reg, nil
 }
 
-// CancelAndPromote atomically cancels a registration and promotes the first waitlisted user.
-// Returns the promoted registration if any, or nil if no one was in the waitlist.
-func (r *RegistrationRepository) CancelAndPromote(
+// RegisterGroupWithLock registers several users for an event together, locking the
+// event the same way as RegisterWithLock. The group is all or nothing: every member
+// is confirmed if there are seats for all of them, otherwise the whole group joins
+// the waitlist as one entry sharing a single position. organizerID is the user who
+// made the registration and must be one of userIDs.
+// Returns ErrNotFound if a user does not exist, ErrBlocked if the host has blocked
+// any of them and ErrAlreadyRegistered if any of them already has an active registration.
+func (r *RegistrationRepository) RegisterGroupWithLock(
 	ctx context.Context,
 	tx *sqlx.Tx,
-	registrationID, eventID uuid.UUID,
-) (*model.Registration, error) {
-	// 1. Lock and get the registration to cancel
-	var reg model.Registration
-	err := tx.GetContext(ctx, &reg,
-		`SELECT * FROM registrations WHERE id = $1 FOR UPDATE`,
-		registrationID)
+	eventID, organizerID uuid.UUID,
+	userIDs []uuid.UUID,
+) ([]model.Registration, error) {
+	// 1. Lock the event record to prevent concurrent modifications
+	var event struct {
+		Capacity int       `db:"capacity"`
+		Status   string    `db:"status"`
+		HostID   uuid.UUID `db:"host_id"`
+	}
+	err := tx.GetContext(ctx, &event,
+		`SELECT capacity, status, host_id FROM events WHERE id = $1 FOR UPDATE`,
+		eventID)
 	if err != nil {
 		return nil, err
 	}
 
-	if reg.Status == model.RegistrationCancelled {
-		return nil, ErrAlreadyCancelled
+	// 2. Validate event status and members
+	if event.Status == "cancelled" || event.Status == "completed" {
+		return nil, ErrEventNotOpen
 	}
-
-	wasConfirmed := reg.Status == model.RegistrationConfirmed
-	wasWaitlist := reg.Status == model.RegistrationWaitlist
-	oldWaitlistPos := reg.WaitlistPosition
-
-	// 2. Update to cancelled status
-	_, err = tx.ExecContext(ctx,
-		`UPDATE registrations SET status = 'cancelled', cancelled_at = NOW(), waitlist_position = NULL WHERE id = $1`,
-		registrationID)
+	for _, userID := range userIDs {
+		if userID == event.HostID {
+			return nil, ErrHostCannotRegister
+		}
+	}
+	if err := r.checkNotBlockedTx(ctx, tx, event.HostID, userIDs...); err != nil {
+		return nil, err
+	}
+	var found int
+	err = tx.GetContext(ctx, &found,
+		`SELECT COUNT(*) FROM users WHERE id = ANY($1)`,
+		pq.Array(userIDs))
 	if err != nil {
 		return nil, err
 	}
+	if found != len(userIDs) {
+		return nil, ErrNotFound
+	}
 
-	// 3. If user was in waitlist, reorder remaining waitlist positions
-	if wasWaitlist && oldWaitlistPos != nil {
-		_, err = tx.ExecContext(ctx, `
-			UPDATE registrations
-			SET waitlist_position = waitlist_position - 1
-			WHERE event_id = $1 AND status = 'waitlist' AND waitlist_position > $2`,
-			eventID, *oldWaitlistPos)
-		if err != nil {
-			return nil, err
+	// 3. Check the members' existing registrations (including cancelled)
+	var existing []model.Registration
+	err = tx.SelectContext(ctx, &existing,
+		`SELECT * FROM registrations WHERE event_id = $1 AND user_id = ANY($2) FOR UPDATE`,
+		eventID, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	cancelled := make(map[uuid.UUID]model.Registration, len(existing))
+	for _, reg := range existing {
+		if reg.Status != model.RegistrationCancelled {
+			return nil, ErrAlreadyRegistered
 		}
-		return nil, nil // No promotion needed for waitlist cancellation
+		cancelled[reg.UserID] = reg
 	}
 
-	// 4. If user was confirmed, promote from waitlist
-	var promoted *model.Registration
-	if wasConfirmed {
-		// Get first waitlist person using SKIP LOCKED to avoid deadlocks
-		var waitlistReg model.Registration
-		err = tx.GetContext(ctx, &waitlistReg, `
-			SELECT * FROM registrations
-			WHERE event_id = $1 AND status = 'waitlist'
-			ORDER BY waitlist_position ASC
-			LIMIT 1
-			FOR UPDATE SKIP LOCKED`,
+	// 4. Confirm everyone if the whole group fits, otherwise waitlist them together
+	heldSeats, err := r.heldSeatsTx(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	status := model.RegistrationConfirmed
+	var waitlistPos *int
+	if heldSeats+len(userIDs) > event.Capacity {
+		status = model.RegistrationWaitlist
+		var maxPos *int
+		err = tx.GetContext(ctx, &maxPos,
+			`SELECT MAX(waitlist_position) FROM registrations
+			 WHERE event_id = $1 AND status = 'waitlist'`,
 			eventID)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+		pos := 1
+		if maxPos != nil {
+			pos = *maxPos + 1
+		}
+		waitlistPos = &pos
+	}
 
-		if err == nil {
-			// Promote the waitlisted user
-			_, err = tx.ExecContext(ctx, `
-				UPDATE registrations
-				SET status = 'confirmed', confirmed_at = NOW(), waitlist_position = NULL
-				WHERE id = $1`,
-				waitlistReg.ID)
-			if err != nil {
-				return nil, err
-			}
+	// 5. Create the group and a registration for each member
+	groupID := uuid.New()
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO registration_groups (id, event_id, organizer_id, created_at) VALUES ($1, $2, $3, NOW())`,
+		groupID, eventID, organizerID)
+	if err != nil {
+		return nil, err
+	}
 
-			// Reorder remaining waitlist positions
-			_, err = tx.ExecContext(ctx, `
+	regs := make([]model.Registration, len(userIDs))
+	for i, userID := range userIDs {
+		reg := model.Registration{
+			EventID:          eventID,
+			UserID:           userID,
+			Status:           status,
+			WaitlistPosition: waitlistPos,
+			GroupID:          &groupID,
+		}
+		if old, ok := cancelled[userID]; ok {
+			reg.ID = old.ID
+			err = tx.QueryRowxContext(ctx, `
 				UPDATE registrations
-				SET waitlist_position = waitlist_position - 1
-				WHERE event_id = $1 AND status = 'waitlist'`,
-				eventID)
-			if err != nil {
-				return nil, err
+				SET status = $2, waitlist_position = $3, group_id = $4,
+					registered_at = NOW(),
+					confirmed_at = CASE WHEN $2 = 'confirmed' THEN NOW() ELSE NULL END,
+					cancelled_at = NULL, checked_in_at = NULL, attendance = NULL, late_cancelled = FALSE
+				WHERE id = $1
+				RETURNING registered_at, confirmed_at`,
+				reg.ID, status, waitlistPos, groupID).Scan(&reg.RegisteredAt, &reg.ConfirmedAt)
+			if err == nil && old.GuestCount > 0 {
+				_, err = r.setGuestsTx(ctx, tx, reg.ID, nil)
 			}
-
-			promoted = &waitlistReg
-			promoted.Status = model.RegistrationConfirmed
-			promoted.WaitlistPosition = nil
-		} else if err != sql.ErrNoRows {
-			// Real error, not just empty waitlist
+		} else {
+			reg.ID = uuid.New()
+			err = tx.QueryRowxContext(ctx, `
+				INSERT INTO registrations (id, event_id, user_id, status, waitlist_position, group_id, registered_at, confirmed_at)
+				VALUES ($1, $2, $3, $4, $5, $6, NOW(), CASE WHEN $4 = 'confirmed' THEN NOW() END)
+				RETURNING registered_at, confirmed_at`,
+				reg.ID, eventID, userID, status, waitlistPos, groupID).Scan(&reg.RegisteredAt, &reg.ConfirmedAt)
+		}
+		if err != nil {
 			return nil, err
 		}
-		// If sql.ErrNoRows, promoted stays nil (no one to promote)
+		regs[i] = reg
 	}
 
-	return promoted, nil
+	// 6. Recalculate open/full while the event row is still locked
+	if err := r.SyncEventStatusTx(ctx, tx, eventID); err != nil {
+		return nil, err
+	}
+
+	return regs, nil
+}
+
+// RequestWithLock records a registration request for an event that requires the
+// host's approval, locking the event the same way as RegisterWithLock. The request
+// holds no seat: the player, their guests and the skill level they state wait in the
+// pending state until the host approves or rejects them.
+// Returns ErrAlreadyRegistered if the user has an active or rejected registration
+// and ErrBlocked if the host has blocked the user.
+func (r *RegistrationRepository) RequestWithLock(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	eventID, userID uuid.UUID,
+	guests []string,
+	skillLevel *model.SkillLevel,
+) (*model.Registration, error) {
+	// 1. Lock the event record to prevent concurrent modifications
+	var event struct {
+		Status string    `db:"status"`
+		HostID uuid.UUID `db:"host_id"`
+	}
+	err := tx.GetContext(ctx, &event,
+		`SELECT status, host_id FROM events WHERE id = $1 FOR UPDATE`,
+		eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.Status == "cancelled" || event.Status == "completed" {
+		return nil, ErrEventNotOpen
+	}
+	if event.HostID == userID {
+		return nil, ErrHostCannotRegister
+	}
+	if err := r.checkNotBlockedTx(ctx, tx, event.HostID, userID); err != nil {
+		return nil, err
+	}
+
+	// 2. Check for existing registration (including cancelled)
+	var existingReg model.Registration
+	err = tx.GetContext(ctx, &existingReg,
+		`SELECT * FROM registrations WHERE event_id = $1 AND user_id = $2`,
+		eventID, userID)
+	hasExisting := err == nil
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	if hasExisting && existingReg.Status != model.RegistrationCancelled {
+		return nil, ErrAlreadyRegistered
+	}
+
+	// 3. Create or update the request
+	reg := &model.Registration{
+		EventID:    eventID,
+		UserID:     userID,
+		Status:     model.RegistrationPending,
+		SkillLevel: skillLevel,
+	}
+	if hasExisting {
+		reg.ID = existingReg.ID
+		err = tx.QueryRowxContext(ctx, `
+			UPDATE registrations
+			SET status = 'pending', waitlist_position = NULL, group_id = NULL, skill_level = $2,
+				registered_at = NOW(), confirmed_at = NULL,
+				cancelled_at = NULL, checked_in_at = NULL, attendance = NULL, late_cancelled = FALSE
+			WHERE id = $1
+			RETURNING registered_at`,
+			reg.ID, skillLevel).Scan(&reg.RegisteredAt)
+	} else {
+		reg.ID = uuid.New()
+		err = tx.QueryRowxContext(ctx, `
+			INSERT INTO registrations (id, event_id, user_id, status, skill_level, registered_at)
+			VALUES ($1, $2, $3, 'pending', $4, NOW())
+			RETURNING registered_at`,
+			reg.ID, eventID, userID, skillLevel).Scan(&reg.RegisteredAt)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Record the guests, dropping any left over from a cancelled registration
+	if len(guests) > 0 || (hasExisting && existingReg.GuestCount > 0) {
+		reg.Guests, err = r.setGuestsTx(ctx, tx, reg.ID, guests)
+		if err != nil {
+			return nil, err
+		}
+		reg.GuestCount = len(guests)
+	}
+
+	return reg, nil
+}
+
+// ApproveWithLock moves a pending registration request into the event, locking the
+// event the same way as RegisterWithLock. The player is confirmed if there are seats
+// for them and their guests, otherwise they join the end of the waitlist.
+// Returns sql.ErrNoRows if the registration is not for the event and ErrNotPending
+// if it is no longer awaiting approval.
+func (r *RegistrationRepository) ApproveWithLock(ctx context.Context, tx *sqlx.Tx, eventID, registrationID uuid.UUID) (*model.Registration, error) {
+	// 1. Lock the event record to prevent concurrent modifications
+	capacity, status, err := r.GetEventForUpdate(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if status == "cancelled" || status == "completed" {
+		return nil, ErrEventNotOpen
+	}
+
+	// 2. Lock the request
+	var reg model.Registration
+	err = tx.GetContext(ctx, &reg,
+		`SELECT * FROM registrations WHERE id = $1 AND event_id = $2 FOR UPDATE`,
+		registrationID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if reg.Status != model.RegistrationPending {
+		return nil, ErrNotPending
+	}
+
+	// 3. Confirm if the party fits, otherwise waitlist
+	heldSeats, err := r.heldSeatsTx(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if heldSeats+reg.Seats() <= capacity {
+		err = tx.QueryRowxContext(ctx, `
+			UPDATE registrations
+			SET status = 'confirmed', confirmed_at = NOW()
+			WHERE id = $1
+			RETURNING confirmed_at`,
+			reg.ID).Scan(&reg.ConfirmedAt)
+		reg.Status = model.RegistrationConfirmed
+	} else {
+		var maxPos *int
+		err = tx.GetContext(ctx, &maxPos,
+			`SELECT MAX(waitlist_position) FROM registrations
+			 WHERE event_id = $1 AND status = 'waitlist'`,
+			eventID)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+		pos := 1
+		if maxPos != nil {
+			pos = *maxPos + 1
+		}
+		_, err = tx.ExecContext(ctx,
+			`UPDATE registrations SET status = 'waitlist', waitlist_position = $2 WHERE id = $1`,
+			reg.ID, pos)
+		reg.Status = model.RegistrationWaitlist
+		reg.WaitlistPosition = &pos
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Recalculate open/full while the event row is still locked
+	if err := r.SyncEventStatusTx(ctx, tx, eventID); err != nil {
+		return nil, err
+	}
+
+	return &reg, nil
+}
+
+// RejectTx turns down a pending registration request within a transaction.
+// Returns sql.ErrNoRows if the registration is not for the event and ErrNotPending
+// if it is no longer awaiting approval.
+func (r *RegistrationRepository) RejectTx(ctx context.Context, tx *sqlx.Tx, eventID, registrationID uuid.UUID) (*model.Registration, error) {
+	var reg model.Registration
+	err := tx.GetContext(ctx, &reg,
+		`SELECT * FROM registrations WHERE id = $1 AND event_id = $2 FOR UPDATE`,
+		registrationID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if reg.Status != model.RegistrationPending {
+		return nil, ErrNotPending
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE registrations SET status = 'rejected' WHERE id = $1`,
+		reg.ID)
+	if err != nil {
+		return nil, err
+	}
+	reg.Status = model.RegistrationRejected
+	return &reg, nil
+}
+
+// PromoteWithLock confirms a waitlisted registration ahead of its turn, locking the
+// event the same way as RegisterWithLock. The player is confirmed straight away rather
+// than offered the spot; a group is confirmed together, as fillTx does.
+// Returns the confirmed registrations. Returns sql.ErrNoRows if the registration is not
+// for the event, ErrNotWaitlisted if it is not on the waitlist and ErrNotEnoughSeats if
+// the party or group does not fit in the free seats.
+func (r *RegistrationRepository) PromoteWithLock(ctx context.Context, tx *sqlx.Tx, eventID, registrationID uuid.UUID) ([]model.Registration, error) {
+	// 1. Lock the event record to prevent concurrent modifications
+	capacity, status, err := r.GetEventForUpdate(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if status == "cancelled" || status == "completed" {
+		return nil, ErrEventNotOpen
+	}
+
+	// 2. Lock the waitlisted registration
+	var reg model.Registration
+	err = tx.GetContext(ctx, &reg,
+		`SELECT * FROM registrations WHERE id = $1 AND event_id = $2 FOR UPDATE`,
+		registrationID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if reg.Status != model.RegistrationWaitlist {
+		return nil, ErrNotWaitlisted
+	}
+
+	// 3. Check the party, or the whole group, fits in the free seats
+	seats := reg.Seats()
+	if reg.GroupID != nil {
+		err = tx.GetContext(ctx, &seats,
+			`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations WHERE group_id = $1 AND status = 'waitlist'`,
+			*reg.GroupID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	heldSeats, err := r.heldSeatsTx(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if heldSeats+seats > capacity {
+		return nil, ErrNotEnoughSeats
+	}
+
+	// 4. Confirm and close the gap left in the waitlist
+	pos := reg.WaitlistPosition
+	var promoted []model.Registration
+	if reg.GroupID != nil {
+		err = tx.SelectContext(ctx, &promoted, `
+			UPDATE registrations
+			SET status = 'confirmed', confirmed_at = NOW(), waitlist_position = NULL
+			WHERE group_id = $1 AND status = 'waitlist'
+			RETURNING *`,
+			*reg.GroupID)
+	} else {
+		err = tx.QueryRowxContext(ctx, `
+			UPDATE registrations
+			SET status = 'confirmed', confirmed_at = NOW(), waitlist_position = NULL
+			WHERE id = $1
+			RETURNING confirmed_at`,
+			reg.ID).Scan(&reg.ConfirmedAt)
+		reg.Status = model.RegistrationConfirmed
+		reg.WaitlistPosition = nil
+		promoted = []model.Registration{reg}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if pos != nil {
+		if err = r.closeWaitlistGapTx(ctx, tx, eventID, *pos); err != nil {
+			return nil, err
+		}
+	}
+
+	// 5. Recalculate open/full while the event row is still locked
+	if err := r.SyncEventStatusTx(ctx, tx, eventID); err != nil {
+		return nil, err
+	}
+
+	return promoted, nil
+}
+
+// MoveWaitlistWithLock moves a waitlisted registration to position, locking the event
+// the same way as RegisterWithLock. Everyone between the old and new positions shifts
+// one place to make room, and a group moves together since its members share a
+// position. Positions past the end of the waitlist move the registration to the end.
+// Returns the new position. Returns sql.ErrNoRows if the registration is not for the
+// event and ErrNotWaitlisted if it is not on the waitlist.
+func (r *RegistrationRepository) MoveWaitlistWithLock(ctx context.Context, tx *sqlx.Tx, eventID, registrationID uuid.UUID, position int) (int, error) {
+	// 1. Lock the event record to prevent concurrent modifications
+	if _, _, err := r.GetEventForUpdate(ctx, tx, eventID); err != nil {
+		return 0, err
+	}
+
+	// 2. Lock the waitlisted registration
+	var reg model.Registration
+	err := tx.GetContext(ctx, &reg,
+		`SELECT * FROM registrations WHERE id = $1 AND event_id = $2 FOR UPDATE`,
+		registrationID, eventID)
+	if err != nil {
+		return 0, err
+	}
+	if reg.Status != model.RegistrationWaitlist || reg.WaitlistPosition == nil {
+		return 0, ErrNotWaitlisted
+	}
+	from := *reg.WaitlistPosition
+
+	var last int
+	err = tx.GetContext(ctx, &last,
+		`SELECT COALESCE(MAX(waitlist_position), 0) FROM registrations WHERE event_id = $1 AND status = 'waitlist'`,
+		eventID)
+	if err != nil {
+		return 0, err
+	}
+	if position > last {
+		position = last
+	}
+	if position == from {
+		return position, nil
+	}
+
+	// 3. Shift everyone in between towards the old position, then drop the registration in
+	shift, low, high := 1, position, from-1
+	if position > from {
+		shift, low, high = -1, from+1, position
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE registrations
+		SET waitlist_position = CASE WHEN waitlist_position = $2 THEN $3 ELSE waitlist_position + $4 END
+		WHERE event_id = $1 AND status = 'waitlist'
+		AND (waitlist_position = $2 OR waitlist_position BETWEEN $5 AND $6)`,
+		eventID, from, position, shift, low, high)
+	if err != nil {
+		return 0, err
+	}
+
+	return position, nil
+}
+
+// TransferWithLock atomically hands the confirmed spot behind a transfer to toUserID,
+// locking the event row the same way as RegisterWithLock. The sender's registration
+// is cancelled and the recipient confirmed in its place without going through the
+// waitlist; the number of waitlisted players bypassed is recorded on the transfer.
+// from is the status the transfer must be in: pending when the recipient accepts
+// directly, awaiting_approval when the host approves.
+// A registration with guests cannot be handed over, nor taken by a waitlisted party with guests
+// or a player whose registration is rejected or still awaiting the host's approval.
+// Returns ErrTransferUnavailable if the transfer or the spot can no longer be handed over
+// and ErrBlocked if the host has blocked the recipient.
+func (r *RegistrationRepository) TransferWithLock(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	eventID, transferID, toUserID uuid.UUID,
+	from model.TransferStatus,
+) (*model.Registration, *model.SpotTransfer, error) {
+	// 1. Lock the event record to prevent concurrent modifications
+	var event struct {
+		Status string    `db:"status"`
+		HostID uuid.UUID `db:"host_id"`
+	}
+	err := tx.GetContext(ctx, &event,
+		`SELECT status, host_id FROM events WHERE id = $1 FOR UPDATE`,
+		eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if event.Status == "cancelled" || event.Status == "completed" {
+		return nil, nil, ErrEventNotOpen
+	}
+	if event.HostID == toUserID {
+		return nil, nil, ErrHostCannotRegister
+	}
+	if err := r.checkNotBlockedTx(ctx, tx, event.HostID, toUserID); err != nil {
+		return nil, nil, err
+	}
+
+	// 2. Lock the transfer and check it can still be used
+	var transfer model.SpotTransfer
+	err = tx.GetContext(ctx, &transfer,
+		`SELECT * FROM spot_transfers WHERE id = $1 AND event_id = $2 FOR UPDATE`,
+		transferID, eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if transfer.Status != from || !transfer.IsOpen(time.Now()) {
+		return nil, nil, ErrTransferUnavailable
+	}
+	if transfer.ToUserID != nil && *transfer.ToUserID != toUserID {
+		return nil, nil, ErrTransferUnavailable
+	}
+	if transfer.FromUserID == toUserID {
+		return nil, nil, ErrAlreadyRegistered
+	}
+
+	// 3. Lock the sender's registration; the spot must still be confirmed
+	var fromReg model.Registration
+	err = tx.GetContext(ctx, &fromReg,
+		`SELECT * FROM registrations WHERE id = $1 FOR UPDATE`,
+		transfer.RegistrationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if fromReg.Status != model.RegistrationConfirmed || fromReg.GuestCount > 0 {
+		return nil, nil, ErrTransferUnavailable
+	}
+
+	// 4. Check for the recipient's existing registration (including cancelled)
+	var existingReg model.Registration
+	err = tx.GetContext(ctx, &existingReg,
+		`SELECT * FROM registrations WHERE event_id = $1 AND user_id = $2 FOR UPDATE`,
+		eventID, toUserID)
+	hasExisting := err == nil
+	if err != nil && err != sql.ErrNoRows {
+		return nil, nil, err
+	}
+	if hasExisting && (existingReg.Status == model.RegistrationConfirmed || existingReg.Status == model.RegistrationOffered) {
+		return nil, nil, ErrAlreadyRegistered
+	}
+	// A single seat cannot take a waitlisted party with guests, nor go to a player the host
+	// turned down or has yet to approve
+	if hasExisting && existingReg.Status == model.RegistrationWaitlist && existingReg.GuestCount > 0 {
+		return nil, nil, ErrTransferUnavailable
+	}
+	if hasExisting && (existingReg.Status == model.RegistrationRejected || existingReg.Status == model.RegistrationPending) {
+		return nil, nil, ErrTransferUnavailable
+	}
+
+	// 5. Count the waitlisted players the recipient goes ahead of
+	var bypassed int
+	if hasExisting && existingReg.Status == model.RegistrationWaitlist && existingReg.WaitlistPosition != nil {
+		bypassed = *existingReg.WaitlistPosition - 1
+	} else {
+		err = tx.GetContext(ctx, &bypassed,
+			`SELECT COUNT(*) FROM registrations WHERE event_id = $1 AND status = 'waitlist'`,
+			eventID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 6. Cancel the sender's registration
+	_, err = tx.ExecContext(ctx,
+		`UPDATE registrations SET status = 'cancelled', cancelled_at = NOW() WHERE id = $1`,
+		fromReg.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 7. Confirm the recipient
+	reg := &model.Registration{
+		EventID: eventID,
+		UserID:  toUserID,
+		Status:  model.RegistrationConfirmed,
+	}
+	if hasExisting {
+		reg.ID = existingReg.ID
+		err = tx.QueryRowxContext(ctx, `
+			UPDATE registrations
+			SET status = 'confirmed', waitlist_position = NULL, group_id = NULL,
+				registered_at = NOW(), confirmed_at = NOW(), offer_expires_at = NULL,
+				cancelled_at = NULL, checked_in_at = NULL, attendance = NULL, late_cancelled = FALSE
+			WHERE id = $1
+			RETURNING registered_at, confirmed_at`,
+			reg.ID).Scan(&reg.RegisteredAt, &reg.ConfirmedAt)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Drop guests left over from a cancelled registration
+		if existingReg.GuestCount > 0 {
+			if _, err = r.setGuestsTx(ctx, tx, reg.ID, nil); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		// Close the gap the recipient leaves in the waitlist
+		if existingReg.Status == model.RegistrationWaitlist && existingReg.WaitlistPosition != nil {
+			if err = r.closeWaitlistGapTx(ctx, tx, eventID, *existingReg.WaitlistPosition); err != nil {
+				return nil, nil, err
+			}
+		}
+	} else {
+		reg.ID = uuid.New()
+		err = tx.QueryRowxContext(ctx, `
+			INSERT INTO registrations (id, event_id, user_id, status, registered_at, confirmed_at)
+			VALUES ($1, $2, $3, 'confirmed', NOW(), NOW())
+			RETURNING registered_at, confirmed_at`,
+			reg.ID, eventID, toUserID).Scan(&reg.RegisteredAt, &reg.ConfirmedAt)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 8. Record the completed transfer
+	err = tx.GetContext(ctx, &transfer, `
+		UPDATE spot_transfers
+		SET status = 'completed', to_user_id = $2, waitlist_bypassed = $3,
+			accepted_at = COALESCE(accepted_at, NOW()), resolved_at = NOW()
+		WHERE id = $1
+		RETURNING *`,
+		transfer.ID, toUserID, bypassed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return reg, &transfer, nil
+}
+
+// CancelAndPromote atomically cancels a registration and hands its seats to the waitlist,
+// locking the event the same way as RegisterWithLock.
+// With a positive offerWindow the seats are offered and held until the offers expire;
+// otherwise waitlisted users are promoted to confirmed immediately. A cancelled party
+// frees a seat for each of its guests as well.
+// Returns the offered or promoted registrations, if any.
+func (r *RegistrationRepository) CancelAndPromote(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	registrationID, eventID uuid.UUID,
+	offerWindow time.Duration,
+) ([]model.Registration, error) {
+	// 1. Lock the event record so freed seats and status are computed against
+	// concurrent registrations and capacity changes
+	if _, _, err := r.GetEventForUpdate(ctx, tx, eventID); err != nil {
+		return nil, err
+	}
+
+	// 2. Lock and get the registration to cancel
+	var reg model.Registration
+	err := tx.GetContext(ctx, &reg,
+		`SELECT * FROM registrations WHERE id = $1 FOR UPDATE`,
+		registrationID)
+	if err != nil {
+		return nil, err
+	}
+	return r.cancelTx(ctx, tx, &reg, eventID, offerWindow)
+}
+
+// RemoveWithLock cancels a registration on the host's behalf, locking the event the
+// same way as RegisterWithLock, and hands the freed seats to the waitlist like
+// CancelAndPromote. Removal is never flagged as a late cancellation.
+// Returns the removed registration and the offered or promoted registrations.
+// Returns sql.ErrNoRows if the registration is not for the event and
+// ErrAlreadyCancelled if it is no longer active.
+func (r *RegistrationRepository) RemoveWithLock(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	eventID, registrationID uuid.UUID,
+	offerWindow time.Duration,
+) (*model.Registration, []model.Registration, error) {
+	// 1. Lock the event record to prevent concurrent modifications
+	_, status, err := r.GetEventForUpdate(ctx, tx, eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if status == "cancelled" || status == "completed" {
+		return nil, nil, ErrEventNotOpen
+	}
+
+	// 2. Lock the registration and cancel it
+	var reg model.Registration
+	err = tx.GetContext(ctx, &reg,
+		`SELECT * FROM registrations WHERE id = $1 AND event_id = $2 FOR UPDATE`,
+		registrationID, eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+	promoted, err := r.cancelTx(ctx, tx, &reg, eventID, offerWindow)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &reg, promoted, nil
+}
+
+// cancelTx cancels a registration the caller has locked and hands its seats to
+// the waitlist, or closes the gap it leaves in the waitlist
+func (r *RegistrationRepository) cancelTx(ctx context.Context, tx *sqlx.Tx, reg *model.Registration, eventID uuid.UUID, offerWindow time.Duration) ([]model.Registration, error) {
+	// A rejected request stays rejected so the player cannot apply again
+	if reg.Status == model.RegistrationCancelled || reg.Status == model.RegistrationRejected {
+		return nil, ErrAlreadyCancelled
+	}
+
+	heldSpot := reg.Status == model.RegistrationConfirmed || reg.Status == model.RegistrationOffered
+	wasWaitlist := reg.Status == model.RegistrationWaitlist
+	oldWaitlistPos := reg.WaitlistPosition
+
+	// 2. Update to cancelled status
+	_, err := tx.ExecContext(ctx,
+		`UPDATE registrations SET status = 'cancelled', cancelled_at = NOW(), waitlist_position = NULL, offer_expires_at = NULL WHERE id = $1`,
+		reg.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. If user was in waitlist, reorder remaining waitlist positions
+	if wasWaitlist && oldWaitlistPos != nil {
+		if err = r.closeWaitlistGapTx(ctx, tx, eventID, *oldWaitlistPos); err != nil {
+			return nil, err
+		}
+		return nil, nil // No promotion needed for waitlist cancellation
+	}
+
+	// 4. If user held a spot, pass the freed seats on to the waitlist
+	if !heldSpot {
+		return nil, nil
+	}
+	promoted, err := r.fillTx(ctx, tx, eventID, offerWindow)
+	if err != nil {
+		return nil, err
+	}
+	return promoted, r.SyncEventStatusTx(ctx, tx, eventID)
+}
+
+// CancelGuestTx releases a single guest seat on a user's registration within a
+// transaction and hands the freed seat to the waitlist the same way as CancelAndPromote,
+// locking the event first.
+// Returns sql.ErrNoRows if the user has no active registration or the guest is not on it.
+func (r *RegistrationRepository) CancelGuestTx(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	eventID, userID, guestID uuid.UUID,
+	offerWindow time.Duration,
+) ([]model.Registration, error) {
+	// Lock the event record so the freed seat is counted against concurrent registrations
+	if _, _, err := r.GetEventForUpdate(ctx, tx, eventID); err != nil {
+		return nil, err
+	}
+
+	var reg model.Registration
+	err := tx.GetContext(ctx, &reg,
+		`SELECT * FROM registrations WHERE event_id = $1 AND user_id = $2 FOR UPDATE`,
+		eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if reg.Status == model.RegistrationCancelled {
+		return nil, sql.ErrNoRows
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE registration_guests SET cancelled_at = NOW()
+		WHERE id = $1 AND registration_id = $2 AND cancelled_at IS NULL`,
+		guestID, reg.ID)
+	if err != nil {
+		return nil, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE registrations SET guest_count = guest_count - 1 WHERE id = $1`,
+		reg.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The freed seat may go to the waitlist, or let a smaller waitlisted party in
+	promoted, err := r.fillTx(ctx, tx, eventID, offerWindow)
+	if err != nil {
+		return nil, err
+	}
+	return promoted, r.SyncEventStatusTx(ctx, tx, eventID)
+}
+
+// FindGuestsByEventID finds the guests on an event's active registrations, keyed by
+// registration ID, in the order they were added
+func (r *RegistrationRepository) FindGuestsByEventID(ctx context.Context, eventID uuid.UUID) (map[uuid.UUID][]model.Guest, error) {
+	var guests []model.Guest
+	query := `
+		SELECT g.id, g.registration_id, g.name, g.created_at, g.cancelled_at
+		FROM registration_guests g
+		JOIN registrations r ON r.id = g.registration_id
+		WHERE r.event_id = $1 AND r.status != 'cancelled' AND g.cancelled_at IS NULL
+		ORDER BY g.created_at ASC`
+	err := r.db.SelectContext(ctx, &guests, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	byRegistration := make(map[uuid.UUID][]model.Guest)
+	for _, guest := range guests {
+		byRegistration[guest.RegistrationID] = append(byRegistration[guest.RegistrationID], guest)
+	}
+	return byRegistration, nil
+}
+
+// setGuestsTx replaces the guests on a registration within a transaction and keeps
+// its guest count in step
+func (r *RegistrationRepository) setGuestsTx(ctx context.Context, tx *sqlx.Tx, registrationID uuid.UUID, names []string) ([]model.Guest, error) {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE registration_guests SET cancelled_at = NOW()
+		WHERE registration_id = $1 AND cancelled_at IS NULL`,
+		registrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	guests := make([]model.Guest, len(names))
+	for i, name := range names {
+		guests[i] = model.Guest{ID: uuid.New(), RegistrationID: registrationID, Name: name}
+		err = tx.QueryRowxContext(ctx, `
+			INSERT INTO registration_guests (id, registration_id, name, created_at)
+			VALUES ($1, $2, $3, NOW())
+			RETURNING created_at`,
+			guests[i].ID, registrationID, name).Scan(&guests[i].CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE registrations SET guest_count = $2 WHERE id = $1`,
+		registrationID, len(names))
+	if err != nil {
+		return nil, err
+	}
+	return guests, nil
+}
+
+// AcceptOffer atomically confirms a user's pending waitlist offer.
+// Returns ErrNoOffer if the user has no pending offer and ErrOfferExpired if the
+// hold window has already passed.
+func (r *RegistrationRepository) AcceptOffer(ctx context.Context, tx *sqlx.Tx, eventID, userID uuid.UUID) (*model.Registration, error) {
+	var reg model.Registration
+	err := tx.GetContext(ctx, &reg,
+		`SELECT * FROM registrations WHERE event_id = $1 AND user_id = $2 FOR UPDATE`,
+		eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reg.Status != model.RegistrationOffered {
+		return nil, ErrNoOffer
+	}
+	if reg.OfferExpiresAt != nil && !reg.OfferExpiresAt.After(time.Now()) {
+		return nil, ErrOfferExpired
+	}
+
+	err = tx.QueryRowxContext(ctx, `
+		UPDATE registrations
+		SET status = 'confirmed', confirmed_at = NOW(), offer_expires_at = NULL
+		WHERE id = $1
+		RETURNING confirmed_at`,
+		reg.ID).Scan(&reg.ConfirmedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	reg.Status = model.RegistrationConfirmed
+	reg.OfferExpiresAt = nil
+	return &reg, nil
+}
+
+// DeclineOffer atomically declines a user's pending waitlist offer and offers the
+// freed seats to the next waitlisted users, locking the event the same way as
+// RegisterWithLock.
+// Returns the next offered registrations, if any.
+func (r *RegistrationRepository) DeclineOffer(ctx context.Context, tx *sqlx.Tx, eventID, userID uuid.UUID, offerWindow time.Duration) ([]model.Registration, error) {
+	_, status, err := r.GetEventForUpdate(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reg model.Registration
+	err = tx.GetContext(ctx, &reg,
+		`SELECT * FROM registrations WHERE event_id = $1 AND user_id = $2 FOR UPDATE`,
+		eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reg.Status != model.RegistrationOffered {
+		return nil, ErrNoOffer
+	}
+
+	return r.releaseOfferTx(ctx, tx, &reg, status, offerWindow)
+}
+
+// ExpireOffer atomically expires a pending waitlist offer whose hold window has
+// passed and offers the freed seats to the next waitlisted users, locking the
+// event the same way as RegisterWithLock.
+// Returns ErrNoOffer if the offer was accepted, declined or is not yet expired.
+func (r *RegistrationRepository) ExpireOffer(ctx context.Context, tx *sqlx.Tx, registrationID uuid.UUID, offerWindow time.Duration) ([]model.Registration, error) {
+	// Look up the event first so its row is locked before the registration,
+	// in the same order as every other registration change
+	var eventID uuid.UUID
+	err := tx.GetContext(ctx, &eventID,
+		`SELECT event_id FROM registrations WHERE id = $1`,
+		registrationID)
+	if err != nil {
+		return nil, err
+	}
+	_, status, err := r.GetEventForUpdate(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reg model.Registration
+	err = tx.GetContext(ctx, &reg,
+		`SELECT * FROM registrations WHERE id = $1 FOR UPDATE`,
+		registrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reg.Status != model.RegistrationOffered || reg.OfferExpiresAt == nil || reg.OfferExpiresAt.After(time.Now()) {
+		return nil, ErrNoOffer
+	}
+
+	return r.releaseOfferTx(ctx, tx, &reg, status, offerWindow)
+}
+
+// releaseOfferTx cancels an offered registration and cascades the offer down the
+// waitlist. eventStatus is the status of the event locked by the caller; once
+// the event is cancelled or completed the seat is not offered to anyone else.
+func (r *RegistrationRepository) releaseOfferTx(ctx context.Context, tx *sqlx.Tx, reg *model.Registration, eventStatus string, offerWindow time.Duration) ([]model.Registration, error) {
+	_, err := tx.ExecContext(ctx,
+		`UPDATE registrations SET status = 'cancelled', cancelled_at = NOW(), offer_expires_at = NULL WHERE id = $1`,
+		reg.ID)
+	if err != nil {
+		return nil, err
+	}
+	if model.EventStatus(eventStatus).IsFinal() {
+		return nil, nil
+	}
+	next, err := r.fillTx(ctx, tx, reg.EventID, offerWindow)
+	if err != nil {
+		return nil, err
+	}
+	return next, r.SyncEventStatusTx(ctx, tx, reg.EventID)
+}
+
+// SyncEventStatusTx recalculates whether an open or full event is full from the
+// seats held by confirmed and offered registrations and their guests. Cancelled
+// and completed events are left untouched.
+func (r *RegistrationRepository) SyncEventStatusTx(ctx context.Context, tx *sqlx.Tx, eventID uuid.UUID) error {
+	_, err := tx.ExecContext(ctx, `
+		WITH held AS (
+			SELECT COALESCE(SUM(1 + guest_count), 0) AS n FROM registrations
+			WHERE event_id = $1 AND status IN ('confirmed', 'offered')
+		)
+		UPDATE events
+		SET status = CASE WHEN held.n >= events.capacity THEN 'full' ELSE 'open' END,
+			updated_at = NOW()
+		FROM held
+		WHERE events.id = $1
+		AND events.status IN ('open', 'full')
+		AND events.status <> CASE WHEN held.n >= events.capacity THEN 'full' ELSE 'open' END`,
+		eventID)
+	return err
+}
+
+// CapacityChange holds the registrations moved by a capacity change
+type CapacityChange struct {
+	Promoted []model.Registration
+	Demoted  []model.Registration
+}
+
+// Notifications returns the notifications for users who were promoted or moved
+// back to the waitlist by the change
+func (c *CapacityChange) Notifications(event *model.Event) []*model.Notification {
+	var notifications []*model.Notification
+	for _, reg := range c.Promoted {
+		notifications = append(notifications, model.NewWaitlistPromotedNotification(reg.UserID, event.ID, event.GetNotificationTitle()))
+	}
+	for _, reg := range c.Demoted {
+		notifications = append(notifications, model.NewMovedToWaitlistNotification(reg.UserID, event.ID, event.GetNotificationTitle(), *reg.WaitlistPosition))
+	}
+	return notifications
+}
+
+// ResizeTx brings registrations in line with a new event capacity. The event row
+// must already be locked and updated by the caller. Extra seats are given to
+// waitlisted users in order. When fewer seats remain than are held, the change is
+// refused with ErrCapacityBelowHeld unless the policy is CapacityPolicyDemote, in
+// which case pending offers and then the latest confirmed users are moved back to
+// the head of the waitlist.
+func (r *RegistrationRepository) ResizeTx(ctx context.Context, tx *sqlx.Tx, eventID uuid.UUID, capacity int, policy model.CapacityPolicy) (*CapacityChange, error) {
+	held, err := r.heldSeatsTx(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &CapacityChange{}
+	switch {
+	case held < capacity:
+		change.Promoted, err = r.fillTx(ctx, tx, eventID, 0)
+	case held > capacity:
+		if policy != model.CapacityPolicyDemote {
+			return nil, ErrCapacityBelowHeld
+		}
+		change.Demoted, err = r.demoteTx(ctx, tx, eventID, held-capacity)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// checkNotBlockedTx returns ErrBlocked if the host has blocked any of the users
+func (r *RegistrationRepository) checkNotBlockedTx(ctx context.Context, tx *sqlx.Tx, hostID uuid.UUID, userIDs ...uuid.UUID) error {
+	var blocked bool
+	err := tx.GetContext(ctx, &blocked,
+		`SELECT EXISTS(SELECT 1 FROM host_blocks WHERE host_id = $1 AND user_id = ANY($2))`,
+		hostID, pq.Array(userIDs))
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrBlocked
+	}
+	return nil
+}
+
+// heldSeatsTx counts the seats held by confirmed and offered registrations and
+// their guests within a transaction
+func (r *RegistrationRepository) heldSeatsTx(ctx context.Context, tx *sqlx.Tx, eventID uuid.UUID) (int, error) {
+	var held int
+	err := tx.GetContext(ctx, &held,
+		`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations WHERE event_id = $1 AND status IN ('confirmed', 'offered')`,
+		eventID)
+	return held, err
+}
+
+// fillTx hands the event's free seats to waitlisted users in waitlist order. A user
+// whose party or group is too large for the seats left is skipped, so a smaller one
+// behind them can go ahead. With a positive offerWindow the seats are offered and
+// held until the offer expires; otherwise users are confirmed immediately. Groups
+// are always confirmed together, since holding seats for each member's reply could
+// split them up.
+// Returns the offered or confirmed registrations.
+func (r *RegistrationRepository) fillTx(ctx context.Context, tx *sqlx.Tx, eventID uuid.UUID, offerWindow time.Duration) ([]model.Registration, error) {
+	var filled []model.Registration
+	for {
+		// Get the first waitlisted party or group that fits, using SKIP LOCKED to avoid deadlocks
+		var next model.Registration
+		err := tx.GetContext(ctx, &next, `
+			SELECT * FROM registrations w
+			WHERE event_id = $1 AND status = 'waitlist'
+			AND (
+				SELECT SUM(1 + u.guest_count) FROM registrations u
+				WHERE u.status = 'waitlist' AND (u.id = w.id OR u.group_id = w.group_id)
+			) <= (SELECT capacity FROM events WHERE id = $1) - (
+				SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations
+				WHERE event_id = $1 AND status IN ('confirmed', 'offered'))
+			ORDER BY waitlist_position ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED`,
+			eventID)
+		if err == sql.ErrNoRows {
+			return filled, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if next.GroupID != nil {
+			var members []model.Registration
+			err = tx.SelectContext(ctx, &members, `
+				UPDATE registrations
+				SET status = 'confirmed', confirmed_at = NOW(), waitlist_position = NULL
+				WHERE group_id = $1 AND status = 'waitlist'
+				RETURNING *`,
+				*next.GroupID)
+			if err != nil {
+				return nil, err
+			}
+			if next.WaitlistPosition != nil {
+				if err = r.closeWaitlistGapTx(ctx, tx, eventID, *next.WaitlistPosition); err != nil {
+					return nil, err
+				}
+			}
+			filled = append(filled, members...)
+			continue
+		}
+
+		if offerWindow > 0 {
+			err = tx.QueryRowxContext(ctx, `
+				UPDATE registrations
+				SET status = 'offered', waitlist_position = NULL,
+					offer_expires_at = NOW() + $2 * INTERVAL '1 second'
+				WHERE id = $1
+				RETURNING offer_expires_at`,
+				next.ID, int64(offerWindow/time.Second)).Scan(&next.OfferExpiresAt)
+			next.Status = model.RegistrationOffered
+		} else {
+			err = tx.QueryRowxContext(ctx, `
+				UPDATE registrations
+				SET status = 'confirmed', confirmed_at = NOW(), waitlist_position = NULL
+				WHERE id = $1
+				RETURNING confirmed_at`,
+				next.ID).Scan(&next.ConfirmedAt)
+			next.Status = model.RegistrationConfirmed
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Close the gap the user leaves in the waitlist
+		if next.WaitlistPosition != nil {
+			if err = r.closeWaitlistGapTx(ctx, tx, eventID, *next.WaitlistPosition); err != nil {
+				return nil, err
+			}
+		}
+
+		next.WaitlistPosition = nil
+		filled = append(filled, next)
+	}
+}
+
+// closeWaitlistGapTx moves everyone behind pos up one place once nobody is left
+// waiting at pos. The members of a group share a position, so the gap only opens
+// when the last of them leaves.
+func (r *RegistrationRepository) closeWaitlistGapTx(ctx context.Context, tx *sqlx.Tx, eventID uuid.UUID, pos int) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE registrations
+		SET waitlist_position = waitlist_position - 1
+		WHERE event_id = $1 AND status = 'waitlist' AND waitlist_position > $2
+		AND NOT EXISTS (
+			SELECT 1 FROM registrations
+			WHERE event_id = $1 AND status = 'waitlist' AND waitlist_position = $2)`,
+		eventID, pos)
+	return err
+}
+
+// demoteTx moves the most recently held spots back to the head of the waitlist until
+// at least n seats are freed. Pending offers go first, then confirmed users from the
+// latest confirmation back; a party is always demoted together with its guests, and
+// a group together with all of its confirmed members, sharing one waitlist position.
+// Demoted users keep their relative order: the earliest confirmed is first in line.
+func (r *RegistrationRepository) demoteTx(ctx context.Context, tx *sqlx.Tx, eventID uuid.UUID, n int) ([]model.Registration, error) {
+	var held []model.Registration
+	err := tx.SelectContext(ctx, &held, `
+		SELECT * FROM registrations
+		WHERE event_id = $1 AND status IN ('confirmed', 'offered')
+		ORDER BY CASE WHEN status = 'offered' THEN 0 ELSE 1 END,
+			COALESCE(confirmed_at, registered_at) DESC
+		FOR UPDATE`,
+		eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each unit takes one waitlist position: a single registration or a whole group
+	var units [][]model.Registration
+	grouped := make(map[uuid.UUID]bool)
+	freed := 0
+	for _, reg := range held {
+		if freed >= n {
+			break
+		}
+		if reg.GroupID == nil {
+			units = append(units, []model.Registration{reg})
+			freed += reg.Seats()
+			continue
+		}
+		if grouped[*reg.GroupID] {
+			continue
+		}
+		grouped[*reg.GroupID] = true
+		var unit []model.Registration
+		for _, member := range held {
+			if member.GroupID != nil && *member.GroupID == *reg.GroupID {
+				unit = append(unit, member)
+				freed += member.Seats()
+			}
+		}
+		units = append(units, unit)
+	}
+	if len(units) == 0 {
+		return nil, nil
+	}
+
+	// Make room at the head of the waitlist
+	_, err = tx.ExecContext(ctx, `
+		UPDATE registrations
+		SET waitlist_position = waitlist_position + $2
+		WHERE event_id = $1 AND status = 'waitlist'`,
+		eventID, len(units))
+	if err != nil {
+		return nil, err
+	}
+
+	var regs []model.Registration
+	for i, unit := range units {
+		pos := len(units) - i
+		for _, reg := range unit {
+			_, err = tx.ExecContext(ctx, `
+				UPDATE registrations
+				SET status = 'waitlist', waitlist_position = $2, confirmed_at = NULL, offer_expires_at = NULL
+				WHERE id = $1`,
+				reg.ID, pos)
+			if err != nil {
+				return nil, err
+			}
+			reg.Status = model.RegistrationWaitlist
+			reg.WaitlistPosition = &pos
+			reg.ConfirmedAt = nil
+			reg.OfferExpiresAt = nil
+			regs = append(regs, reg)
+		}
+	}
+	return regs, nil
 }
 
 // GetEventForUpdate locks an event row for update within a transaction
@@ -538,11 +1935,11 @@ func (r *RegistrationRepository) GetEventForUpdate(ctx context.Context, tx *sqlx
 	return event.Capacity, event.Status, nil
 }
 
-// CountConfirmedTx counts confirmed registrations within a transaction
+// CountConfirmedTx counts the seats taken by confirmed registrations and their guests within a transaction
 func (r *RegistrationRepository) CountConfirmedTx(ctx context.Context, tx *sqlx.Tx, eventID uuid.UUID) (int, error) {
 	var count int
 	err := tx.GetContext(ctx, &count,
-		`SELECT COUNT(*) FROM registrations WHERE event_id = $1 AND status = 'confirmed'`,
+		`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations WHERE event_id = $1 AND status = 'confirmed'`,
 		eventID)
 	return count, err
 }
@@ -225,7 +225,7 @@ func TestCountConfirmed(t *testing.T) {
 			eventID: uuid.New(),
 			setupMock: func(mock sqlmock.Sqlmock, eventID uuid.UUID) {
 				rows := sqlmock.NewRows([]string{"count"}).AddRow(3)
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM registrations WHERE event_id = $1 AND status = 'confirmed'`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations WHERE event_id = $1 AND status = 'confirmed'`)).
 					WithArgs(eventID).
 					WillReturnRows(rows)
 			},
@@ -237,7 +237,7 @@ func TestCountConfirmed(t *testing.T) {
 			eventID: uuid.New(),
 			setupMock: func(mock sqlmock.Sqlmock, eventID uuid.UUID) {
 				rows := sqlmock.NewRows([]string{"count"}).AddRow(0)
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM registrations WHERE event_id = $1 AND status = 'confirmed'`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations WHERE event_id = $1 AND status = 'confirmed'`)).
 					WithArgs(eventID).
 					WillReturnRows(rows)
 			},
@@ -461,15 +461,16 @@ func TestRegisterWithLock(t *testing.T) {
 				mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status, host_id FROM events WHERE id = $1 FOR UPDATE`)).
 					WithArgs(eventID).
 					WillReturnRows(eventRows)
+				expectNotBlocked(mock)
 
 				// Check for existing registration
 				mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE event_id = $1 AND user_id = $2`)).
 					WithArgs(eventID, userID).
 					WillReturnError(sql.ErrNoRows)
 
-				// Count confirmed registrations
+				// Count seats held by confirmed and offered registrations
 				countRows := sqlmock.NewRows([]string{"count"}).AddRow(confirmedCount)
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM registrations WHERE event_id = $1 AND status = 'confirmed'`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations WHERE event_id = $1 AND status IN ('confirmed', 'offered')`)).
 					WithArgs(eventID).
 					WillReturnRows(countRows)
 
@@ -480,6 +481,11 @@ func TestRegisterWithLock(t *testing.T) {
 					WithArgs(sqlmock.AnyArg(), eventID, userID, model.RegistrationConfirmed, nil).
 					WillReturnRows(insertRows)
 
+				// Recalculate open/full in the same transaction
+				mock.ExpectExec(regexp.QuoteMeta(`WITH held AS`)).
+					WithArgs(eventID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
 				mock.ExpectCommit()
 			},
 			expectedStatus: model.RegistrationConfirmed,
@@ -503,15 +509,16 @@ func TestRegisterWithLock(t *testing.T) {
 				mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status, host_id FROM events WHERE id = $1 FOR UPDATE`)).
 					WithArgs(eventID).
 					WillReturnRows(eventRows)
+				expectNotBlocked(mock)
 
 				// Check for existing registration
 				mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE event_id = $1 AND user_id = $2`)).
 					WithArgs(eventID, userID).
 					WillReturnError(sql.ErrNoRows)
 
-				// Count confirmed registrations
+				// Count seats held by confirmed and offered registrations
 				countRows := sqlmock.NewRows([]string{"count"}).AddRow(confirmedCount)
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM registrations WHERE event_id = $1 AND status = 'confirmed'`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations WHERE event_id = $1 AND status IN ('confirmed', 'offered')`)).
 					WithArgs(eventID).
 					WillReturnRows(countRows)
 
@@ -528,6 +535,11 @@ func TestRegisterWithLock(t *testing.T) {
 					WithArgs(sqlmock.AnyArg(), eventID, userID, model.RegistrationWaitlist, 1).
 					WillReturnRows(insertRows)
 
+				// Recalculate open/full in the same transaction
+				mock.ExpectExec(regexp.QuoteMeta(`WITH held AS`)).
+					WithArgs(eventID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
 				mock.ExpectCommit()
 			},
 			expectedStatus: model.RegistrationWaitlist,
@@ -622,6 +634,7 @@ func TestRegisterWithLock(t *testing.T) {
 				mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status, host_id FROM events WHERE id = $1 FOR UPDATE`)).
 					WithArgs(eventID).
 					WillReturnRows(eventRows)
+				expectNotBlocked(mock)
 
 				// Check for existing registration - found active one
 				now := time.Now()
@@ -661,6 +674,7 @@ func TestRegisterWithLock(t *testing.T) {
 				mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status, host_id FROM events WHERE id = $1 FOR UPDATE`)).
 					WithArgs(eventID).
 					WillReturnRows(eventRows)
+				expectNotBlocked(mock)
 
 				// Check for existing registration - found cancelled one
 				now := time.Now()
@@ -676,9 +690,9 @@ func TestRegisterWithLock(t *testing.T) {
 					WithArgs(eventID, userID).
 					WillReturnRows(existingRows)
 
-				// Count confirmed registrations
+				// Count seats held by confirmed and offered registrations
 				countRows := sqlmock.NewRows([]string{"count"}).AddRow(confirmedCount)
-				mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM registrations WHERE event_id = $1 AND status = 'confirmed'`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations WHERE event_id = $1 AND status IN ('confirmed', 'offered')`)).
 					WithArgs(eventID).
 					WillReturnRows(countRows)
 
@@ -688,6 +702,11 @@ func TestRegisterWithLock(t *testing.T) {
 					WithArgs(existingReg.ID, model.RegistrationConfirmed, nil).
 					WillReturnRows(updateRows)
 
+				// Recalculate open/full in the same transaction
+				mock.ExpectExec(regexp.QuoteMeta(`WITH held AS`)).
+					WithArgs(eventID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
 				mock.ExpectCommit()
 			},
 			expectedStatus: model.RegistrationConfirmed,
@@ -735,7 +754,7 @@ func TestRegisterWithLock(t *testing.T) {
 				return
 			}
 
-			result, err := repo.RegisterWithLock(context.Background(), tx, tt.eventID, tt.userID)
+			result, err := repo.RegisterWithLock(context.Background(), tx, tt.eventID, tt.userID, nil)
 
 			if tt.expectedError != nil {
 				if err == nil {
@@ -781,8 +800,10 @@ func TestCancelAndPromote(t *testing.T) {
 		regStatus        model.RegistrationStatus
 		waitlistPosition *int
 		hasWaitlist      bool
+		offerWindow      time.Duration
 		setupMock        func(mock sqlmock.Sqlmock, regID, eventID uuid.UUID, regStatus model.RegistrationStatus, waitlistPos *int, hasWaitlist bool)
 		expectedPromoted bool
+		expectedStatus   model.RegistrationStatus
 		expectedError    error
 	}{
 		{
@@ -794,6 +815,7 @@ func TestCancelAndPromote(t *testing.T) {
 			hasWaitlist:      true,
 			setupMock: func(mock sqlmock.Sqlmock, regID, eventID uuid.UUID, regStatus model.RegistrationStatus, waitlistPos *int, hasWaitlist bool) {
 				mock.ExpectBegin()
+				expectEventLock(mock, eventID)
 
 				// Lock and get the registration
 				now := time.Now()
@@ -809,7 +831,7 @@ func TestCancelAndPromote(t *testing.T) {
 					WillReturnRows(regRows)
 
 				// Update to cancelled
-				mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations SET status = 'cancelled', cancelled_at = NOW(), waitlist_position = NULL WHERE id = $1`)).
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations SET status = 'cancelled', cancelled_at = NOW(), waitlist_position = NULL, offer_expires_at = NULL WHERE id = $1`)).
 					WithArgs(regID).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -828,18 +850,98 @@ func TestCancelAndPromote(t *testing.T) {
 					WillReturnRows(waitlistRows)
 
 				// Promote the waitlisted user
-				mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`SET status = 'confirmed', confirmed_at = NOW(), waitlist_position = NULL`)).
 					WithArgs(waitlistID).
-					WillReturnResult(sqlmock.NewResult(0, 1))
+					WillReturnRows(sqlmock.NewRows([]string{"confirmed_at"}).AddRow(now))
 
 				// Reorder remaining waitlist positions
 				mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations`)).
+					WithArgs(eventID, 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				// No one else fits in the freed seats
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations`)).
+					WithArgs(eventID).
+					WillReturnError(sql.ErrNoRows)
+
+				// Recalculate open/full in the same transaction
+				mock.ExpectExec(regexp.QuoteMeta(`WITH held AS`)).
+					WithArgs(eventID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectCommit()
+			},
+			expectedPromoted: true,
+			expectedStatus:   model.RegistrationConfirmed,
+			expectedError:    nil,
+		},
+		{
+			name:             "cancel confirmed and offer spot to waitlist",
+			registrationID:   uuid.New(),
+			eventID:          uuid.New(),
+			regStatus:        model.RegistrationConfirmed,
+			waitlistPosition: nil,
+			hasWaitlist:      true,
+			offerWindow:      2 * time.Hour,
+			setupMock: func(mock sqlmock.Sqlmock, regID, eventID uuid.UUID, regStatus model.RegistrationStatus, waitlistPos *int, hasWaitlist bool) {
+				mock.ExpectBegin()
+				expectEventLock(mock, eventID)
+
+				// Lock and get the registration
+				now := time.Now()
+				regRows := sqlmock.NewRows([]string{
+					"id", "event_id", "user_id", "status", "waitlist_position",
+					"registered_at", "confirmed_at", "cancelled_at",
+				}).AddRow(
+					regID, eventID, uuid.New(), regStatus, waitlistPos,
+					now, now, nil,
+				)
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE id = $1 FOR UPDATE`)).
+					WithArgs(regID).
+					WillReturnRows(regRows)
+
+				// Update to cancelled
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations SET status = 'cancelled', cancelled_at = NOW(), waitlist_position = NULL, offer_expires_at = NULL WHERE id = $1`)).
+					WithArgs(regID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				// Get first waitlist person with SKIP LOCKED
+				waitlistID := uuid.New()
+				waitlistRows := sqlmock.NewRows([]string{
+					"id", "event_id", "user_id", "status", "waitlist_position",
+					"registered_at", "confirmed_at", "cancelled_at",
+				}).AddRow(
+					waitlistID, eventID, uuid.New(), model.RegistrationWaitlist, 1,
+					now.Add(-time.Hour), nil, nil,
+				)
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations`)).
 					WithArgs(eventID).
+					WillReturnRows(waitlistRows)
+
+				// Hold the spot for the offer window
+				mock.ExpectQuery(regexp.QuoteMeta(`SET status = 'offered'`)).
+					WithArgs(waitlistID, int64(7200)).
+					WillReturnRows(sqlmock.NewRows([]string{"offer_expires_at"}).AddRow(now.Add(2 * time.Hour)))
+
+				// Reorder remaining waitlist positions
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations`)).
+					WithArgs(eventID, 1).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 
+				// No one else fits in the freed seats
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations`)).
+					WithArgs(eventID).
+					WillReturnError(sql.ErrNoRows)
+
+				// Recalculate open/full in the same transaction
+				mock.ExpectExec(regexp.QuoteMeta(`WITH held AS`)).
+					WithArgs(eventID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
 				mock.ExpectCommit()
 			},
 			expectedPromoted: true,
+			expectedStatus:   model.RegistrationOffered,
 			expectedError:    nil,
 		},
 		{
@@ -851,6 +953,7 @@ func TestCancelAndPromote(t *testing.T) {
 			hasWaitlist:      false,
 			setupMock: func(mock sqlmock.Sqlmock, regID, eventID uuid.UUID, regStatus model.RegistrationStatus, waitlistPos *int, hasWaitlist bool) {
 				mock.ExpectBegin()
+				expectEventLock(mock, eventID)
 
 				// Lock and get the registration
 				now := time.Now()
@@ -866,7 +969,7 @@ func TestCancelAndPromote(t *testing.T) {
 					WillReturnRows(regRows)
 
 				// Update to cancelled
-				mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations SET status = 'cancelled', cancelled_at = NOW(), waitlist_position = NULL WHERE id = $1`)).
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations SET status = 'cancelled', cancelled_at = NOW(), waitlist_position = NULL, offer_expires_at = NULL WHERE id = $1`)).
 					WithArgs(regID).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -875,6 +978,11 @@ func TestCancelAndPromote(t *testing.T) {
 					WithArgs(eventID).
 					WillReturnError(sql.ErrNoRows)
 
+				// Recalculate open/full in the same transaction
+				mock.ExpectExec(regexp.QuoteMeta(`WITH held AS`)).
+					WithArgs(eventID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
 				mock.ExpectCommit()
 			},
 			expectedPromoted: false,
@@ -889,6 +997,7 @@ func TestCancelAndPromote(t *testing.T) {
 			hasWaitlist:      false,
 			setupMock: func(mock sqlmock.Sqlmock, regID, eventID uuid.UUID, regStatus model.RegistrationStatus, waitlistPos *int, hasWaitlist bool) {
 				mock.ExpectBegin()
+				expectEventLock(mock, eventID)
 
 				// Lock and get the registration
 				now := time.Now()
@@ -904,7 +1013,7 @@ func TestCancelAndPromote(t *testing.T) {
 					WillReturnRows(regRows)
 
 				// Update to cancelled
-				mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations SET status = 'cancelled', cancelled_at = NOW(), waitlist_position = NULL WHERE id = $1`)).
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations SET status = 'cancelled', cancelled_at = NOW(), waitlist_position = NULL, offer_expires_at = NULL WHERE id = $1`)).
 					WithArgs(regID).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -927,6 +1036,7 @@ func TestCancelAndPromote(t *testing.T) {
 			hasWaitlist:      false,
 			setupMock: func(mock sqlmock.Sqlmock, regID, eventID uuid.UUID, regStatus model.RegistrationStatus, waitlistPos *int, hasWaitlist bool) {
 				mock.ExpectBegin()
+				expectEventLock(mock, eventID)
 
 				// Lock and get the registration
 				now := time.Now()
@@ -956,6 +1066,7 @@ func TestCancelAndPromote(t *testing.T) {
 			hasWaitlist:      false,
 			setupMock: func(mock sqlmock.Sqlmock, regID, eventID uuid.UUID, regStatus model.RegistrationStatus, waitlistPos *int, hasWaitlist bool) {
 				mock.ExpectBegin()
+				expectEventLock(mock, eventID)
 
 				// Lock and get the registration - not found
 				mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE id = $1 FOR UPDATE`)).
@@ -987,7 +1098,7 @@ func TestCancelAndPromote(t *testing.T) {
 				return
 			}
 
-			promoted, err := repo.CancelAndPromote(context.Background(), tx, tt.registrationID, tt.eventID)
+			promoted, err := repo.CancelAndPromote(context.Background(), tx, tt.registrationID, tt.eventID, tt.offerWindow)
 
 			if tt.expectedError != nil {
 				if err == nil {
@@ -1003,12 +1114,15 @@ func TestCancelAndPromote(t *testing.T) {
 				return
 			}
 
-			if tt.expectedPromoted && promoted == nil {
-				t.Error("expected promoted registration, got nil")
+			if tt.expectedPromoted && len(promoted) != 1 {
+				t.Errorf("expected one promoted registration, got %v", promoted)
 			}
-			if !tt.expectedPromoted && promoted != nil {
+			if !tt.expectedPromoted && len(promoted) != 0 {
 				t.Errorf("expected no promotion, got %v", promoted)
 			}
+			if len(promoted) > 0 && promoted[0].Status != tt.expectedStatus {
+				t.Errorf("expected promoted status %s, got %s", tt.expectedStatus, promoted[0].Status)
+			}
 
 			tx.Commit()
 
@@ -1019,6 +1133,452 @@ func TestCancelAndPromote(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Waitlist Offer Tests
+// =============================================================================
+
+func TestAcceptOffer(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        model.RegistrationStatus
+		expiresAt     time.Time
+		expectUpdate  bool
+		expectedError error
+	}{
+		{
+			name:         "accept pending offer",
+			status:       model.RegistrationOffered,
+			expiresAt:    time.Now().Add(time.Hour),
+			expectUpdate: true,
+		},
+		{
+			name:          "offer has expired",
+			status:        model.RegistrationOffered,
+			expiresAt:     time.Now().Add(-time.Minute),
+			expectedError: ErrOfferExpired,
+		},
+		{
+			name:          "registration has no offer",
+			status:        model.RegistrationWaitlist,
+			expectedError: ErrNoOffer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupMockDB(t)
+			defer db.Close()
+
+			repo := NewRegistrationRepository(db)
+			eventID := uuid.New()
+			userID := uuid.New()
+			regID := uuid.New()
+			now := time.Now()
+
+			mock.ExpectBegin()
+
+			var expiresAt interface{}
+			if !tt.expiresAt.IsZero() {
+				expiresAt = tt.expiresAt
+			}
+			regRows := sqlmock.NewRows([]string{
+				"id", "event_id", "user_id", "status", "waitlist_position",
+				"registered_at", "confirmed_at", "cancelled_at", "offer_expires_at",
+			}).AddRow(
+				regID, eventID, userID, tt.status, nil,
+				now, nil, nil, expiresAt,
+			)
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE event_id = $1 AND user_id = $2 FOR UPDATE`)).
+				WithArgs(eventID, userID).
+				WillReturnRows(regRows)
+
+			if tt.expectUpdate {
+				mock.ExpectQuery(regexp.QuoteMeta(`SET status = 'confirmed', confirmed_at = NOW(), offer_expires_at = NULL`)).
+					WithArgs(regID).
+					WillReturnRows(sqlmock.NewRows([]string{"confirmed_at"}).AddRow(now))
+			}
+			mock.ExpectRollback()
+
+			tx, err := db.Beginx()
+			if err != nil {
+				t.Fatalf("failed to begin transaction: %v", err)
+			}
+
+			reg, err := repo.AcceptOffer(context.Background(), tx, eventID, userID)
+			tx.Rollback()
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unfulfilled expectations: %v", err)
+			}
+			if tt.expectedError != nil {
+				if !errors.Is(err, tt.expectedError) {
+					t.Errorf("expected error %v, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if reg.Status != model.RegistrationConfirmed {
+				t.Errorf("expected status confirmed, got %s", reg.Status)
+			}
+			if reg.OfferExpiresAt != nil {
+				t.Error("expected offer expiry to be cleared")
+			}
+		})
+	}
+}
+
+func TestExpireOffer_CascadesToNextWaitlisted(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRegistrationRepository(db)
+	eventID := uuid.New()
+	regID := uuid.New()
+	nextID := uuid.New()
+	now := time.Now()
+
+	mock.ExpectBegin()
+
+	// Lock the event before the expired offer
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT event_id FROM registrations WHERE id = $1`)).
+		WithArgs(regID).
+		WillReturnRows(sqlmock.NewRows([]string{"event_id"}).AddRow(eventID))
+	expectEventLock(mock, eventID)
+
+	// Lock the expired offer
+	regRows := sqlmock.NewRows([]string{
+		"id", "event_id", "user_id", "status", "waitlist_position",
+		"registered_at", "confirmed_at", "cancelled_at", "offer_expires_at",
+	}).AddRow(
+		regID, eventID, uuid.New(), model.RegistrationOffered, nil,
+		now.Add(-3*time.Hour), nil, nil, now.Add(-time.Minute),
+	)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE id = $1 FOR UPDATE`)).
+		WithArgs(regID).
+		WillReturnRows(regRows)
+
+	// Release the offer
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations SET status = 'cancelled', cancelled_at = NOW(), offer_expires_at = NULL WHERE id = $1`)).
+		WithArgs(regID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Offer to the next waitlisted user
+	waitlistRows := sqlmock.NewRows([]string{
+		"id", "event_id", "user_id", "status", "waitlist_position",
+		"registered_at", "confirmed_at", "cancelled_at",
+	}).AddRow(
+		nextID, eventID, uuid.New(), model.RegistrationWaitlist, 1,
+		now.Add(-time.Hour), nil, nil,
+	)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations`)).
+		WithArgs(eventID).
+		WillReturnRows(waitlistRows)
+	mock.ExpectQuery(regexp.QuoteMeta(`SET status = 'offered'`)).
+		WithArgs(nextID, int64(1800)).
+		WillReturnRows(sqlmock.NewRows([]string{"offer_expires_at"}).AddRow(now.Add(30 * time.Minute)))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations`)).
+		WithArgs(eventID, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations`)).
+		WithArgs(eventID).
+		WillReturnError(sql.ErrNoRows)
+
+	// Recalculate open/full in the same transaction
+	mock.ExpectExec(regexp.QuoteMeta(`WITH held AS`)).
+		WithArgs(eventID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mock.ExpectCommit()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+
+	next, err := repo.ExpireOffer(context.Background(), tx, regID, 30*time.Minute)
+	if err != nil {
+		tx.Rollback()
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tx.Commit()
+
+	if len(next) != 1 || next[0].ID != nextID {
+		t.Fatalf("expected offer to cascade to %s, got %v", nextID, next)
+	}
+	if next[0].Status != model.RegistrationOffered {
+		t.Errorf("expected status offered, got %s", next[0].Status)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestExpireOffer_CompletedEventOffersNoOne(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRegistrationRepository(db)
+	eventID, regID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT event_id FROM registrations WHERE id = $1`)).
+		WithArgs(regID).
+		WillReturnRows(sqlmock.NewRows([]string{"event_id"}).AddRow(eventID))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status FROM events WHERE id = $1 FOR UPDATE`)).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(4, "completed"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE id = $1 FOR UPDATE`)).
+		WithArgs(regID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status", "offer_expires_at"}).
+			AddRow(regID, eventID, uuid.New(), model.RegistrationOffered, now.Add(-time.Minute)))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations SET status = 'cancelled'`)).
+		WithArgs(regID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// The game has been played, so nobody on the waitlist is offered the seat
+	mock.ExpectCommit()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	next, err := repo.ExpireOffer(context.Background(), tx, regID, 30*time.Minute)
+	if err != nil {
+		tx.Rollback()
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tx.Commit()
+
+	if len(next) != 0 {
+		t.Errorf("expected no new offers, got %v", next)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDeclineOffer_LocksEventBeforeRegistration(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRegistrationRepository(db)
+	eventID, userID := uuid.New(), uuid.New()
+
+	// The freed seat is counted under the event lock, so a concurrent
+	// registration cannot take it as well
+	mock.ExpectBegin()
+	expectEventLock(mock, eventID)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE event_id = $1 AND user_id = $2 FOR UPDATE`)).
+		WithArgs(eventID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status"}).
+			AddRow(uuid.New(), eventID, userID, model.RegistrationConfirmed))
+	mock.ExpectRollback()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	if _, err := repo.DeclineOffer(context.Background(), tx, eventID, userID, 30*time.Minute); err != ErrNoOffer {
+		t.Errorf("expected ErrNoOffer, got %v", err)
+	}
+	tx.Rollback()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+// =============================================================================
+// ResizeTx Tests
+// =============================================================================
+
+func TestResizeTx(t *testing.T) {
+	regCols := []string{
+		"id", "event_id", "user_id", "status", "waitlist_position",
+		"registered_at", "confirmed_at", "cancelled_at", "offer_expires_at",
+	}
+
+	t.Run("raising capacity promotes waitlisted users in order", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewRegistrationRepository(db)
+		eventID := uuid.New()
+		firstID, secondID := uuid.New(), uuid.New()
+		now := time.Now()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations WHERE event_id = $1 AND status IN ('confirmed', 'offered')`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(8))
+		for _, id := range []uuid.UUID{firstID, secondID} {
+			mock.ExpectQuery(regexp.QuoteMeta(`WHERE event_id = $1 AND status = 'waitlist'`)).
+				WithArgs(eventID).
+				WillReturnRows(sqlmock.NewRows(regCols).
+					AddRow(id, eventID, uuid.New(), model.RegistrationWaitlist, 1, now, nil, nil, nil))
+			mock.ExpectQuery(regexp.QuoteMeta(`SET status = 'confirmed'`)).
+				WithArgs(id).
+				WillReturnRows(sqlmock.NewRows([]string{"confirmed_at"}).AddRow(now))
+			mock.ExpectExec(regexp.QuoteMeta(`SET waitlist_position = waitlist_position - 1`)).
+				WithArgs(eventID, 1).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE event_id = $1 AND status = 'waitlist'`)).
+			WithArgs(eventID).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectCommit()
+
+		tx, err := db.Beginx()
+		if err != nil {
+			t.Fatalf("failed to begin transaction: %v", err)
+		}
+		change, err := repo.ResizeTx(context.Background(), tx, eventID, 10, model.CapacityPolicyRefuse)
+		if err != nil {
+			tx.Rollback()
+			t.Fatalf("unexpected error: %v", err)
+		}
+		tx.Commit()
+
+		if len(change.Promoted) != 2 || change.Promoted[0].ID != firstID || change.Promoted[1].ID != secondID {
+			t.Fatalf("expected both waitlisted users promoted in order, got %+v", change.Promoted)
+		}
+		if change.Promoted[0].Status != model.RegistrationConfirmed {
+			t.Errorf("expected status confirmed, got %s", change.Promoted[0].Status)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("lowering capacity is refused by default", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewRegistrationRepository(db)
+		eventID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(8))
+		mock.ExpectRollback()
+
+		tx, err := db.Beginx()
+		if err != nil {
+			t.Fatalf("failed to begin transaction: %v", err)
+		}
+		_, err = repo.ResizeTx(context.Background(), tx, eventID, 6, model.CapacityPolicyRefuse)
+		tx.Rollback()
+
+		if !errors.Is(err, ErrCapacityBelowHeld) {
+			t.Errorf("expected ErrCapacityBelowHeld, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("lowering capacity with demote moves latest confirmed to head of waitlist", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewRegistrationRepository(db)
+		eventID := uuid.New()
+		latestID, earlierID := uuid.New(), uuid.New()
+		now := time.Now()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(8))
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE event_id = $1 AND status IN ('confirmed', 'offered')`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows(regCols).
+				AddRow(latestID, eventID, uuid.New(), model.RegistrationConfirmed, nil, now, now, nil, nil).
+				AddRow(earlierID, eventID, uuid.New(), model.RegistrationConfirmed, nil, now, now.Add(-time.Hour), nil, nil).
+				AddRow(uuid.New(), eventID, uuid.New(), model.RegistrationConfirmed, nil, now, now.Add(-2*time.Hour), nil, nil))
+		mock.ExpectExec(regexp.QuoteMeta(`SET waitlist_position = waitlist_position + $2`)).
+			WithArgs(eventID, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`SET status = 'waitlist'`)).
+			WithArgs(latestID, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`SET status = 'waitlist'`)).
+			WithArgs(earlierID, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		tx, err := db.Beginx()
+		if err != nil {
+			t.Fatalf("failed to begin transaction: %v", err)
+		}
+		change, err := repo.ResizeTx(context.Background(), tx, eventID, 6, model.CapacityPolicyDemote)
+		if err != nil {
+			tx.Rollback()
+			t.Fatalf("unexpected error: %v", err)
+		}
+		tx.Commit()
+
+		if len(change.Demoted) != 2 {
+			t.Fatalf("expected 2 demoted registrations, got %d", len(change.Demoted))
+		}
+		if *change.Demoted[1].WaitlistPosition != 1 {
+			t.Errorf("expected earliest confirmed user first in line, got position %d", *change.Demoted[1].WaitlistPosition)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("demoting a player moves their guests with them", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewRegistrationRepository(db)
+		eventID := uuid.New()
+		partyID := uuid.New()
+		now := time.Now()
+		cols := append(regCols, "guest_count")
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(8))
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE event_id = $1 AND status IN ('confirmed', 'offered')`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows(cols).
+				AddRow(partyID, eventID, uuid.New(), model.RegistrationConfirmed, nil, now, now, nil, nil, 2).
+				AddRow(uuid.New(), eventID, uuid.New(), model.RegistrationConfirmed, nil, now, now.Add(-time.Hour), nil, nil, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`SET waitlist_position = waitlist_position + $2`)).
+			WithArgs(eventID, 1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`SET status = 'waitlist'`)).
+			WithArgs(partyID, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		tx, err := db.Beginx()
+		if err != nil {
+			t.Fatalf("failed to begin transaction: %v", err)
+		}
+		change, err := repo.ResizeTx(context.Background(), tx, eventID, 6, model.CapacityPolicyDemote)
+		if err != nil {
+			tx.Rollback()
+			t.Fatalf("unexpected error: %v", err)
+		}
+		tx.Commit()
+
+		if len(change.Demoted) != 1 || change.Demoted[0].ID != partyID {
+			t.Fatalf("expected only the party of three demoted, got %+v", change.Demoted)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
 // =============================================================================
 // GetRegistrationStats Tests
 // =============================================================================
@@ -1158,6 +1718,116 @@ func TestCancelAllByEventID(t *testing.T) {
 	}
 }
 
+func TestCancelAllByEventIDTx(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRegistrationRepository(db)
+	eventID := uuid.New()
+	confirmedUser, waitlistedUser := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`RETURNING user_id`)).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(confirmedUser).AddRow(waitlistedUser))
+	mock.ExpectCommit()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	userIDs, err := repo.CancelAllByEventIDTx(context.Background(), tx, eventID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	if len(userIDs) != 2 || userIDs[0] != confirmedUser || userIDs[1] != waitlistedUser {
+		t.Errorf("expected cancelled user IDs, got %v", userIDs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+// =============================================================================
+// Attendance Tests
+// =============================================================================
+
+func TestSetAttendanceTx(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRegistrationRepository(db)
+	eventID, regID := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations SET attendance = $3`)).
+		WithArgs(eventID, regID, model.AttendanceNoShow).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	defer tx.Rollback()
+
+	// Waitlisted, cancelled or other events' registrations are not updated
+	err = repo.SetAttendanceTx(context.Background(), tx, eventID, regID, model.AttendanceNoShow)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestFindReliability(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRegistrationRepository(db)
+	reliable, flaky, newcomer := uuid.New(), uuid.New(), uuid.New()
+	policy := model.ReliabilityPolicy{Window: 90 * 24 * time.Hour}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT r.user_id`)).
+		WithArgs(sqlmock.AnyArg(), "Asia/Taipei", int64(90*24*60*60)).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "attended", "late_cancelled", "no_shows"}).
+			AddRow(reliable, 9, 1, 0).
+			AddRow(flaky, 1, 1, 2))
+
+	results, err := repo.FindReliability(context.Background(), []uuid.UUID{reliable, flaky, newcomer}, policy, "Asia/Taipei")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := results[reliable]; got != (model.Reliability{Attended: 9, LateCancelled: 1}) {
+		t.Errorf("unexpected reliability for reliable player: %+v", got)
+	}
+	if got := results[flaky].Score(); got == nil || *got != 25 {
+		t.Errorf("expected flaky player score 25, got %v", got)
+	}
+	if _, ok := results[newcomer]; ok {
+		t.Error("expected no entry for a player without history")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestFindReliability_NoUsers(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	results, err := NewRegistrationRepository(db).FindReliability(context.Background(), nil, model.ReliabilityPolicy{}, "UTC")
+	if err != nil || len(results) != 0 {
+		t.Errorf("expected no results without a query, got %v, %v", results, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
 // =============================================================================
 // Delete Tests
 // =============================================================================
@@ -1229,3 +1899,816 @@ func TestDelete(t *testing.T) {
 func intPtr(i int) *int {
 	return &i
 }
+
+// =============================================================================
+// Late Cancellation Tests
+// =============================================================================
+
+func TestFindLateCancellations(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRegistrationRepository(db)
+	eventID, regID, userID := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE r.event_id = $1 AND r.status = 'cancelled' AND r.late_cancelled`)).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "event_id", "user_id", "status", "registered_at", "confirmed_at", "cancelled_at",
+			"user.id", "user.display_name", "user.avatar_url",
+		}).AddRow(regID, eventID, userID, model.RegistrationCancelled, now, now, now, userID, "Player", nil))
+
+	results, err := repo.FindLateCancellations(context.Background(), eventID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 late cancellation, got %d", len(results))
+	}
+	if !results[0].LateCancelled || results[0].User.DisplayName != "Player" {
+		t.Errorf("unexpected result: %+v", results[0])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+// =============================================================================
+// Spot Transfer Tests
+// =============================================================================
+
+func TestTransferWithLock(t *testing.T) {
+	eventID, transferID, regID := uuid.New(), uuid.New(), uuid.New()
+	hostID, fromUserID, toUserID := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+
+	transferColumns := []string{
+		"id", "event_id", "registration_id", "from_user_id", "to_user_id", "token_hash", "status",
+		"waitlist_bypassed", "expires_at", "created_at", "accepted_at", "resolved_at",
+	}
+	registrationColumns := []string{"id", "event_id", "user_id", "status", "waitlist_position", "registered_at"}
+
+	tests := []struct {
+		name       string
+		fromStatus model.RegistrationStatus
+		recipient  model.RegistrationStatus
+		wantErr    error
+	}{
+		{name: "hands the spot to a new player"},
+		{name: "moves a waitlisted recipient ahead", recipient: model.RegistrationWaitlist},
+		{name: "sender no longer confirmed", fromStatus: model.RegistrationCancelled, wantErr: ErrTransferUnavailable},
+		{name: "recipient awaiting approval", recipient: model.RegistrationPending, wantErr: ErrTransferUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setupMockDB(t)
+			defer db.Close()
+
+			repo := NewRegistrationRepository(db)
+			fromStatus := tt.fromStatus
+			if fromStatus == "" {
+				fromStatus = model.RegistrationConfirmed
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT status, host_id FROM events WHERE id = $1 FOR UPDATE`)).
+				WithArgs(eventID).
+				WillReturnRows(sqlmock.NewRows([]string{"status", "host_id"}).AddRow("full", hostID))
+			expectNotBlocked(mock)
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM spot_transfers WHERE id = $1 AND event_id = $2 FOR UPDATE`)).
+				WithArgs(transferID, eventID).
+				WillReturnRows(sqlmock.NewRows(transferColumns).AddRow(
+					transferID, eventID, regID, fromUserID, nil, "hash", "pending", 0, now.Add(time.Hour), now, nil, nil))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE id = $1 FOR UPDATE`)).
+				WithArgs(regID).
+				WillReturnRows(sqlmock.NewRows(registrationColumns).AddRow(regID, eventID, fromUserID, fromStatus, nil, now))
+
+			waitlisted := tt.recipient == model.RegistrationWaitlist
+			if fromStatus == model.RegistrationConfirmed {
+				existing := mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE event_id = $1 AND user_id = $2 FOR UPDATE`)).
+					WithArgs(eventID, toUserID)
+				switch {
+				case waitlisted:
+					existing.WillReturnRows(sqlmock.NewRows(registrationColumns).AddRow(uuid.New(), eventID, toUserID, "waitlist", 3, now))
+				case tt.recipient != "":
+					existing.WillReturnRows(sqlmock.NewRows(registrationColumns).AddRow(uuid.New(), eventID, toUserID, tt.recipient, nil, now))
+				default:
+					existing.WillReturnError(sql.ErrNoRows)
+				}
+			}
+
+			if tt.wantErr == nil {
+				if !waitlisted {
+					mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM registrations WHERE event_id = $1 AND status = 'waitlist'`)).
+						WithArgs(eventID).
+						WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+				}
+				mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations SET status = 'cancelled', cancelled_at = NOW() WHERE id = $1`)).
+					WithArgs(regID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				if waitlisted {
+					mock.ExpectQuery(regexp.QuoteMeta(`UPDATE registrations`)).
+						WillReturnRows(sqlmock.NewRows([]string{"registered_at", "confirmed_at"}).AddRow(now, now))
+					mock.ExpectExec(regexp.QuoteMeta(`SET waitlist_position = waitlist_position - 1`)).
+						WithArgs(eventID, 3).
+						WillReturnResult(sqlmock.NewResult(0, 2))
+				} else {
+					mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO registrations (id, event_id, user_id, status, registered_at, confirmed_at)`)).
+						WillReturnRows(sqlmock.NewRows([]string{"registered_at", "confirmed_at"}).AddRow(now, now))
+				}
+				wantBypassed := 4
+				if waitlisted {
+					wantBypassed = 2
+				}
+				mock.ExpectQuery(regexp.QuoteMeta(`UPDATE spot_transfers`)).
+					WithArgs(transferID, toUserID, wantBypassed).
+					WillReturnRows(sqlmock.NewRows(transferColumns).AddRow(
+						transferID, eventID, regID, fromUserID, toUserID, "hash", "completed", wantBypassed, now.Add(time.Hour), now, now, now))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			tx, err := db.Beginx()
+			if err != nil {
+				t.Fatalf("failed to begin: %v", err)
+			}
+
+			reg, transfer, err := repo.TransferWithLock(context.Background(), tx, eventID, transferID, toUserID, model.TransferPending)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				tx.Rollback()
+			} else {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				tx.Commit()
+				if reg.UserID != toUserID || reg.Status != model.RegistrationConfirmed {
+					t.Errorf("expected a confirmed registration for the recipient, got %+v", reg)
+				}
+				if transfer.Status != model.TransferCompleted {
+					t.Errorf("expected completed transfer, got %s", transfer.Status)
+				}
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestRegisterWithLock_PartyWaitlistedWhenGuestsDoNotFit(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRegistrationRepository(db)
+	eventID, userID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status, host_id FROM events WHERE id = $1 FOR UPDATE`)).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "status", "host_id"}).AddRow(4, "open", uuid.New()))
+	expectNotBlocked(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE event_id = $1 AND user_id = $2`)).
+		WithArgs(eventID, userID).
+		WillReturnError(sql.ErrNoRows)
+	// One seat is left, but the player brings two guests
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations`)).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT MAX(waitlist_position) FROM registrations`)).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(nil))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO registrations (id, event_id, user_id, status, waitlist_position, registered_at)`)).
+		WithArgs(sqlmock.AnyArg(), eventID, userID, model.RegistrationWaitlist, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"registered_at"}).AddRow(now))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE registration_guests SET cancelled_at = NOW()`)).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	for _, name := range []string{"Amy", "Ben"} {
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO registration_guests`)).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), name).
+			WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(now))
+	}
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations SET guest_count = $2 WHERE id = $1`)).
+		WithArgs(sqlmock.AnyArg(), 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`WITH held AS`)).
+		WithArgs(eventID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	reg, err := repo.RegisterWithLock(context.Background(), tx, eventID, userID, []string{"Amy", "Ben"})
+	if err != nil {
+		tx.Rollback()
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tx.Commit()
+
+	if reg.Status != model.RegistrationWaitlist {
+		t.Errorf("expected the whole party waitlisted, got %s", reg.Status)
+	}
+	if reg.GuestCount != 2 || len(reg.Guests) != 2 {
+		t.Errorf("expected 2 guests, got count %d and %d names", reg.GuestCount, len(reg.Guests))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCancelGuestTx(t *testing.T) {
+	regCols := []string{"id", "event_id", "user_id", "status", "guest_count"}
+
+	t.Run("frees the seat for the next waitlisted player", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewRegistrationRepository(db)
+		eventID, userID, guestID := uuid.New(), uuid.New(), uuid.New()
+		regID, nextID := uuid.New(), uuid.New()
+
+		mock.ExpectBegin()
+		expectEventLock(mock, eventID)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE event_id = $1 AND user_id = $2 FOR UPDATE`)).
+			WithArgs(eventID, userID).
+			WillReturnRows(sqlmock.NewRows(regCols).AddRow(regID, eventID, userID, model.RegistrationConfirmed, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE registration_guests SET cancelled_at = NOW()`)).
+			WithArgs(guestID, regID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`SET guest_count = guest_count - 1`)).
+			WithArgs(regID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE event_id = $1 AND status = 'waitlist'`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows(append(regCols, "waitlist_position")).
+				AddRow(nextID, eventID, uuid.New(), model.RegistrationWaitlist, 0, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SET status = 'confirmed'`)).
+			WithArgs(nextID).
+			WillReturnRows(sqlmock.NewRows([]string{"confirmed_at"}).AddRow(time.Now()))
+		mock.ExpectExec(regexp.QuoteMeta(`SET waitlist_position = waitlist_position - 1`)).
+			WithArgs(eventID, 1).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE event_id = $1 AND status = 'waitlist'`)).
+			WithArgs(eventID).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectExec(regexp.QuoteMeta(`WITH held AS`)).
+			WithArgs(eventID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		tx, err := db.Beginx()
+		if err != nil {
+			t.Fatalf("failed to begin transaction: %v", err)
+		}
+		promoted, err := repo.CancelGuestTx(context.Background(), tx, eventID, userID, guestID, 0)
+		if err != nil {
+			tx.Rollback()
+			t.Fatalf("unexpected error: %v", err)
+		}
+		tx.Commit()
+
+		if len(promoted) != 1 || promoted[0].ID != nextID || promoted[0].Status != model.RegistrationConfirmed {
+			t.Errorf("expected the next waitlisted player confirmed, got %+v", promoted)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("guest not on the registration", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewRegistrationRepository(db)
+		eventID, userID, guestID, regID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+		mock.ExpectBegin()
+		expectEventLock(mock, eventID)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE event_id = $1 AND user_id = $2 FOR UPDATE`)).
+			WithArgs(eventID, userID).
+			WillReturnRows(sqlmock.NewRows(regCols).AddRow(regID, eventID, userID, model.RegistrationConfirmed, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE registration_guests SET cancelled_at = NOW()`)).
+			WithArgs(guestID, regID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		tx, err := db.Beginx()
+		if err != nil {
+			t.Fatalf("failed to begin transaction: %v", err)
+		}
+		_, err = repo.CancelGuestTx(context.Background(), tx, eventID, userID, guestID, 0)
+		tx.Rollback()
+
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("expected sql.ErrNoRows, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestRegisterWithLock_BlockedByHost(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRegistrationRepository(db)
+	eventID, userID, hostID := uuid.New(), uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status, host_id FROM events WHERE id = $1 FOR UPDATE`)).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "status", "host_id"}).AddRow(4, "open", hostID))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT EXISTS(SELECT 1 FROM host_blocks WHERE host_id = $1 AND user_id = ANY($2))`)).
+		WithArgs(hostID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	_, err = repo.RegisterWithLock(context.Background(), tx, eventID, userID, nil)
+	tx.Rollback()
+
+	if !errors.Is(err, ErrBlocked) {
+		t.Errorf("expected ErrBlocked, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRegisterGroupWithLock(t *testing.T) {
+	t.Run("whole group joins the waitlist when it does not fit", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewRegistrationRepository(db)
+		eventID, organizerID, partnerID := uuid.New(), uuid.New(), uuid.New()
+		userIDs := []uuid.UUID{organizerID, partnerID}
+		now := time.Now()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status, host_id FROM events WHERE id = $1 FOR UPDATE`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"capacity", "status", "host_id"}).AddRow(4, "open", uuid.New()))
+		expectNotBlocked(mock)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM users WHERE id = ANY($1)`)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE event_id = $1 AND user_id = ANY($2) FOR UPDATE`)).
+			WithArgs(eventID, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		// Only one seat is left for two players
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT MAX(waitlist_position) FROM registrations`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(2))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO registration_groups`)).
+			WithArgs(sqlmock.AnyArg(), eventID, organizerID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		for _, userID := range userIDs {
+			mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO registrations`)).
+				WithArgs(sqlmock.AnyArg(), eventID, userID, model.RegistrationWaitlist, 3, sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows([]string{"registered_at", "confirmed_at"}).AddRow(now, nil))
+		}
+		mock.ExpectExec(regexp.QuoteMeta(`WITH held AS`)).
+			WithArgs(eventID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		tx, err := db.Beginx()
+		if err != nil {
+			t.Fatalf("failed to begin transaction: %v", err)
+		}
+		regs, err := repo.RegisterGroupWithLock(context.Background(), tx, eventID, organizerID, userIDs)
+		if err != nil {
+			tx.Rollback()
+			t.Fatalf("unexpected error: %v", err)
+		}
+		tx.Commit()
+
+		if len(regs) != 2 {
+			t.Fatalf("expected 2 registrations, got %d", len(regs))
+		}
+		for _, reg := range regs {
+			if reg.Status != model.RegistrationWaitlist || *reg.WaitlistPosition != 3 {
+				t.Errorf("expected every member at waitlist position 3, got %s at %v", reg.Status, reg.WaitlistPosition)
+			}
+			if reg.GroupID == nil || *reg.GroupID != *regs[0].GroupID {
+				t.Errorf("expected members to share a group")
+			}
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("refused when a member is already registered", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewRegistrationRepository(db)
+		eventID, organizerID, partnerID := uuid.New(), uuid.New(), uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status, host_id FROM events WHERE id = $1 FOR UPDATE`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"capacity", "status", "host_id"}).AddRow(8, "open", uuid.New()))
+		expectNotBlocked(mock)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM users WHERE id = ANY($1)`)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE event_id = $1 AND user_id = ANY($2) FOR UPDATE`)).
+			WithArgs(eventID, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status"}).
+				AddRow(uuid.New(), eventID, partnerID, model.RegistrationConfirmed))
+		mock.ExpectRollback()
+
+		tx, err := db.Beginx()
+		if err != nil {
+			t.Fatalf("failed to begin transaction: %v", err)
+		}
+		_, err = repo.RegisterGroupWithLock(context.Background(), tx, eventID, organizerID, []uuid.UUID{organizerID, partnerID})
+		tx.Rollback()
+
+		if !errors.Is(err, ErrAlreadyRegistered) {
+			t.Errorf("expected ErrAlreadyRegistered, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestCancelAndPromote_LocksEventBeforeRegistration(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRegistrationRepository(db)
+	eventID, regID := uuid.New(), uuid.New()
+
+	// The event row is locked first, in the same order as RegisterWithLock, so a
+	// cancellation cannot interleave with a registration or capacity change
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status FROM events WHERE id = $1 FOR UPDATE`)).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(4, "full"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE id = $1 FOR UPDATE`)).
+		WithArgs(regID).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	if _, err := repo.CancelAndPromote(context.Background(), tx, regID, eventID, 0); err != sql.ErrNoRows {
+		t.Errorf("expected sql.ErrNoRows, got %v", err)
+	}
+	tx.Rollback()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestCancelAndPromote_PromotesGroupTogether(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRegistrationRepository(db)
+	eventID, regID, groupID := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+	cols := []string{"id", "event_id", "user_id", "status", "waitlist_position", "group_id"}
+
+	mock.ExpectBegin()
+	expectEventLock(mock, eventID)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE id = $1 FOR UPDATE`)).
+		WithArgs(regID).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(regID, eventID, uuid.New(), model.RegistrationConfirmed, nil, nil))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations SET status = 'cancelled'`)).
+		WithArgs(regID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE event_id = $1 AND status = 'waitlist'`)).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(uuid.New(), eventID, uuid.New(), model.RegistrationWaitlist, 1, groupID))
+	// The group is confirmed together even with an offer window
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE group_id = $1 AND status = 'waitlist'`)).
+		WithArgs(groupID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status", "confirmed_at", "group_id"}).
+			AddRow(uuid.New(), eventID, uuid.New(), model.RegistrationConfirmed, now, groupID).
+			AddRow(uuid.New(), eventID, uuid.New(), model.RegistrationConfirmed, now, groupID))
+	mock.ExpectExec(regexp.QuoteMeta(`SET waitlist_position = waitlist_position - 1`)).
+		WithArgs(eventID, 1).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE event_id = $1 AND status = 'waitlist'`)).
+		WithArgs(eventID).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectExec(regexp.QuoteMeta(`WITH held AS`)).
+		WithArgs(eventID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	promoted, err := repo.CancelAndPromote(context.Background(), tx, regID, eventID, 2*time.Hour)
+	if err != nil {
+		tx.Rollback()
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tx.Commit()
+
+	if len(promoted) != 2 {
+		t.Fatalf("expected both group members promoted, got %d", len(promoted))
+	}
+	for _, reg := range promoted {
+		if reg.Status != model.RegistrationConfirmed {
+			t.Errorf("expected confirmed, got %s", reg.Status)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestApproveWithLock(t *testing.T) {
+	t.Run("waitlists the party when its seats are taken", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewRegistrationRepository(db)
+		eventID, regID := uuid.New(), uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status FROM events WHERE id = $1 FOR UPDATE`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(4, "open"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE id = $1 AND event_id = $2 FOR UPDATE`)).
+			WithArgs(regID, eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status", "guest_count"}).
+				AddRow(regID, eventID, uuid.New(), model.RegistrationPending, 1))
+		// One seat is left for a player and their guest
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT MAX(waitlist_position) FROM registrations`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(nil))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations SET status = 'waitlist', waitlist_position = $2 WHERE id = $1`)).
+			WithArgs(regID, 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`WITH held AS`)).
+			WithArgs(eventID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		tx, err := db.Beginx()
+		if err != nil {
+			t.Fatalf("failed to begin transaction: %v", err)
+		}
+		reg, err := repo.ApproveWithLock(context.Background(), tx, eventID, regID)
+		if err != nil {
+			tx.Rollback()
+			t.Fatalf("unexpected error: %v", err)
+		}
+		tx.Commit()
+
+		if reg.Status != model.RegistrationWaitlist || *reg.WaitlistPosition != 1 {
+			t.Errorf("expected waitlist position 1, got %s at %v", reg.Status, reg.WaitlistPosition)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("refused when the request was already handled", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewRegistrationRepository(db)
+		eventID, regID := uuid.New(), uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status FROM events WHERE id = $1 FOR UPDATE`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(4, "open"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE id = $1 AND event_id = $2 FOR UPDATE`)).
+			WithArgs(regID, eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status"}).
+				AddRow(regID, eventID, uuid.New(), model.RegistrationRejected))
+		mock.ExpectRollback()
+
+		tx, err := db.Beginx()
+		if err != nil {
+			t.Fatalf("failed to begin transaction: %v", err)
+		}
+		_, err = repo.ApproveWithLock(context.Background(), tx, eventID, regID)
+		tx.Rollback()
+
+		if !errors.Is(err, ErrNotPending) {
+			t.Errorf("expected ErrNotPending, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestRemoveWithLock_PromotesNextWaitlisted(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRegistrationRepository(db)
+	eventID, regID, waitlistID := uuid.New(), uuid.New(), uuid.New()
+	cols := []string{"id", "event_id", "user_id", "status", "waitlist_position"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status FROM events WHERE id = $1 FOR UPDATE`)).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(4, "full"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE id = $1 AND event_id = $2 FOR UPDATE`)).
+		WithArgs(regID, eventID).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(regID, eventID, uuid.New(), model.RegistrationConfirmed, nil))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE registrations SET status = 'cancelled'`)).
+		WithArgs(regID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE event_id = $1 AND status = 'waitlist'`)).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(waitlistID, eventID, uuid.New(), model.RegistrationWaitlist, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SET status = 'confirmed', confirmed_at = NOW(), waitlist_position = NULL`)).
+		WithArgs(waitlistID).
+		WillReturnRows(sqlmock.NewRows([]string{"confirmed_at"}).AddRow(time.Now()))
+	mock.ExpectExec(regexp.QuoteMeta(`SET waitlist_position = waitlist_position - 1`)).
+		WithArgs(eventID, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE event_id = $1 AND status = 'waitlist'`)).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows(cols))
+	mock.ExpectExec(regexp.QuoteMeta(`WITH held AS`)).
+		WithArgs(eventID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	removed, promoted, err := repo.RemoveWithLock(context.Background(), tx, eventID, regID, 0)
+	if err != nil {
+		tx.Rollback()
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tx.Commit()
+
+	if removed.ID != regID {
+		t.Errorf("expected the removed registration, got %v", removed.ID)
+	}
+	if len(promoted) != 1 || promoted[0].ID != waitlistID {
+		t.Errorf("expected the first waitlisted player to be promoted, got %v", promoted)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPromoteWithLock(t *testing.T) {
+	t.Run("confirms the player and closes the waitlist gap", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewRegistrationRepository(db)
+		eventID, regID := uuid.New(), uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status FROM events WHERE id = $1 FOR UPDATE`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(4, "open"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE id = $1 AND event_id = $2 FOR UPDATE`)).
+			WithArgs(regID, eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status", "waitlist_position"}).
+				AddRow(regID, eventID, uuid.New(), model.RegistrationWaitlist, 3))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery(regexp.QuoteMeta(`SET status = 'confirmed', confirmed_at = NOW(), waitlist_position = NULL`)).
+			WithArgs(regID).
+			WillReturnRows(sqlmock.NewRows([]string{"confirmed_at"}).AddRow(time.Now()))
+		mock.ExpectExec(regexp.QuoteMeta(`SET waitlist_position = waitlist_position - 1`)).
+			WithArgs(eventID, 3).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(regexp.QuoteMeta(`WITH held AS`)).
+			WithArgs(eventID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		tx, err := db.Beginx()
+		if err != nil {
+			t.Fatalf("failed to begin transaction: %v", err)
+		}
+		promoted, err := repo.PromoteWithLock(context.Background(), tx, eventID, regID)
+		if err != nil {
+			tx.Rollback()
+			t.Fatalf("unexpected error: %v", err)
+		}
+		tx.Commit()
+
+		if len(promoted) != 1 || promoted[0].Status != model.RegistrationConfirmed || promoted[0].WaitlistPosition != nil {
+			t.Errorf("expected one confirmed registration, got %+v", promoted)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("refused when the party does not fit", func(t *testing.T) {
+		db, mock := setupMockDB(t)
+		defer db.Close()
+
+		repo := NewRegistrationRepository(db)
+		eventID, regID := uuid.New(), uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status FROM events WHERE id = $1 FOR UPDATE`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(4, "open"))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE id = $1 AND event_id = $2 FOR UPDATE`)).
+			WithArgs(regID, eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status", "waitlist_position", "guest_count"}).
+				AddRow(regID, eventID, uuid.New(), model.RegistrationWaitlist, 1, 1))
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(SUM(1 + guest_count), 0) FROM registrations`)).
+			WithArgs(eventID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectRollback()
+
+		tx, err := db.Beginx()
+		if err != nil {
+			t.Fatalf("failed to begin transaction: %v", err)
+		}
+		_, err = repo.PromoteWithLock(context.Background(), tx, eventID, regID)
+		tx.Rollback()
+
+		if !errors.Is(err, ErrNotEnoughSeats) {
+			t.Errorf("expected ErrNotEnoughSeats, got %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
+
+func TestMoveWaitlistWithLock(t *testing.T) {
+	db, mock := setupMockDB(t)
+	defer db.Close()
+
+	repo := NewRegistrationRepository(db)
+	eventID, regID := uuid.New(), uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status FROM events WHERE id = $1 FOR UPDATE`)).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(4, "full"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM registrations WHERE id = $1 AND event_id = $2 FOR UPDATE`)).
+		WithArgs(regID, eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "user_id", "status", "waitlist_position"}).
+			AddRow(regID, eventID, uuid.New(), model.RegistrationWaitlist, 4))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(waitlist_position), 0) FROM registrations`)).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(5))
+	// Moving up from 4 to 2 pushes positions 2 and 3 back one place
+	mock.ExpectExec(regexp.QuoteMeta(`SET waitlist_position = CASE WHEN waitlist_position = $2 THEN $3 ELSE waitlist_position + $4 END`)).
+		WithArgs(eventID, 4, 2, 1, 2, 3).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatalf("failed to begin transaction: %v", err)
+	}
+	position, err := repo.MoveWaitlistWithLock(context.Background(), tx, eventID, regID, 2)
+	if err != nil {
+		tx.Rollback()
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tx.Commit()
+
+	if position != 2 {
+		t.Errorf("expected position 2, got %d", position)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
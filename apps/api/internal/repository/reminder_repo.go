@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// ReminderRepository handles event reminder data access
+type ReminderRepository struct {
+	db *sqlx.DB
+}
+
+// NewReminderRepository creates a new ReminderRepository
+func NewReminderRepository(db *sqlx.DB) *ReminderRepository {
+	return &ReminderRepository{db: db}
+}
+
+// FindDue finds registrations with the given status for open or full events
+// starting in (from, to] that have not been sent the reminder kind yet.
+// Event dates and times are wall-clock times in the given time zone.
+func (r *ReminderRepository) FindDue(ctx context.Context, status model.RegistrationStatus, kind, timezone string, from, to time.Time, limit int) ([]model.DueReminder, error) {
+	var due []model.DueReminder
+	query := `
+		SELECT e.id, e.title, e.event_date, e.start_time, e.location_name,
+			   r.user_id, r.status AS registration_status, r.waitlist_position
+		FROM registrations r
+		JOIN events e ON e.id = r.event_id
+		WHERE r.status = $1
+		AND e.status IN ('open', 'full')
+		AND (e.event_date + e.start_time) AT TIME ZONE $3 > $4
+		AND (e.event_date + e.start_time) AT TIME ZONE $3 <= $5
+		AND NOT EXISTS (
+			SELECT 1 FROM event_reminders er
+			WHERE er.event_id = r.event_id AND er.user_id = r.user_id AND er.kind = $2
+		)
+		ORDER BY e.event_date, e.start_time
+		LIMIT $6`
+	err := r.db.SelectContext(ctx, &due, query, status, kind, timezone, from, to, limit)
+	return due, err
+}
+
+// MarkSentTx claims a reminder for a user within a transaction.
+// Returns false if the reminder was already sent.
+func (r *ReminderRepository) MarkSentTx(ctx context.Context, tx *sqlx.Tx, eventID, userID uuid.UUID, kind string) (bool, error) {
+	query := `
+		INSERT INTO event_reminders (id, event_id, user_id, kind)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id, user_id, kind) DO NOTHING`
+	result, err := tx.ExecContext(ctx, query, uuid.New(), eventID, userID, kind)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/google/uuid"
+)
+
+func TestReminderFindDue(t *testing.T) {
+	db, mock := newMockDB(t)
+	defer db.Close()
+
+	repo := NewReminderRepository(db)
+	eventID := uuid.New()
+	userID := uuid.New()
+	from := time.Now().Add(2 * time.Hour)
+	to := time.Now().Add(24 * time.Hour)
+	eventDate := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{
+		"id", "title", "event_date", "start_time", "location_name",
+		"user_id", "registration_status", "waitlist_position",
+	}).AddRow(eventID, "Friday Doubles", eventDate, "19:00:00", "Daan Park", userID, "confirmed", nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`NOT EXISTS (`)).
+		WithArgs(model.RegistrationConfirmed, "confirmed_24h", "Asia/Taipei", from, to, 100).
+		WillReturnRows(rows)
+
+	due, err := repo.FindDue(context.Background(), model.RegistrationConfirmed, "confirmed_24h", "Asia/Taipei", from, to, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(due) != 1 {
+		t.Fatalf("expected 1 due reminder, got %d", len(due))
+	}
+	if due[0].ID != eventID || due[0].UserID != userID {
+		t.Errorf("unexpected reminder %+v", due[0])
+	}
+	if got := due[0].GetStartTimeLabel(); got != "19:00" {
+		t.Errorf("expected start time 19:00, got %s", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestReminderMarkSentTx(t *testing.T) {
+	tests := []struct {
+		name        string
+		affected    int64
+		wantClaimed bool
+	}{
+		{name: "first send is claimed", affected: 1, wantClaimed: true},
+		{name: "repeat send is skipped", affected: 0, wantClaimed: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			defer db.Close()
+
+			repo := NewReminderRepository(db)
+			eventID := uuid.New()
+			userID := uuid.New()
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`ON CONFLICT (event_id, user_id, kind) DO NOTHING`)).
+				WithArgs(sqlmock.AnyArg(), eventID, userID, "confirmed_2h").
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			mock.ExpectCommit()
+
+			tx, err := db.Beginx()
+			if err != nil {
+				t.Fatalf("failed to begin transaction: %v", err)
+			}
+			claimed, err := repo.MarkSentTx(context.Background(), tx, eventID, userID, "confirmed_2h")
+			if err != nil {
+				tx.Rollback()
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tx.Commit()
+
+			if claimed != tt.wantClaimed {
+				t.Errorf("expected claimed=%v, got %v", tt.wantClaimed, claimed)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// SeriesRepository handles recurring event series data access
+type SeriesRepository struct {
+	db *sqlx.DB
+}
+
+// NewSeriesRepository creates a new SeriesRepository
+func NewSeriesRepository(db *sqlx.DB) *SeriesRepository {
+	return &SeriesRepository{db: db}
+}
+
+const seriesColumns = `
+	id, host_id, title, description, start_time, end_time,
+	location_name, location_address,
+	ST_Y(location_point::geometry) as latitude,
+	ST_X(location_point::geometry) as longitude,
+	google_place_id, capacity, skill_level, fee,
+	frequency, weekdays, month_day, starts_on, ends_on, materialized_through,
+	status, created_at, updated_at`
+
+// FindByID finds a series by ID
+func (r *SeriesRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.EventSeries, error) {
+	var series model.EventSeries
+	query := `SELECT ` + seriesColumns + ` FROM event_series WHERE id = $1`
+	err := r.db.GetContext(ctx, &series, query, id)
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// FindByIDForUpdate finds and locks a series row within a transaction
+func (r *SeriesRepository) FindByIDForUpdate(ctx context.Context, tx *sqlx.Tx, id uuid.UUID) (*model.EventSeries, error) {
+	var series model.EventSeries
+	query := `SELECT ` + seriesColumns + ` FROM event_series WHERE id = $1 FOR UPDATE`
+	err := tx.GetContext(ctx, &series, query, id)
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// FindByHostID finds all series created by a host
+func (r *SeriesRepository) FindByHostID(ctx context.Context, hostID uuid.UUID) ([]model.EventSeries, error) {
+	var series []model.EventSeries
+	query := `SELECT ` + seriesColumns + ` FROM event_series WHERE host_id = $1 ORDER BY created_at DESC`
+	err := r.db.SelectContext(ctx, &series, query, hostID)
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// FindActiveIDs returns the IDs of all series that are still materializing occurrences
+func (r *SeriesRepository) FindActiveIDs(ctx context.Context) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	query := `SELECT id FROM event_series WHERE status = 'active' ORDER BY created_at ASC`
+	err := r.db.SelectContext(ctx, &ids, query)
+	return ids, err
+}
+
+// CreateTx creates a new series within a transaction
+func (r *SeriesRepository) CreateTx(ctx context.Context, tx *sqlx.Tx, series *model.EventSeries) error {
+	query := `
+		INSERT INTO event_series (
+			id, host_id, title, description, start_time, end_time,
+			location_name, location_address, location_point, google_place_id,
+			capacity, skill_level, fee,
+			frequency, weekdays, month_day, starts_on, ends_on,
+			status, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8,
+			ST_SetSRID(ST_MakePoint($9, $10), 4326)::geography,
+			$11, $12, $13, $14, $15, $16, $17, $18, $19, 'active', NOW(), NOW()
+		)
+		RETURNING status, created_at, updated_at`
+	return tx.QueryRowxContext(ctx, query,
+		series.ID, series.HostID, series.Title, series.Description,
+		series.StartTime, series.EndTime,
+		series.LocationName, series.LocationAddress,
+		series.Longitude, series.Latitude, series.GooglePlaceID,
+		series.Capacity, series.SkillLevel, series.Fee,
+		series.Frequency, series.Weekdays, series.MonthDay, series.StartsOn, series.EndsOn,
+	).StructScan(series)
+}
+
+// UpdateTemplateTx updates the template fields of a series within a transaction
+func (r *SeriesRepository) UpdateTemplateTx(ctx context.Context, tx *sqlx.Tx, series *model.EventSeries) error {
+	query := `
+		UPDATE event_series SET
+			title = $2, description = $3, start_time = $4, end_time = $5,
+			capacity = $6, skill_level = $7, fee = $8, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at`
+	return tx.QueryRowxContext(ctx, query,
+		series.ID, series.Title, series.Description, series.StartTime, series.EndTime,
+		series.Capacity, series.SkillLevel, series.Fee,
+	).Scan(&series.UpdatedAt)
+}
+
+// SetMaterializedThroughTx records the last date occurrences were materialized for
+func (r *SeriesRepository) SetMaterializedThroughTx(ctx context.Context, tx *sqlx.Tx, id uuid.UUID, through time.Time) error {
+	query := `UPDATE event_series SET materialized_through = $2, updated_at = NOW() WHERE id = $1`
+	_, err := tx.ExecContext(ctx, query, id, through)
+	return err
+}
+
+// UpdateStatusTx updates the status of a series within a transaction
+func (r *SeriesRepository) UpdateStatusTx(ctx context.Context, tx *sqlx.Tx, id uuid.UUID, status model.SeriesStatus) error {
+	query := `UPDATE event_series SET status = $2, updated_at = NOW() WHERE id = $1`
+	_, err := tx.ExecContext(ctx, query, id, status)
+	return err
+}
+
+// CreateOccurrenceTx records an occurrence (or an exception) of a series within a transaction.
+// Returns ErrDuplicateKey if the date has already been recorded for the series.
+func (r *SeriesRepository) CreateOccurrenceTx(ctx context.Context, tx *sqlx.Tx, occ *model.SeriesOccurrence) error {
+	if occ.ID == uuid.Nil {
+		occ.ID = uuid.New()
+	}
+	query := `
+		INSERT INTO event_series_occurrences (id, series_id, occurrence_date, event_id, is_exception, is_detached, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (series_id, occurrence_date) DO NOTHING
+		RETURNING created_at`
+	rows, err := tx.QueryxContext(ctx, query,
+		occ.ID, occ.SeriesID, occ.OccurrenceDate, occ.EventID, occ.IsException, occ.IsDetached,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return ErrDuplicateKey
+	}
+	return rows.Scan(&occ.CreatedAt)
+}
+
+// FindOccurrenceDatesTx returns every date already recorded for a series, including exceptions
+func (r *SeriesRepository) FindOccurrenceDatesTx(ctx context.Context, tx *sqlx.Tx, seriesID uuid.UUID) ([]time.Time, error) {
+	var dates []time.Time
+	query := `SELECT occurrence_date FROM event_series_occurrences WHERE series_id = $1 ORDER BY occurrence_date`
+	err := tx.SelectContext(ctx, &dates, query, seriesID)
+	return dates, err
+}
+
+// FindOccurrences returns the occurrences of a series with the status of their events
+func (r *SeriesRepository) FindOccurrences(ctx context.Context, seriesID uuid.UUID) ([]model.SeriesOccurrenceWithEvent, error) {
+	var occurrences []model.SeriesOccurrenceWithEvent
+	query := `
+		SELECT o.id, o.series_id, o.occurrence_date, o.event_id, o.is_exception, o.is_detached, o.created_at,
+			   e.status as event_status
+		FROM event_series_occurrences o
+		LEFT JOIN events e ON o.event_id = e.id
+		WHERE o.series_id = $1
+		ORDER BY o.occurrence_date ASC`
+	err := r.db.SelectContext(ctx, &occurrences, query, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	return occurrences, nil
+}
+
+// FindOccurrenceByEventID finds the series occurrence an event was materialized for
+func (r *SeriesRepository) FindOccurrenceByEventID(ctx context.Context, eventID uuid.UUID) (*model.SeriesOccurrence, error) {
+	var occ model.SeriesOccurrence
+	query := `
+		SELECT id, series_id, occurrence_date, event_id, is_exception, is_detached, created_at
+		FROM event_series_occurrences WHERE event_id = $1`
+	err := r.db.GetContext(ctx, &occ, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return &occ, nil
+}
+
+// MarkDetachedTx marks an occurrence as individually edited within a transaction so
+// that series-wide edits skip it. Events that are not part of a series are left alone.
+func (r *SeriesRepository) MarkDetachedTx(ctx context.Context, tx *sqlx.Tx, eventID uuid.UUID) error {
+	query := `UPDATE event_series_occurrences SET is_detached = TRUE WHERE event_id = $1`
+	_, err := tx.ExecContext(ctx, query, eventID)
+	return err
+}
+
+// FindFutureEventIDsTx returns the events of a series on or after a date that still follow
+// the series template (not detached and not cancelled), locking them for update
+func (r *SeriesRepository) FindFutureEventIDsTx(ctx context.Context, tx *sqlx.Tx, seriesID uuid.UUID, from time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	query := `
+		SELECT e.id
+		FROM event_series_occurrences o
+		JOIN events e ON o.event_id = e.id
+		WHERE o.series_id = $1
+		AND o.occurrence_date >= $2
+		AND o.is_detached = FALSE
+		AND e.status NOT IN ('cancelled', 'completed')
+		ORDER BY o.occurrence_date ASC
+		FOR UPDATE OF e`
+	err := tx.SelectContext(ctx, &ids, query, seriesID, from)
+	return ids, err
+}
+
+// FindUpcomingEventIDsTx returns the events of a series on or after a date that are
+// not cancelled or completed, within a transaction
+func (r *SeriesRepository) FindUpcomingEventIDsTx(ctx context.Context, tx *sqlx.Tx, seriesID uuid.UUID, from time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	query := `
+		SELECT e.id
+		FROM event_series_occurrences o
+		JOIN events e ON o.event_id = e.id
+		WHERE o.series_id = $1
+		AND o.occurrence_date >= $2
+		AND e.status NOT IN ('cancelled', 'completed')
+		ORDER BY o.occurrence_date ASC`
+	err := tx.SelectContext(ctx, &ids, query, seriesID, from)
+	return ids, err
+}
@@ -2,10 +2,12 @@ package repository
 
 import (
 	"database/sql/driver"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -32,3 +34,16 @@ func (a AnyTime) Match(v driver.Value) bool {
 func strPtr(s string) *string {
 	return &s
 }
+
+// expectNotBlocked mocks the host block check passing
+func expectNotBlocked(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM host_blocks`)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+}
+
+// expectEventLock mocks locking an open event row with GetEventForUpdate
+func expectEventLock(mock sqlmock.Sqlmock, eventID uuid.UUID) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT capacity, status FROM events WHERE id = $1 FOR UPDATE`)).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"capacity", "status"}).AddRow(4, "open"))
+}
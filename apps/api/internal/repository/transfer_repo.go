@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// TransferRepository handles spot transfer data access
+type TransferRepository struct {
+	db *sqlx.DB
+}
+
+// NewTransferRepository creates a new TransferRepository
+func NewTransferRepository(db *sqlx.DB) *TransferRepository {
+	return &TransferRepository{db: db}
+}
+
+// FindByTokenHash finds a transfer by the hash of its link token
+func (r *TransferRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*model.SpotTransfer, error) {
+	var transfer model.SpotTransfer
+	err := r.db.GetContext(ctx, &transfer, `SELECT * FROM spot_transfers WHERE token_hash = $1`, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// FindByID finds a transfer by ID
+func (r *TransferRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.SpotTransfer, error) {
+	var transfer model.SpotTransfer
+	err := r.db.GetContext(ctx, &transfer, `SELECT * FROM spot_transfers WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// FindByEventID finds the transfer history of an event with the players
+// involved, most recent first
+func (r *TransferRepository) FindByEventID(ctx context.Context, eventID uuid.UUID) ([]model.SpotTransferWithUsers, error) {
+	query := `
+		SELECT
+			t.id, t.event_id, t.registration_id, t.from_user_id, t.to_user_id, t.status,
+			t.waitlist_bypassed, t.expires_at, t.created_at, t.accepted_at, t.resolved_at,
+			f.display_name, f.avatar_url, tu.display_name, tu.avatar_url
+		FROM spot_transfers t
+		JOIN users f ON f.id = t.from_user_id
+		LEFT JOIN users tu ON tu.id = t.to_user_id
+		WHERE t.event_id = $1
+		ORDER BY t.created_at DESC`
+
+	rows, err := r.db.QueryxContext(ctx, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []model.SpotTransferWithUsers
+	for rows.Next() {
+		var t model.SpotTransferWithUsers
+		var toName *string
+		var toAvatar *string
+
+		err := rows.Scan(
+			&t.ID, &t.EventID, &t.RegistrationID, &t.FromUserID, &t.ToUserID, &t.Status,
+			&t.WaitlistBypassed, &t.ExpiresAt, &t.CreatedAt, &t.AcceptedAt, &t.ResolvedAt,
+			&t.FromUser.DisplayName, &t.FromUser.AvatarURL, &toName, &toAvatar,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		t.FromUser.ID = t.FromUserID
+		if t.ToUserID != nil && toName != nil {
+			t.ToUser = &model.UserProfile{ID: *t.ToUserID, DisplayName: *toName, AvatarURL: toAvatar}
+		}
+		results = append(results, t)
+	}
+
+	return results, rows.Err()
+}
+
+// CreateTx creates a transfer link within a transaction, revoking any link
+// the registration already has open
+func (r *TransferRepository) CreateTx(ctx context.Context, tx *sqlx.Tx, transfer *model.SpotTransfer) error {
+	if err := r.RevokeOpenTx(ctx, tx, transfer.RegistrationID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO spot_transfers (id, event_id, registration_id, from_user_id, token_hash, status, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, 'pending', $6, NOW())
+		RETURNING status, created_at`
+	return tx.QueryRowxContext(ctx, query,
+		transfer.ID, transfer.EventID, transfer.RegistrationID, transfer.FromUserID,
+		transfer.TokenHash, transfer.ExpiresAt,
+	).Scan(&transfer.Status, &transfer.CreatedAt)
+}
+
+// RevokeOpenTx revokes the pending or awaiting-approval transfer of a
+// registration, if any, within a transaction
+func (r *TransferRepository) RevokeOpenTx(ctx context.Context, tx *sqlx.Tx, registrationID uuid.UUID) error {
+	query := `
+		UPDATE spot_transfers SET status = 'revoked', resolved_at = NOW()
+		WHERE registration_id = $1 AND status IN ('pending', 'awaiting_approval')`
+	_, err := tx.ExecContext(ctx, query, registrationID)
+	return err
+}
+
+// RequestApprovalTx records who accepted a pending transfer and holds it for the
+// host's approval within a transaction. Returns ErrTransferUnavailable if the
+// transfer is no longer pending or has expired.
+func (r *TransferRepository) RequestApprovalTx(ctx context.Context, tx *sqlx.Tx, id, toUserID uuid.UUID) error {
+	query := `
+		UPDATE spot_transfers SET status = 'awaiting_approval', to_user_id = $2, accepted_at = NOW()
+		WHERE id = $1 AND status = 'pending' AND expires_at > NOW()`
+	result, err := tx.ExecContext(ctx, query, id, toUserID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrTransferUnavailable
+	}
+	return nil
+}
+
+// RejectTx turns down a transfer awaiting the host's approval within a
+// transaction. Returns ErrTransferUnavailable if it is not awaiting approval.
+func (r *TransferRepository) RejectTx(ctx context.Context, tx *sqlx.Tx, eventID, id uuid.UUID) (*model.SpotTransfer, error) {
+	var transfer model.SpotTransfer
+	query := `
+		UPDATE spot_transfers SET status = 'rejected', resolved_at = NOW()
+		WHERE id = $1 AND event_id = $2 AND status = 'awaiting_approval'
+		RETURNING *`
+	err := tx.GetContext(ctx, &transfer, query, id, eventID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTransferUnavailable
+		}
+		return nil, err
+	}
+	return &transfer, nil
+}
@@ -79,6 +79,23 @@ func (r *UserRepository) Upsert(ctx context.Context, user *model.User) error {
 	).StructScan(user)
 }
 
+// SetLineFriend records whether the user with the given Line user ID has added the bot as a friend
+func (r *UserRepository) SetLineFriend(ctx context.Context, lineUserID string, friend bool) error {
+	query := `UPDATE users SET line_friend = $2, updated_at = NOW() WHERE line_user_id = $1`
+	result, err := r.db.ExecContext(ctx, query, lineUserID, friend)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
 // Delete deletes a user by ID
 func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM users WHERE id = $1`
@@ -0,0 +1,249 @@
+// Package scheduler runs periodic background jobs inside the API process.
+//
+// Every replica runs a scheduler, but jobs only execute on the replica that
+// currently holds leadership (a Postgres advisory lock), so each job runs once
+// per tick across the deployment.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/pkg/cron"
+)
+
+// Default job settings
+const (
+	DefaultTimeout    = 5 * time.Minute
+	DefaultRetryDelay = 5 * time.Second
+)
+
+// Scheduler errors
+var (
+	ErrAlreadyStarted = errors.New("scheduler already started")
+	ErrDuplicateJob   = errors.New("job already registered")
+	ErrInvalidJob     = errors.New("job must have a name and a run function")
+)
+
+// Job is a unit of periodic background work
+type Job struct {
+	// Name identifies the job in logs and must be unique
+	Name string
+	// Spec is a cron spec such as "*/5 * * * *" or "@every 1m"
+	Spec string
+	// Timeout bounds a single attempt (DefaultTimeout if zero)
+	Timeout time.Duration
+	// MaxRetries is how many times a failed attempt is retried within a tick
+	MaxRetries int
+	// RetryDelay is the delay before the first retry, doubled for each further retry
+	// (DefaultRetryDelay if zero)
+	RetryDelay time.Duration
+	// Run does the work. It must return promptly once ctx is done.
+	Run func(ctx context.Context) error
+}
+
+// Elector decides which replica runs jobs
+type Elector interface {
+	// TryAcquire reports whether this replica is the leader, trying to become it if not
+	TryAcquire(ctx context.Context) (bool, error)
+	// Release gives up leadership
+	Release(ctx context.Context) error
+}
+
+// Scheduler runs registered jobs on their schedules
+type Scheduler struct {
+	elector  Elector
+	location *time.Location
+
+	mu      sync.Mutex
+	jobs    []*entry
+	started bool
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+type entry struct {
+	job      Job
+	schedule cron.Schedule
+}
+
+// New creates a scheduler. Specs are evaluated in the given location. A nil
+// elector runs every job on this replica.
+func New(elector Elector, location *time.Location) *Scheduler {
+	if location == nil {
+		location = time.Local
+	}
+	return &Scheduler{elector: elector, location: location}
+}
+
+// Register adds a job. Jobs must be registered before Start.
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" || job.Run == nil {
+		return ErrInvalidJob
+	}
+	schedule, err := cron.Parse(job.Spec)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", job.Name, err)
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = DefaultTimeout
+	}
+	if job.RetryDelay <= 0 {
+		job.RetryDelay = DefaultRetryDelay
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return ErrAlreadyStarted
+	}
+	for _, e := range s.jobs {
+		if e.job.Name == job.Name {
+			return fmt.Errorf("%w: %s", ErrDuplicateJob, job.Name)
+		}
+	}
+	s.jobs = append(s.jobs, &entry{job: job, schedule: schedule})
+	return nil
+}
+
+// MustRegister is like Register but panics on error
+func (s *Scheduler) MustRegister(job Job) {
+	if err := s.Register(job); err != nil {
+		panic(err)
+	}
+}
+
+// Start starts running jobs in the background
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	for _, e := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, e)
+	}
+}
+
+// Stop stops scheduling new runs, cancels running jobs and waits for them to
+// return, then gives up leadership. Returns ctx.Err() if jobs do not finish in time.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if s.elector != nil {
+		return s.elector.Release(ctx)
+	}
+	return nil
+}
+
+// loop waits for each scheduled time of a job and runs it. Runs of the same job never overlap.
+func (s *Scheduler) loop(ctx context.Context, e *entry) {
+	defer s.wg.Done()
+
+	for {
+		next := e.schedule.Next(time.Now().In(s.location))
+		if next.IsZero() {
+			log.Printf("Scheduler: job %s has no future runs", e.job.Name)
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if !s.isLeader(ctx) {
+			continue
+		}
+		if err := s.run(ctx, e.job); err != nil && ctx.Err() == nil {
+			log.Printf("Scheduler: job %s failed: %v", e.job.Name, err)
+		}
+	}
+}
+
+// isLeader reports whether this replica should run jobs
+func (s *Scheduler) isLeader(ctx context.Context) bool {
+	if s.elector == nil {
+		return true
+	}
+	ok, err := s.elector.TryAcquire(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Scheduler: leader election failed: %v", err)
+		}
+		return false
+	}
+	return ok
+}
+
+// run runs a job, retrying failed attempts with exponential backoff
+func (s *Scheduler) run(ctx context.Context, job Job) error {
+	delay := job.RetryDelay
+	var err error
+	for attempt := 0; attempt <= job.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+
+		err = s.attempt(ctx, job)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// attempt runs a job once within its timeout, turning panics into errors
+func (s *Scheduler) attempt(ctx context.Context, job Job) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, job.Timeout)
+	defer cancel()
+
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+
+	return job.Run(ctx)
+}
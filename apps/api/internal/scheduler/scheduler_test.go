@@ -0,0 +1,189 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeElector struct {
+	leader   bool
+	released atomic.Bool
+}
+
+func (e *fakeElector) TryAcquire(ctx context.Context) (bool, error) { return e.leader, nil }
+func (e *fakeElector) Release(ctx context.Context) error {
+	e.released.Store(true)
+	return nil
+}
+
+func TestRegister_Validation(t *testing.T) {
+	s := New(nil, time.UTC)
+	noop := func(ctx context.Context) error { return nil }
+
+	if err := s.Register(Job{Name: "", Spec: "@every 1m", Run: noop}); !errors.Is(err, ErrInvalidJob) {
+		t.Errorf("expected ErrInvalidJob, got %v", err)
+	}
+	if err := s.Register(Job{Name: "bad-spec", Spec: "every minute", Run: noop}); err == nil {
+		t.Error("expected error for invalid spec")
+	}
+	if err := s.Register(Job{Name: "job", Spec: "@every 1m", Run: noop}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Register(Job{Name: "job", Spec: "@every 1m", Run: noop}); !errors.Is(err, ErrDuplicateJob) {
+		t.Errorf("expected ErrDuplicateJob, got %v", err)
+	}
+
+	s.Start()
+	defer s.Stop(context.Background())
+	if err := s.Register(Job{Name: "late", Spec: "@every 1m", Run: noop}); !errors.Is(err, ErrAlreadyStarted) {
+		t.Errorf("expected ErrAlreadyStarted, got %v", err)
+	}
+}
+
+func TestRun_RetriesUntilSuccess(t *testing.T) {
+	s := New(nil, time.UTC)
+	var calls int
+	job := Job{
+		Name:       "flaky",
+		MaxRetries: 3,
+		RetryDelay: time.Millisecond,
+		Timeout:    time.Second,
+		Run: func(ctx context.Context) error {
+			calls++
+			if calls < 3 {
+				return errors.New("temporary failure")
+			}
+			return nil
+		},
+	}
+
+	if err := s.run(context.Background(), job); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRun_GivesUpAfterMaxRetries(t *testing.T) {
+	s := New(nil, time.UTC)
+	var calls int
+	job := Job{
+		Name:       "broken",
+		MaxRetries: 2,
+		RetryDelay: time.Millisecond,
+		Timeout:    time.Second,
+		Run: func(ctx context.Context) error {
+			calls++
+			return errors.New("permanent failure")
+		},
+	}
+
+	if err := s.run(context.Background(), job); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRun_TimeoutAndPanic(t *testing.T) {
+	s := New(nil, time.UTC)
+
+	slow := Job{
+		Name:       "slow",
+		Timeout:    10 * time.Millisecond,
+		RetryDelay: time.Millisecond,
+		Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+	if err := s.run(context.Background(), slow); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+
+	panicky := Job{
+		Name:       "panicky",
+		Timeout:    time.Second,
+		RetryDelay: time.Millisecond,
+		Run: func(ctx context.Context) error {
+			panic("boom")
+		},
+	}
+	if err := s.run(context.Background(), panicky); err == nil {
+		t.Error("expected panic to be returned as an error")
+	}
+}
+
+func TestScheduler_OnlyLeaderRunsJobs(t *testing.T) {
+	tests := []struct {
+		name    string
+		leader  bool
+		wantRun bool
+	}{
+		{name: "leader runs jobs", leader: true, wantRun: true},
+		{name: "follower skips jobs", leader: false, wantRun: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elector := &fakeElector{leader: tt.leader}
+			s := New(elector, time.UTC)
+
+			var runs atomic.Int32
+			s.MustRegister(Job{
+				Name: "tick",
+				Spec: "@every 5ms",
+				Run: func(ctx context.Context) error {
+					runs.Add(1)
+					return nil
+				},
+			})
+
+			s.Start()
+			time.Sleep(50 * time.Millisecond)
+			if err := s.Stop(context.Background()); err != nil {
+				t.Fatalf("unexpected stop error: %v", err)
+			}
+
+			if got := runs.Load() > 0; got != tt.wantRun {
+				t.Errorf("expected ran=%v, got %d runs", tt.wantRun, runs.Load())
+			}
+			if !elector.released.Load() {
+				t.Error("expected leadership to be released on stop")
+			}
+		})
+	}
+}
+
+func TestScheduler_StopCancelsRunningJobs(t *testing.T) {
+	s := New(nil, time.UTC)
+	started := make(chan struct{})
+	var once atomic.Bool
+
+	s.MustRegister(Job{
+		Name:    "long",
+		Spec:    "@every 1ms",
+		Timeout: time.Minute,
+		Run: func(ctx context.Context) error {
+			if once.CompareAndSwap(false, true) {
+				close(started)
+			}
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	s.Start()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.Stop(ctx); err != nil {
+		t.Fatalf("expected running job to be cancelled, got %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/pkg/line"
+)
+
+// deliveryBatchSize limits how many notifications are pushed per sweep
+const deliveryBatchSize = 100
+
+// Temporary push failures are retried with exponential backoff, starting at
+// deliveryRetryBackoff, until a delivery has been attempted maxDeliveryAttempts times
+const (
+	maxDeliveryAttempts  = 5
+	deliveryRetryBackoff = time.Minute
+)
+
+// Reasons recorded for notifications that are not pushed
+const (
+	skipReasonNotConfigured = "LINE messaging is not configured"
+	skipReasonNotFriend     = "user has not added the LINE bot"
+)
+
+// DeliveryService pushes notifications to users through LINE
+type DeliveryService struct {
+	deliveryRepo *repository.DeliveryRepository
+	eventRepo    *repository.EventRepository
+	lineClient   *line.MessagingClient
+	baseURL      string
+}
+
+// NewDeliveryService creates a new DeliveryService. Every delivery is skipped when
+// lineClient is nil or has no access token. baseURL is the web app URL event cards link to.
+func NewDeliveryService(deliveryRepo *repository.DeliveryRepository, eventRepo *repository.EventRepository, lineClient *line.MessagingClient, baseURL string) *DeliveryService {
+	return &DeliveryService{
+		deliveryRepo: deliveryRepo,
+		eventRepo:    eventRepo,
+		lineClient:   lineClient,
+		baseURL:      strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// DeliverPending pushes pending LINE deliveries and records the outcome of each.
+// Returns the number of notifications sent.
+func (s *DeliveryService) DeliverPending(ctx context.Context) (int, error) {
+	pending, err := s.deliveryRepo.FindPending(ctx, model.DeliveryChannelLine, deliveryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, d := range pending {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+
+		switch {
+		case s.lineClient == nil || !s.lineClient.CanPush():
+			err = s.deliveryRepo.MarkSkipped(ctx, d.ID, skipReasonNotConfigured)
+		case !d.LineFriend:
+			err = s.deliveryRepo.MarkSkipped(ctx, d.ID, skipReasonNotFriend)
+		default:
+			// The delivery ID doubles as the retry key so a retried push is never shown twice
+			if pushErr := s.lineClient.Push(ctx, d.LineUserID, d.ID.String(), s.buildMessage(ctx, &d)); pushErr != nil {
+				err = s.recordFailure(ctx, &d, pushErr)
+			} else {
+				err = s.deliveryRepo.MarkSent(ctx, d.ID)
+				sent++
+			}
+		}
+		if err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// recordFailure keeps a delivery pending for another attempt after a temporary
+// push error, or marks it failed once the error is permanent or it has used
+// up its attempts
+func (s *DeliveryService) recordFailure(ctx context.Context, d *model.PendingDelivery, pushErr error) error {
+	attempts := d.Attempts + 1
+	if !isTemporaryPushError(pushErr) || attempts >= maxDeliveryAttempts {
+		return s.deliveryRepo.MarkFailed(ctx, d.ID, pushErr.Error())
+	}
+	retryAt := time.Now().Add(deliveryRetryBackoff << (attempts - 1))
+	return s.deliveryRepo.MarkRetry(ctx, d.ID, pushErr.Error(), retryAt)
+}
+
+// isTemporaryPushError reports whether a push may succeed if retried. LINE
+// rate limits and server errors are temporary, and so are network failures,
+// which never reach the API.
+func isTemporaryPushError(err error) bool {
+	var apiErr *line.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Temporary()
+	}
+	return true
+}
+
+// buildMessage renders a notification as an event card when it is about an
+// event, or as plain text otherwise
+func (s *DeliveryService) buildMessage(ctx context.Context, d *model.PendingDelivery) line.Message {
+	message := ""
+	if d.Message != nil {
+		message = *d.Message
+	}
+
+	if d.EventID != nil {
+		if event, err := s.eventRepo.FindByID(ctx, *d.EventID); err == nil {
+			title := event.LocationName
+			if event.Title != nil && *event.Title != "" {
+				title = *event.Title
+			}
+			return line.NewEventCardMessage(line.EventCard{
+				Heading:  d.Title,
+				Title:    title,
+				Date:     event.EventDate.Format("2006/01/02 (Mon)"),
+				Time:     event.GetStartTimeLabel(),
+				Location: event.LocationName,
+				Message:  message,
+				URL:      s.baseURL + "/g/" + event.ShortCode,
+			})
+		}
+	}
+
+	text := d.Title
+	if message != "" {
+		text += "\n" + message
+	}
+	return line.TextMessage{Text: text}
+}
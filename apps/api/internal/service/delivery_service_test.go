@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/pkg/line"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestDeliverPending_PushFailures(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		attempts   int
+		wantRetry  bool
+	}{
+		{name: "rate limited is retried", statusCode: http.StatusTooManyRequests, attempts: 0, wantRetry: true},
+		{name: "server error is retried", statusCode: http.StatusInternalServerError, attempts: 3, wantRetry: true},
+		{name: "bad request fails", statusCode: http.StatusBadRequest, attempts: 0, wantRetry: false},
+		{name: "last attempt fails", statusCode: http.StatusInternalServerError, attempts: maxDeliveryAttempts - 1, wantRetry: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lineServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(`{"message":"try again"}`))
+			}))
+			defer lineServer.Close()
+
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("failed to create mock database: %v", err)
+			}
+			db := sqlx.NewDb(mockDB, "postgres")
+			defer db.Close()
+
+			lineClient := line.NewMessagingClient(line.MessagingConfig{ChannelAccessToken: "token", BaseURL: lineServer.URL})
+			s := NewDeliveryService(repository.NewDeliveryRepository(db), repository.NewEventRepository(db), lineClient, "https://picklego.tw")
+
+			deliveryID := uuid.New()
+			mock.ExpectQuery(regexp.QuoteMeta(`WHERE d.channel = $1 AND d.status = 'pending'`)).
+				WillReturnRows(sqlmock.NewRows([]string{
+					"id", "notification_id", "attempts", "user_id", "event_id",
+					"type", "title", "message", "line_user_id", "line_friend",
+				}).AddRow(deliveryID, uuid.New(), tt.attempts, uuid.New(), nil,
+					model.NotificationEventReminder, "Your game starts in 2 hours", nil, "U123", true))
+			if tt.wantRetry {
+				mock.ExpectExec(regexp.QuoteMeta(`SET attempts = attempts + 1, last_error = $2, deliver_after = $3`)).
+					WithArgs(deliveryID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			} else {
+				mock.ExpectExec(regexp.QuoteMeta(`SET status = 'failed'`)).
+					WithArgs(deliveryID, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			sent, err := s.DeliverPending(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sent != 0 {
+				t.Errorf("expected nothing sent, got %d", sent)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
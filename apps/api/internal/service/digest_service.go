@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// DigestService batches notifications into daily or weekly digests for users
+// who prefer them to individual LINE messages
+type DigestService struct {
+	notificationRepo *repository.NotificationRepository
+	preferenceRepo   *repository.PreferenceRepository
+	txManager        *database.TxManager
+}
+
+// NewDigestService creates a new DigestService
+func NewDigestService(notificationRepo *repository.NotificationRepository, preferenceRepo *repository.PreferenceRepository, txManager *database.TxManager) *DigestService {
+	return &DigestService{
+		notificationRepo: notificationRepo,
+		preferenceRepo:   preferenceRepo,
+		txManager:        txManager,
+	}
+}
+
+// SendDue sends every digest that is due. Returns the number of digests sent.
+func (s *DigestService) SendDue(ctx context.Context) (int, error) {
+	subscribers, err := s.preferenceRepo.FindDigestSubscribers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	sent := 0
+	for i := range subscribers {
+		settings := &subscribers[i]
+		if !settings.DigestDue(now) {
+			continue
+		}
+
+		var created bool
+		err := s.txManager.WithTx(ctx, func(tx *sqlx.Tx) error {
+			items, err := s.notificationRepo.FindDigestItemsTx(ctx, tx, settings.UserID)
+			if err != nil || len(items) == 0 {
+				return err
+			}
+
+			ids := make([]uuid.UUID, len(items))
+			for i, item := range items {
+				ids[i] = item.NotificationID
+			}
+			digest := model.NewDigestNotification(settings.UserID, settings.Digest, items)
+			if err := s.notificationRepo.CreateDigestTx(ctx, tx, digest, ids, settings.QuietUntil(now)); err != nil {
+				return err
+			}
+			created = true
+			return s.preferenceRepo.MarkDigestSentTx(ctx, tx, settings.UserID, now)
+		})
+		if err != nil {
+			return sent, err
+		}
+		if created {
+			sent++
+		}
+	}
+	return sent, nil
+}
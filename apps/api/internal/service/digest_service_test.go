@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+func newTestDigestService(t *testing.T) (*DigestService, sqlmock.Sqlmock, *sqlx.DB) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+	db := sqlx.NewDb(mockDB, "postgres")
+	s := NewDigestService(repository.NewNotificationRepository(db), repository.NewPreferenceRepository(db), database.NewTxManager(db))
+	return s, mock, db
+}
+
+var digestSubscriberColumns = []string{"user_id", "timezone", "quiet_hours_start", "quiet_hours_end", "digest", "last_digest_at"}
+
+func TestDigestSendDue(t *testing.T) {
+	t.Run("sends due digests", func(t *testing.T) {
+		s, mock, db := newTestDigestService(t)
+		defer db.Close()
+
+		userID, itemID := uuid.New(), uuid.New()
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM notification_settings s`)).
+			WillReturnRows(sqlmock.NewRows(digestSubscriberColumns).
+				AddRow(userID, "Asia/Taipei", nil, nil, model.DigestDaily, nil))
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE n.user_id = $1 AND n.digest_pending`)).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "title", "created_at"}).
+				AddRow(itemID, nil, "You're in!", time.Now()))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO notification_deliveries`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`SET digest_pending = false, digest_id = $1`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE notification_settings SET last_digest_at = $2`)).
+			WithArgs(userID, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		sent, err := s.SendDue(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sent != 1 {
+			t.Errorf("expected 1 digest sent, got %d", sent)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("skips digests that are not due", func(t *testing.T) {
+		s, mock, db := newTestDigestService(t)
+		defer db.Close()
+
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM notification_settings s`)).
+			WillReturnRows(sqlmock.NewRows(digestSubscriberColumns).
+				AddRow(uuid.New(), "Asia/Taipei", nil, nil, model.DigestDaily, time.Now()))
+
+		sent, err := s.SendDue(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sent != 0 {
+			t.Errorf("expected no digests sent, got %d", sent)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+
+	t.Run("empty batch creates nothing", func(t *testing.T) {
+		s, mock, db := newTestDigestService(t)
+		defer db.Close()
+
+		userID := uuid.New()
+		mock.ExpectQuery(regexp.QuoteMeta(`FROM notification_settings s`)).
+			WillReturnRows(sqlmock.NewRows(digestSubscriberColumns).
+				AddRow(userID, "Asia/Taipei", nil, nil, model.DigestWeekly, nil))
+		// The items were sent some other way since the subscriber was listed;
+		// no digest is created and last_digest_at is left alone
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE n.user_id = $1 AND n.digest_pending`)).
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "title", "created_at"}))
+		mock.ExpectCommit()
+
+		sent, err := s.SendDue(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sent != 0 {
+			t.Errorf("expected no digests sent, got %d", sent)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unfulfilled expectations: %v", err)
+		}
+	})
+}
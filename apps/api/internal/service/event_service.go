@@ -50,19 +50,21 @@ type CreateEventInput struct {
 // CreateEvent creates a new event
 func (s *EventService) CreateEvent(ctx context.Context, input CreateEventInput) (*model.Event, error) {
 	event := &model.Event{
-		ID:              uuid.New(),
-		HostID:          input.HostID,
-		Title:           input.Title,
-		Description:     input.Description,
-		LocationName:    input.LocationName,
-		LocationAddress: input.LocationAddress,
-		Latitude:        input.Latitude,
-		Longitude:       input.Longitude,
-		GooglePlaceID:   input.GooglePlaceID,
-		Capacity:        input.Capacity,
-		SkillLevel:      input.SkillLevel,
-		Fee:             input.Fee,
-		Status:          model.EventStatusOpen,
+		ID:                 uuid.New(),
+		HostID:             input.HostID,
+		Title:              input.Title,
+		Description:        input.Description,
+		LocationName:       input.LocationName,
+		LocationAddress:    input.LocationAddress,
+		Latitude:           input.Latitude,
+		Longitude:          input.Longitude,
+		GooglePlaceID:      input.GooglePlaceID,
+		Capacity:           input.Capacity,
+		SkillLevel:         input.SkillLevel,
+		Fee:                input.Fee,
+		Status:             model.EventStatusOpen,
+		Visibility:         model.VisibilityPublic,
+		RegistrationAccess: model.AccessEveryone,
 	}
 
 	err := s.eventRepo.Create(ctx, event)
@@ -147,15 +149,20 @@ func (s *EventService) UpdateEvent(ctx context.Context, input UpdateEventInput)
 	if input.Fee != nil {
 		event.Fee = *input.Fee
 	}
-	if input.Status != nil {
-		event.Status = *input.Status
-	}
 
 	err = s.eventRepo.Update(ctx, event)
 	if err != nil {
 		return nil, err
 	}
 
+	// Status goes through the state machine rather than the general update
+	if input.Status != nil && *input.Status != event.Status {
+		if err := s.eventRepo.UpdateStatus(ctx, event.ID, *input.Status); err != nil {
+			return nil, err
+		}
+		event.Status = *input.Status
+	}
+
 	return event, nil
 }
 
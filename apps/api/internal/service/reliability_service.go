@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ReliabilityService scores how reliably players turn up for the spots they confirm
+type ReliabilityService struct {
+	registrationRepo *repository.RegistrationRepository
+	policy           model.ReliabilityPolicy
+	timezone         string
+}
+
+// NewReliabilityService creates a new ReliabilityService. Event dates and times
+// are wall-clock times in timezone.
+func NewReliabilityService(registrationRepo *repository.RegistrationRepository, policy model.ReliabilityPolicy, timezone string) *ReliabilityService {
+	return &ReliabilityService{
+		registrationRepo: registrationRepo,
+		policy:           policy,
+		timezone:         timezone,
+	}
+}
+
+// ForUser returns a player's reliability
+func (s *ReliabilityService) ForUser(ctx context.Context, userID uuid.UUID) (model.Reliability, error) {
+	results, err := s.ForUsers(ctx, []uuid.UUID{userID})
+	if err != nil {
+		return model.Reliability{}, err
+	}
+	return results[userID], nil
+}
+
+// ForUsers returns the reliability of several players. Players without history
+// have a zero Reliability.
+func (s *ReliabilityService) ForUsers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]model.Reliability, error) {
+	return s.registrationRepo.FindReliability(ctx, userIDs, s.policy, s.timezone)
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/jmoiron/sqlx"
+)
+
+// reminderBatchSize limits how many reminders of one kind are sent per sweep
+const reminderBatchSize = 500
+
+// ReminderService sends reminders before events start
+type ReminderService struct {
+	reminderRepo *repository.ReminderRepository
+	outboxRepo   *repository.OutboxRepository
+	txManager    *database.TxManager
+	offsets      []time.Duration
+	timezone     string
+}
+
+// NewReminderService creates a new ReminderService. Confirmed players are reminded
+// at every offset before an event starts; waitlisted players once, at the earliest.
+// Event dates and times are wall-clock times in the given time zone.
+func NewReminderService(reminderRepo *repository.ReminderRepository, outboxRepo *repository.OutboxRepository, txManager *database.TxManager, offsets []time.Duration, timezone string) *ReminderService {
+	sorted := append([]time.Duration(nil), offsets...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] > sorted[j] })
+	return &ReminderService{
+		reminderRepo: reminderRepo,
+		outboxRepo:   outboxRepo,
+		txManager:    txManager,
+		offsets:      sorted,
+		timezone:     timezone,
+	}
+}
+
+// SendDue sends every reminder that is due. Each offset covers events starting
+// between it and the next shorter offset, so a player who registers late only
+// gets the closest reminder. Returns the number of reminders sent.
+func (s *ReminderService) SendDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	sent := 0
+	for i, offset := range s.offsets {
+		var lower time.Duration
+		if i+1 < len(s.offsets) {
+			lower = s.offsets[i+1]
+		}
+		from, to := now.Add(lower), now.Add(offset)
+
+		n, err := s.send(ctx, model.RegistrationConfirmed, offset, from, to)
+		sent += n
+		if err != nil {
+			return sent, err
+		}
+
+		// Waitlisted players only hear from us once
+		if i == 0 {
+			n, err := s.send(ctx, model.RegistrationWaitlist, offset, now, to)
+			sent += n
+			if err != nil {
+				return sent, err
+			}
+		}
+	}
+	return sent, nil
+}
+
+// send sends one kind of reminder for events starting in (from, to]
+func (s *ReminderService) send(ctx context.Context, status model.RegistrationStatus, offset time.Duration, from, to time.Time) (int, error) {
+	kind := model.ReminderKind(status, offset)
+	due, err := s.reminderRepo.FindDue(ctx, status, kind, s.timezone, from, to, reminderBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, d := range due {
+		d := d
+		var claimed bool
+		err := s.txManager.WithTx(ctx, func(tx *sqlx.Tx) error {
+			var txErr error
+			claimed, txErr = s.reminderRepo.MarkSentTx(ctx, tx, d.ID, d.UserID, kind)
+			if txErr != nil || !claimed {
+				return txErr
+			}
+			if status == model.RegistrationWaitlist {
+				if d.WaitlistPosition == nil {
+					return nil
+				}
+				return s.outboxRepo.EnqueueNotificationsTx(ctx, tx,
+					model.NewWaitlistReminderNotification(d.UserID, d.ID, d.GetNotificationTitle(), *d.WaitlistPosition))
+			}
+			return s.outboxRepo.EnqueueNotificationsTx(ctx, tx,
+				model.NewEventReminderNotification(d.UserID, d.ID, d.GetNotificationTitle(), d.GetStartTimeLabel(), formatStartsIn(offset)))
+		})
+		if err != nil {
+			return sent, err
+		}
+		if claimed {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// formatStartsIn formats a reminder offset for display, e.g. "24 hours" or "30 minutes"
+func formatStartsIn(offset time.Duration) string {
+	if offset%time.Hour == 0 {
+		if offset == time.Hour {
+			return "1 hour"
+		}
+		return fmt.Sprintf("%d hours", offset/time.Hour)
+	}
+	return fmt.Sprintf("%d minutes", offset/time.Minute)
+}
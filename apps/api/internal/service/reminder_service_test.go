@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// fromNow matches a time argument within a second of now plus the duration
+type fromNow time.Duration
+
+func (d fromNow) Match(v driver.Value) bool {
+	t, ok := v.(time.Time)
+	if !ok {
+		return false
+	}
+	diff := time.Until(t) - time.Duration(d)
+	return diff > -time.Second && diff < time.Second
+}
+
+func newTestReminderService(t *testing.T, offsets []time.Duration) (*ReminderService, sqlmock.Sqlmock, *sqlx.DB) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock database: %v", err)
+	}
+	db := sqlx.NewDb(mockDB, "postgres")
+	s := NewReminderService(repository.NewReminderRepository(db), repository.NewOutboxRepository(db), database.NewTxManager(db), offsets, "Asia/Taipei")
+	return s, mock, db
+}
+
+var dueReminderColumns = []string{"id", "title", "event_date", "start_time", "location_name", "user_id", "registration_status", "waitlist_position"}
+
+func TestReminderSendDue_Windows(t *testing.T) {
+	type window struct {
+		status   model.RegistrationStatus
+		offset   time.Duration
+		from, to time.Duration
+	}
+	tests := []struct {
+		name    string
+		offsets []time.Duration
+		want    []window
+	}{
+		{
+			name:    "single offset",
+			offsets: []time.Duration{2 * time.Hour},
+			want: []window{
+				{model.RegistrationConfirmed, 2 * time.Hour, 0, 2 * time.Hour},
+				{model.RegistrationWaitlist, 2 * time.Hour, 0, 2 * time.Hour},
+			},
+		},
+		{
+			name:    "offsets are sorted longest first",
+			offsets: []time.Duration{2 * time.Hour, 24 * time.Hour},
+			want: []window{
+				{model.RegistrationConfirmed, 24 * time.Hour, 2 * time.Hour, 24 * time.Hour},
+				{model.RegistrationWaitlist, 24 * time.Hour, 0, 24 * time.Hour},
+				{model.RegistrationConfirmed, 2 * time.Hour, 0, 2 * time.Hour},
+			},
+		},
+		{
+			name:    "each offset covers up to the next shorter one",
+			offsets: []time.Duration{30 * time.Minute, 24 * time.Hour, 2 * time.Hour},
+			want: []window{
+				{model.RegistrationConfirmed, 24 * time.Hour, 2 * time.Hour, 24 * time.Hour},
+				{model.RegistrationWaitlist, 24 * time.Hour, 0, 24 * time.Hour},
+				{model.RegistrationConfirmed, 2 * time.Hour, 30 * time.Minute, 2 * time.Hour},
+				{model.RegistrationConfirmed, 30 * time.Minute, 0, 30 * time.Minute},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mock, db := newTestReminderService(t, tt.offsets)
+			defer db.Close()
+
+			// Waitlisted players are only looked up for the first, longest offset
+			for _, w := range tt.want {
+				mock.ExpectQuery(regexp.QuoteMeta(`FROM registrations r`)).
+					WithArgs(w.status, model.ReminderKind(w.status, w.offset), "Asia/Taipei", fromNow(w.from), fromNow(w.to), reminderBatchSize).
+					WillReturnRows(sqlmock.NewRows(dueReminderColumns))
+			}
+
+			sent, err := s.SendDue(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sent != 0 {
+				t.Errorf("expected no reminders sent, got %d", sent)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unfulfilled expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestReminderSendDue_WaitlistRemindedOnce(t *testing.T) {
+	s, mock, db := newTestReminderService(t, []time.Duration{24 * time.Hour})
+	defer db.Close()
+
+	eventID, userID := uuid.New(), uuid.New()
+	kind := model.ReminderKind(model.RegistrationWaitlist, 24*time.Hour)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM registrations r`)).
+		WithArgs(model.RegistrationConfirmed, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(dueReminderColumns))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM registrations r`)).
+		WithArgs(model.RegistrationWaitlist, kind, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(dueReminderColumns).
+			AddRow(eventID, nil, time.Now(), "20:00", "Test Location", userID, model.RegistrationWaitlist, 2))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO event_reminders`)).
+		WithArgs(sqlmock.AnyArg(), eventID, userID, kind).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO outbox`)).
+		WithArgs(sqlmock.AnyArg(), model.OutboxTopicNotification, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	sent, err := s.SendDue(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sent != 1 {
+		t.Errorf("expected 1 reminder sent, got %d", sent)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/anthropics/pickle-go/apps/api/pkg/recurrence"
+	"github.com/anthropics/pickle-go/apps/api/pkg/shortcode"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// DefaultMaterializeHorizon is how far ahead series occurrences are created
+const DefaultMaterializeHorizon = 8 * 7 * 24 * time.Hour
+
+// Series errors
+var (
+	ErrSeriesNotFound     = errors.New("series not found")
+	ErrSeriesEnded        = errors.New("series has ended")
+	ErrOccurrenceNotFound = errors.New("event is not an occurrence of this series")
+	ErrOccurrenceClosed   = errors.New("occurrence has been cancelled or completed")
+)
+
+// SeriesService handles recurring event series business logic
+type SeriesService struct {
+	seriesRepo       *repository.SeriesRepository
+	eventRepo        *repository.EventRepository
+	registrationRepo *repository.RegistrationRepository
+	outboxRepo       *repository.OutboxRepository
+	txManager        *database.TxManager
+}
+
+// NewSeriesService creates a new SeriesService
+func NewSeriesService(seriesRepo *repository.SeriesRepository, eventRepo *repository.EventRepository, registrationRepo *repository.RegistrationRepository, outboxRepo *repository.OutboxRepository, txManager *database.TxManager) *SeriesService {
+	return &SeriesService{
+		seriesRepo:       seriesRepo,
+		eventRepo:        eventRepo,
+		registrationRepo: registrationRepo,
+		outboxRepo:       outboxRepo,
+		txManager:        txManager,
+	}
+}
+
+// SeriesChanges represents template fields to change on a series or its occurrences
+type SeriesChanges struct {
+	Title       *string
+	Description *string
+	StartTime   *string
+	EndTime     *string
+	Capacity    *int
+	SkillLevel  *model.SkillLevel
+	Fee         *int
+	// CapacityPolicy decides what happens to an occurrence whose new capacity
+	// is below the seats already held
+	CapacityPolicy model.CapacityPolicy
+}
+
+// ApplyToEvent applies the changes to a single occurrence
+func (c SeriesChanges) ApplyToEvent(event *model.Event) {
+	if c.Title != nil {
+		event.Title = c.Title
+	}
+	if c.Description != nil {
+		event.Description = c.Description
+	}
+	if c.StartTime != nil {
+		event.StartTime = *c.StartTime
+	}
+	if c.EndTime != nil {
+		event.EndTime = c.EndTime
+	}
+	if c.Capacity != nil {
+		event.Capacity = *c.Capacity
+	}
+	if c.SkillLevel != nil {
+		event.SkillLevel = *c.SkillLevel
+	}
+	if c.Fee != nil {
+		event.Fee = *c.Fee
+	}
+}
+
+// ApplyToSeries applies the changes to the series template
+func (c SeriesChanges) ApplyToSeries(series *model.EventSeries) {
+	if c.Title != nil {
+		series.Title = c.Title
+	}
+	if c.Description != nil {
+		series.Description = c.Description
+	}
+	if c.StartTime != nil {
+		series.StartTime = *c.StartTime
+	}
+	if c.EndTime != nil {
+		series.EndTime = c.EndTime
+	}
+	if c.Capacity != nil {
+		series.Capacity = *c.Capacity
+	}
+	if c.SkillLevel != nil {
+		series.SkillLevel = *c.SkillLevel
+	}
+	if c.Fee != nil {
+		series.Fee = *c.Fee
+	}
+}
+
+// CreateSeries creates a series, records its exception dates and materializes
+// the first occurrences up to the default horizon, all in one transaction
+func (s *SeriesService) CreateSeries(ctx context.Context, series *model.EventSeries, exceptions []time.Time) error {
+	if err := series.Rule(exceptions).Validate(); err != nil {
+		return err
+	}
+
+	return s.txManager.WithTx(ctx, func(tx *sqlx.Tx) error {
+		if err := s.seriesRepo.CreateTx(ctx, tx, series); err != nil {
+			return err
+		}
+		for _, d := range exceptions {
+			occ := &model.SeriesOccurrence{
+				SeriesID:       series.ID,
+				OccurrenceDate: recurrence.Date(d),
+				IsException:    true,
+			}
+			err := s.seriesRepo.CreateOccurrenceTx(ctx, tx, occ)
+			if err != nil && !errors.Is(err, repository.ErrDuplicateKey) {
+				return err
+			}
+		}
+		_, err := s.materializeTx(ctx, tx, series, time.Now().Add(DefaultMaterializeHorizon))
+		return err
+	})
+}
+
+// Materialize creates events for every occurrence of a series up to the given date
+// that has not been materialized yet. It is idempotent and safe to run concurrently.
+// Returns the events that were created.
+func (s *SeriesService) Materialize(ctx context.Context, seriesID uuid.UUID, through time.Time) ([]*model.Event, error) {
+	return database.WithTxResult(s.txManager, ctx, func(tx *sqlx.Tx) ([]*model.Event, error) {
+		series, err := s.seriesRepo.FindByIDForUpdate(ctx, tx, seriesID)
+		if err != nil {
+			return nil, err
+		}
+		return s.materializeTx(ctx, tx, series, through)
+	})
+}
+
+// materializeTx creates the missing occurrences of a series locked by the caller
+func (s *SeriesService) materializeTx(ctx context.Context, tx *sqlx.Tx, series *model.EventSeries, through time.Time) ([]*model.Event, error) {
+	if series.Status != model.SeriesStatusActive {
+		return nil, nil
+	}
+
+	// Never create occurrences in the past
+	from := recurrence.Date(time.Now())
+	if series.MaterializedThrough != nil {
+		next := recurrence.Date(*series.MaterializedThrough).AddDate(0, 0, 1)
+		if next.After(from) {
+			from = next
+		}
+	}
+	through = recurrence.Date(through)
+	if through.Before(from) {
+		return nil, nil
+	}
+
+	// Dates already recorded (materialized, cancelled or excluded) are skipped
+	recorded, err := s.seriesRepo.FindOccurrenceDatesTx(ctx, tx, series.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var created []*model.Event
+	for _, date := range series.Rule(recorded).Between(from, through) {
+		event := series.NewOccurrence(date, shortcode.Generate())
+		if err := s.eventRepo.CreateTx(ctx, tx, event); err != nil {
+			return nil, err
+		}
+		occ := &model.SeriesOccurrence{
+			SeriesID:       series.ID,
+			OccurrenceDate: date,
+			EventID:        &event.ID,
+		}
+		if err := s.seriesRepo.CreateOccurrenceTx(ctx, tx, occ); err != nil {
+			return nil, err
+		}
+		created = append(created, event)
+	}
+
+	if err := s.seriesRepo.SetMaterializedThroughTx(ctx, tx, series.ID, through); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// MaterializeActive materializes every active series up to the default horizon.
+// Returns the number of events created.
+func (s *SeriesService) MaterializeActive(ctx context.Context) (int, error) {
+	ids, err := s.seriesRepo.FindActiveIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	through := time.Now().Add(DefaultMaterializeHorizon)
+	created := 0
+	for _, id := range ids {
+		events, err := s.Materialize(ctx, id, through)
+		if err != nil {
+			return created, err
+		}
+		created += len(events)
+	}
+	return created, nil
+}
+
+// UpdateOccurrence applies changes to a single occurrence and detaches it from
+// series-wide edits
+func (s *SeriesService) UpdateOccurrence(ctx context.Context, seriesID, eventID uuid.UUID, changes SeriesChanges) (*model.Event, error) {
+	occ, err := s.findOccurrence(ctx, seriesID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return database.WithTxResult(s.txManager, ctx, func(tx *sqlx.Tx) (*model.Event, error) {
+		event, err := s.eventRepo.FindByIDForUpdate(ctx, tx, *occ.EventID)
+		if err != nil {
+			return nil, err
+		}
+		if event.Status.IsFinal() {
+			return nil, ErrOccurrenceClosed
+		}
+
+		if err := s.updateOccurrenceTx(ctx, tx, event, changes); err != nil {
+			return nil, err
+		}
+		if err := s.seriesRepo.MarkDetachedTx(ctx, tx, eventID); err != nil {
+			return nil, err
+		}
+		return event, nil
+	})
+}
+
+// UpdateFutureOccurrences applies changes to the series template and to every
+// occurrence from the given one onward that has not been edited individually.
+// Returns the IDs of the events that were updated.
+func (s *SeriesService) UpdateFutureOccurrences(ctx context.Context, seriesID, eventID uuid.UUID, changes SeriesChanges) ([]uuid.UUID, error) {
+	occ, err := s.findOccurrence(ctx, seriesID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return database.WithTxResult(s.txManager, ctx, func(tx *sqlx.Tx) ([]uuid.UUID, error) {
+		series, err := s.seriesRepo.FindByIDForUpdate(ctx, tx, seriesID)
+		if err != nil {
+			return nil, err
+		}
+		if series.Status != model.SeriesStatusActive {
+			return nil, ErrSeriesEnded
+		}
+
+		changes.ApplyToSeries(series)
+		if err := s.seriesRepo.UpdateTemplateTx(ctx, tx, series); err != nil {
+			return nil, err
+		}
+
+		eventIDs, err := s.seriesRepo.FindFutureEventIDsTx(ctx, tx, seriesID, occ.OccurrenceDate)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range eventIDs {
+			event, err := s.eventRepo.FindByIDForUpdate(ctx, tx, id)
+			if err != nil {
+				return nil, err
+			}
+			if event.Status.IsFinal() {
+				return nil, ErrOccurrenceClosed
+			}
+			if err := s.updateOccurrenceTx(ctx, tx, event, changes); err != nil {
+				return nil, err
+			}
+		}
+		return eventIDs, nil
+	})
+}
+
+// CancelOccurrence cancels a single occurrence and its registrations without
+// touching the rest of the series. The occurrence date stays recorded so it is
+// never materialized again.
+func (s *SeriesService) CancelOccurrence(ctx context.Context, seriesID, eventID uuid.UUID) error {
+	occ, err := s.findOccurrence(ctx, seriesID, eventID)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.WithTx(ctx, func(tx *sqlx.Tx) error {
+		event, err := s.eventRepo.FindByIDForUpdate(ctx, tx, *occ.EventID)
+		if err != nil {
+			return err
+		}
+		if event.Status.IsFinal() {
+			return ErrOccurrenceClosed
+		}
+		return s.cancelOccurrenceTx(ctx, tx, event)
+	})
+}
+
+// EndSeries stops materializing a series and cancels its upcoming occurrences.
+// The series is only ended if every occurrence is cancelled.
+func (s *SeriesService) EndSeries(ctx context.Context, seriesID uuid.UUID) error {
+	return s.txManager.WithTx(ctx, func(tx *sqlx.Tx) error {
+		series, err := s.seriesRepo.FindByIDForUpdate(ctx, tx, seriesID)
+		if err != nil {
+			return err
+		}
+		if series.Status == model.SeriesStatusEnded {
+			return ErrSeriesEnded
+		}
+		if err := s.seriesRepo.UpdateStatusTx(ctx, tx, seriesID, model.SeriesStatusEnded); err != nil {
+			return err
+		}
+
+		eventIDs, err := s.seriesRepo.FindUpcomingEventIDsTx(ctx, tx, seriesID, recurrence.Date(time.Now()))
+		if err != nil {
+			return err
+		}
+		for _, id := range eventIDs {
+			event, err := s.eventRepo.FindByIDForUpdate(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			if err := s.cancelOccurrenceTx(ctx, tx, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// cancelOccurrenceTx cancels an occurrence and its registrations and notifies
+// everyone who was registered
+func (s *SeriesService) cancelOccurrenceTx(ctx context.Context, tx *sqlx.Tx, event *model.Event) error {
+	if err := s.eventRepo.UpdateStatusTx(ctx, tx, event.ID, model.EventStatusCancelled); err != nil {
+		return err
+	}
+	userIDs, err := s.registrationRepo.CancelAllByEventIDTx(ctx, tx, event.ID)
+	if err != nil {
+		return err
+	}
+	return s.outboxRepo.EnqueueNotificationsTx(ctx, tx, model.NewEventCancelledNotifications(userIDs, event)...)
+}
+
+// updateOccurrenceTx applies changes to a locked occurrence. A new capacity
+// moves registrations between confirmed and waitlisted and resyncs the
+// event's status, the same as editing a standalone event.
+func (s *SeriesService) updateOccurrenceTx(ctx context.Context, tx *sqlx.Tx, event *model.Event, changes SeriesChanges) error {
+	before := *event
+	changes.ApplyToEvent(event)
+	if err := s.eventRepo.UpdateTx(ctx, tx, event); err != nil {
+		return err
+	}
+
+	if event.Capacity != before.Capacity {
+		capacityChange, err := s.registrationRepo.ResizeTx(ctx, tx, event.ID, event.Capacity, changes.CapacityPolicy)
+		if err != nil {
+			return err
+		}
+		if err := s.outboxRepo.EnqueueNotificationsTx(ctx, tx, capacityChange.Notifications(event)...); err != nil {
+			return err
+		}
+	}
+	if err := s.notifyUpdatedTx(ctx, tx, &before, event); err != nil {
+		return err
+	}
+	return s.registrationRepo.SyncEventStatusTx(ctx, tx, event.ID)
+}
+
+// notifyUpdatedTx notifies everyone registered for an occurrence of material
+// changes to it
+func (s *SeriesService) notifyUpdatedTx(ctx context.Context, tx *sqlx.Tx, before, after *model.Event) error {
+	changes := model.DiffEvent(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+	userIDs, err := s.registrationRepo.FindParticipantIDsTx(ctx, tx, after.ID)
+	if err != nil {
+		return err
+	}
+	return s.outboxRepo.EnqueueNotificationsTx(ctx, tx, model.NewEventUpdatedNotifications(userIDs, after, changes)...)
+}
+
+// findOccurrence finds the occurrence for an event and checks it belongs to the series
+func (s *SeriesService) findOccurrence(ctx context.Context, seriesID, eventID uuid.UUID) (*model.SeriesOccurrence, error) {
+	occ, err := s.seriesRepo.FindOccurrenceByEventID(ctx, eventID)
+	if err != nil {
+		return nil, ErrOccurrenceNotFound
+	}
+	if occ.SeriesID != seriesID || occ.EventID == nil {
+		return nil, ErrOccurrenceNotFound
+	}
+	return occ, nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/anthropics/pickle-go/apps/api/internal/database"
+	"github.com/anthropics/pickle-go/apps/api/internal/model"
+	"github.com/anthropics/pickle-go/apps/api/internal/repository"
+	"github.com/jmoiron/sqlx"
+)
+
+// expiredOfferBatchSize limits how many expired offers are processed per sweep
+const expiredOfferBatchSize = 100
+
+// WaitlistService handles waitlist offer business logic
+type WaitlistService struct {
+	registrationRepo *repository.RegistrationRepository
+	eventRepo        *repository.EventRepository
+	outboxRepo       *repository.OutboxRepository
+	txManager        *database.TxManager
+	offerWindow      time.Duration
+}
+
+// NewWaitlistService creates a new WaitlistService
+func NewWaitlistService(registrationRepo *repository.RegistrationRepository, eventRepo *repository.EventRepository, outboxRepo *repository.OutboxRepository, txManager *database.TxManager, offerWindow time.Duration) *WaitlistService {
+	return &WaitlistService{
+		registrationRepo: registrationRepo,
+		eventRepo:        eventRepo,
+		outboxRepo:       outboxRepo,
+		txManager:        txManager,
+		offerWindow:      offerWindow,
+	}
+}
+
+// ExpireOffers releases every waitlist offer whose hold window has passed and
+// offers the freed seats to the next waitlisted users. Returns the number of offers expired.
+func (s *WaitlistService) ExpireOffers(ctx context.Context) (int, error) {
+	offers, err := s.registrationRepo.FindExpiredOffers(ctx, expiredOfferBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, offer := range offers {
+		offer := offer
+		err := s.txManager.WithTx(ctx, func(tx *sqlx.Tx) error {
+			next, txErr := s.registrationRepo.ExpireOffer(ctx, tx, offer.ID, s.offerWindow)
+			if txErr != nil {
+				return txErr
+			}
+			return s.notifyExpiredTx(ctx, tx, &offer, next)
+		})
+		if errors.Is(err, repository.ErrNoOffer) {
+			// Accepted or declined since it was listed
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// notifyExpiredTx enqueues notifications telling the previous holder their offer
+// expired and the next users that the spot is theirs to accept
+func (s *WaitlistService) notifyExpiredTx(ctx context.Context, tx *sqlx.Tx, offer *model.Registration, next []model.Registration) error {
+	event, err := s.eventRepo.FindByIDTx(ctx, tx, offer.EventID)
+	if err != nil {
+		return err
+	}
+
+	notifications := []*model.Notification{
+		model.NewOfferExpiredNotification(offer.UserID, event.ID, event.GetNotificationTitle()),
+	}
+	for _, reg := range next {
+		if reg.OfferExpiresAt != nil {
+			notifications = append(notifications, model.NewWaitlistOfferNotification(reg.UserID, event.ID, event.GetNotificationTitle(), *reg.OfferExpiresAt))
+		}
+	}
+	return s.outboxRepo.EnqueueNotificationsTx(ctx, tx, notifications...)
+}
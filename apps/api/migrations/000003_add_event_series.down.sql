@@ -0,0 +1,7 @@
+-- Pickle Go Recurring Event Series Rollback
+-- Version: 000003
+-- Description: Drop recurring event series tables
+
+DROP TABLE IF EXISTS event_series_occurrences;
+DROP TRIGGER IF EXISTS trigger_event_series_updated_at ON event_series;
+DROP TABLE IF EXISTS event_series;
@@ -0,0 +1,81 @@
+-- Pickle Go Recurring Event Series Migration
+-- Version: 000003
+-- Description: Add recurring event series and the occurrences materialized from them
+
+-- ============================================
+-- Event Series Table
+-- ============================================
+-- A series is a template plus a recurrence rule. Occurrences are materialized
+-- ahead of time as regular rows in the events table so that each occurrence
+-- keeps its own registrations and waitlist.
+CREATE TABLE IF NOT EXISTS event_series (
+    id                      UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    host_id                 UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Template applied to every materialized occurrence
+    title                   VARCHAR(200),
+    description             TEXT,
+    start_time              TIME NOT NULL,
+    end_time                TIME,
+    location_name           VARCHAR(200) NOT NULL,
+    location_address        VARCHAR(500),
+    location_point          GEOGRAPHY(POINT, 4326) NOT NULL,
+    google_place_id         VARCHAR(255),
+    capacity                SMALLINT NOT NULL CHECK (capacity >= 4 AND capacity <= 20),
+    skill_level             VARCHAR(20) NOT NULL CHECK (skill_level IN ('beginner', 'intermediate', 'advanced', 'expert', 'any')),
+    fee                     INTEGER DEFAULT 0 CHECK (fee >= 0 AND fee <= 9999),
+
+    -- Recurrence rule (RRULE-style)
+    frequency               VARCHAR(20) NOT NULL CHECK (frequency IN ('weekly', 'biweekly', 'monthly')),
+    weekdays                SMALLINT[] NOT NULL DEFAULT '{}',
+    month_day               SMALLINT CHECK (month_day >= 1 AND month_day <= 31),
+    starts_on               DATE NOT NULL,
+    ends_on                 DATE,
+
+    -- Last date up to which occurrences have been materialized
+    materialized_through    DATE,
+
+    status                  VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'ended')),
+
+    created_at              TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at              TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CHECK (ends_on IS NULL OR ends_on >= starts_on)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_series_host_id ON event_series(host_id);
+CREATE INDEX IF NOT EXISTS idx_event_series_active
+    ON event_series(materialized_through)
+    WHERE status = 'active';
+
+CREATE TRIGGER trigger_event_series_updated_at
+    BEFORE UPDATE ON event_series
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================
+-- Event Series Occurrences Table
+-- ============================================
+-- One row per occurrence date of a series. Rows without an event_id are
+-- exceptions (dates excluded from the rule). The unique constraint guarantees
+-- that a date is never materialized twice, and that a cancelled occurrence is
+-- not re-created by a later materialization run.
+CREATE TABLE IF NOT EXISTS event_series_occurrences (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    series_id           UUID NOT NULL REFERENCES event_series(id) ON DELETE CASCADE,
+    occurrence_date     DATE NOT NULL,
+    event_id            UUID REFERENCES events(id) ON DELETE SET NULL,
+
+    -- Excluded from the rule, never materialized
+    is_exception        BOOLEAN NOT NULL DEFAULT FALSE,
+    -- Edited as a single occurrence, no longer follows series-wide edits
+    is_detached         BOOLEAN NOT NULL DEFAULT FALSE,
+
+    created_at          TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    UNIQUE(series_id, occurrence_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_series_occurrences_event_id
+    ON event_series_occurrences(event_id)
+    WHERE event_id IS NOT NULL;
@@ -0,0 +1,13 @@
+-- Pickle Go Waitlist Offers Rollback
+-- Version: 000004
+-- Description: Remove waitlist offers
+
+-- Outstanding offers go back to the head of the waitlist
+UPDATE registrations SET status = 'waitlist', waitlist_position = 0 WHERE status = 'offered';
+
+DROP INDEX IF EXISTS idx_registrations_offer_expires_at;
+ALTER TABLE registrations DROP COLUMN IF EXISTS offer_expires_at;
+
+ALTER TABLE registrations DROP CONSTRAINT IF EXISTS registrations_status_check;
+ALTER TABLE registrations ADD CONSTRAINT registrations_status_check
+    CHECK (status IN ('confirmed', 'waitlist', 'cancelled'));
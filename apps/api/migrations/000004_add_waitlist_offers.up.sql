@@ -0,0 +1,22 @@
+-- Pickle Go Waitlist Offers Migration
+-- Version: 000004
+-- Description: Hold freed spots for the next waitlisted player until they accept
+
+-- ============================================
+-- Registration Status
+-- ============================================
+-- 'offered' registrations hold a spot (they count against capacity) until the
+-- player accepts, declines, or the offer expires.
+ALTER TABLE registrations DROP CONSTRAINT IF EXISTS registrations_status_check;
+ALTER TABLE registrations ADD CONSTRAINT registrations_status_check
+    CHECK (status IN ('confirmed', 'waitlist', 'offered', 'cancelled'));
+
+ALTER TABLE registrations ADD COLUMN IF NOT EXISTS offer_expires_at TIMESTAMP WITH TIME ZONE;
+
+-- ============================================
+-- Indexes
+-- ============================================
+-- Used by the expiry sweeper
+CREATE INDEX IF NOT EXISTS idx_registrations_offer_expires_at
+    ON registrations(offer_expires_at)
+    WHERE status = 'offered';
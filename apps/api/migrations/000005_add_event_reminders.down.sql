@@ -0,0 +1,6 @@
+-- Pickle Go Event Reminders Rollback
+-- Version: 000005
+-- Description: Remove event reminders
+
+DROP INDEX IF EXISTS idx_event_reminders_user_id;
+DROP TABLE IF EXISTS event_reminders;
@@ -0,0 +1,25 @@
+-- Pickle Go Event Reminders Migration
+-- Version: 000005
+-- Description: Record sent event reminders so each one is delivered only once
+
+-- ============================================
+-- Event Reminders Table
+-- ============================================
+-- One row per reminder sent to a user for an event. The unique key is claimed
+-- before the notification is created, so a reminder is never sent twice even
+-- if the job is retried.
+CREATE TABLE IF NOT EXISTS event_reminders (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id    UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id     UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- e.g. 'confirmed_24h', 'waitlist_24h'
+    kind        VARCHAR(30) NOT NULL,
+    sent_at     TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT event_reminders_unique UNIQUE (event_id, user_id, kind)
+);
+
+-- ============================================
+-- Indexes
+-- ============================================
+CREATE INDEX IF NOT EXISTS idx_event_reminders_user_id ON event_reminders(user_id);
@@ -0,0 +1,9 @@
+-- Pickle Go Notification Delivery Rollback
+-- Version: 000006
+-- Description: Remove notification deliveries
+
+DROP TRIGGER IF EXISTS trigger_notification_deliveries_updated_at ON notification_deliveries;
+DROP INDEX IF EXISTS idx_notification_deliveries_pending;
+DROP TABLE IF EXISTS notification_deliveries;
+
+ALTER TABLE users DROP COLUMN IF EXISTS line_friend;
@@ -0,0 +1,45 @@
+-- Pickle Go Notification Delivery Migration
+-- Version: 000006
+-- Description: Push notifications to LINE and record delivery status per notification
+
+-- ============================================
+-- Users
+-- ============================================
+-- Whether the user has added the LINE bot as a friend (kept in sync by the
+-- follow/unfollow webhook). Push messages can only reach friends.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS line_friend BOOLEAN NOT NULL DEFAULT false;
+
+-- ============================================
+-- Notification Deliveries Table
+-- ============================================
+-- One row per notification and delivery channel. Rows are created together
+-- with the notification and updated by the delivery job.
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    notification_id     UUID NOT NULL REFERENCES notifications(id) ON DELETE CASCADE,
+    channel             VARCHAR(20) NOT NULL CHECK (channel IN ('line')),
+    status              VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed', 'skipped')),
+    attempts            INTEGER NOT NULL DEFAULT 0,
+    last_error          TEXT,
+    sent_at             TIMESTAMP WITH TIME ZONE,
+    created_at          TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at          TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT notification_deliveries_unique UNIQUE (notification_id, channel)
+);
+
+-- ============================================
+-- Indexes
+-- ============================================
+-- Used by the delivery job
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_pending
+    ON notification_deliveries(created_at)
+    WHERE status = 'pending';
+
+-- ============================================
+-- Triggers
+-- ============================================
+CREATE TRIGGER trigger_notification_deliveries_updated_at
+    BEFORE UPDATE ON notification_deliveries
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,7 @@
+-- Pickle Go Transactional Outbox Rollback
+-- Version: 000007
+-- Description: Remove the outbox
+
+DROP INDEX IF EXISTS idx_outbox_dead;
+DROP INDEX IF EXISTS idx_outbox_due;
+DROP TABLE IF EXISTS outbox;
@@ -0,0 +1,35 @@
+-- Pickle Go Transactional Outbox Migration
+-- Version: 000007
+-- Description: Record side effects in the same transaction as the state change that causes them
+
+-- ============================================
+-- Outbox Table
+-- ============================================
+-- Messages are written inside the business transaction and delivered
+-- at-least-once by the outbox dispatcher. A claimed message's next_attempt_at
+-- is pushed forward by a lease, so a dispatcher that dies mid-delivery only
+-- delays the message. Messages that keep failing are marked 'dead'.
+CREATE TABLE IF NOT EXISTS outbox (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    topic               VARCHAR(100) NOT NULL,
+    payload             JSONB NOT NULL,
+    status              VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'done', 'dead')),
+    attempts            INTEGER NOT NULL DEFAULT 0,
+    last_error          TEXT,
+    next_attempt_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at          TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    processed_at        TIMESTAMP WITH TIME ZONE
+);
+
+-- ============================================
+-- Indexes
+-- ============================================
+-- Used by the dispatcher
+CREATE INDEX IF NOT EXISTS idx_outbox_due
+    ON outbox(next_attempt_at)
+    WHERE status = 'pending';
+
+-- Used to inspect dead letters
+CREATE INDEX IF NOT EXISTS idx_outbox_dead
+    ON outbox(created_at)
+    WHERE status = 'dead';
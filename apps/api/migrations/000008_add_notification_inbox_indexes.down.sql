@@ -0,0 +1,6 @@
+-- Pickle Go Notification Inbox Indexes Rollback
+-- Version: 000008
+-- Description: Remove notification inbox indexes
+
+DROP INDEX IF EXISTS idx_notifications_user_unread;
+DROP INDEX IF EXISTS idx_notifications_user_created;
@@ -0,0 +1,15 @@
+-- Pickle Go Notification Inbox Indexes Migration
+-- Version: 000008
+-- Description: Support cursor pagination and unread counts in the notification inbox
+
+-- ============================================
+-- Indexes
+-- ============================================
+-- Used to page through a user's inbox, newest first
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created
+    ON notifications(user_id, created_at DESC, id DESC);
+
+-- Used for the unread badge count
+CREATE INDEX IF NOT EXISTS idx_notifications_user_unread
+    ON notifications(user_id)
+    WHERE is_read = false;
@@ -0,0 +1,10 @@
+-- Pickle Go Notification Preferences Rollback
+-- Version: 000009
+-- Description: Remove notification preferences and quiet hours
+
+DROP TRIGGER IF EXISTS trigger_notification_settings_updated_at ON notification_settings;
+DROP TRIGGER IF EXISTS trigger_notification_preferences_updated_at ON notification_preferences;
+ALTER TABLE notification_deliveries DROP COLUMN IF EXISTS deliver_after;
+ALTER TABLE notifications DROP COLUMN IF EXISTS in_app;
+DROP TABLE IF EXISTS notification_settings;
+DROP TABLE IF EXISTS notification_preferences;
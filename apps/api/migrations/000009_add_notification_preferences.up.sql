@@ -0,0 +1,61 @@
+-- Pickle Go Notification Preferences Migration
+-- Version: 000009
+-- Description: Let users choose which notifications they receive on which channel, and set quiet hours
+
+-- ============================================
+-- Notification Preferences Table
+-- ============================================
+-- One row per user, notification type and channel the user has changed.
+-- A missing row means the notification is enabled.
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id             UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type                VARCHAR(50) NOT NULL,
+    channel             VARCHAR(20) NOT NULL CHECK (channel IN ('in_app', 'line')),
+    enabled             BOOLEAN NOT NULL,
+    created_at          TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at          TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    PRIMARY KEY (user_id, type, channel)
+);
+
+-- ============================================
+-- Notification Settings Table
+-- ============================================
+-- Quiet hours are wall-clock times in the user's time zone. A window whose
+-- end is before its start runs overnight, e.g. 22:00 to 07:00.
+CREATE TABLE IF NOT EXISTS notification_settings (
+    user_id             UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    timezone            VARCHAR(64) NOT NULL DEFAULT 'Asia/Taipei',
+    quiet_hours_start   TIME,
+    quiet_hours_end     TIME,
+    created_at          TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at          TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT notification_settings_quiet_hours CHECK ((quiet_hours_start IS NULL) = (quiet_hours_end IS NULL))
+);
+
+-- ============================================
+-- Notifications
+-- ============================================
+-- Notifications the user turned off in-app are still recorded for the other
+-- channels, but hidden from the inbox
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS in_app BOOLEAN NOT NULL DEFAULT true;
+
+-- ============================================
+-- Notification Deliveries
+-- ============================================
+-- Deliveries created during quiet hours wait until they end
+ALTER TABLE notification_deliveries ADD COLUMN IF NOT EXISTS deliver_after TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+
+-- ============================================
+-- Triggers
+-- ============================================
+CREATE TRIGGER trigger_notification_preferences_updated_at
+    BEFORE UPDATE ON notification_preferences
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER trigger_notification_settings_updated_at
+    BEFORE UPDATE ON notification_settings
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,9 @@
+-- Pickle Go Notification Digests Rollback
+-- Version: 000010
+-- Description: Remove notification digests
+
+DROP INDEX IF EXISTS idx_notifications_digest_pending;
+ALTER TABLE notifications DROP COLUMN IF EXISTS digest_id;
+ALTER TABLE notifications DROP COLUMN IF EXISTS digest_pending;
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS last_digest_at;
+ALTER TABLE notification_settings DROP COLUMN IF EXISTS digest;
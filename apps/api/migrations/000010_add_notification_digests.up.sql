@@ -0,0 +1,29 @@
+-- Pickle Go Notification Digests Migration
+-- Version: 000010
+-- Description: Let users batch their LINE notifications into a daily or weekly digest
+
+-- ============================================
+-- Notification Settings
+-- ============================================
+ALTER TABLE notification_settings
+    ADD COLUMN IF NOT EXISTS digest VARCHAR(10) NOT NULL DEFAULT 'off'
+        CHECK (digest IN ('off', 'daily', 'weekly')),
+    ADD COLUMN IF NOT EXISTS last_digest_at TIMESTAMP WITH TIME ZONE;
+
+-- ============================================
+-- Notifications
+-- ============================================
+-- Notifications held for the next digest instead of being pushed on their own.
+-- Once the digest is sent they point at the digest notification, which is
+-- pushed to LINE but not shown in the inbox.
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS digest_pending BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS digest_id UUID REFERENCES notifications(id) ON DELETE SET NULL;
+
+-- ============================================
+-- Indexes
+-- ============================================
+-- Used to collect the notifications waiting for a user's digest
+CREATE INDEX IF NOT EXISTS idx_notifications_digest_pending
+    ON notifications(user_id, created_at)
+    WHERE digest_pending = true;
@@ -0,0 +1,10 @@
+-- Pickle Go Realtime Notifications Rollback
+-- Version: 000011
+-- Description: Stop publishing changes for live streams
+
+DROP TRIGGER IF EXISTS trigger_notifications_notify_created ON notifications;
+DROP TRIGGER IF EXISTS trigger_events_notify_change ON events;
+DROP TRIGGER IF EXISTS trigger_registrations_notify_change ON registrations;
+DROP FUNCTION IF EXISTS notify_notification_created();
+DROP FUNCTION IF EXISTS notify_event_change();
+DROP FUNCTION IF EXISTS notify_registration_change();
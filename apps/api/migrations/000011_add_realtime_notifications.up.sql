@@ -0,0 +1,85 @@
+-- Pickle Go Realtime Notifications Migration
+-- Version: 000011
+-- Description: Publish registration, event and notification changes with pg_notify for live streams
+
+-- ============================================
+-- Functions
+-- ============================================
+-- Every change is published on the realtime_changes channel as a small JSON
+-- object saying what changed, e.g. {"type": "event", "event_id": "..."}.
+-- Listeners reload the current state themselves. Identical notifications in one
+-- transaction are delivered once, so bulk updates do not flood listeners.
+
+-- Registration changes move an event's counts and, for the registered user,
+-- their status or waitlist position
+CREATE OR REPLACE FUNCTION notify_registration_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    reg registrations;
+    status_changed BOOLEAN := true;
+    position_changed BOOLEAN := true;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        reg := OLD;
+    ELSE
+        reg := NEW;
+    END IF;
+
+    IF TG_OP = 'UPDATE' THEN
+        status_changed := NEW.status IS DISTINCT FROM OLD.status;
+        position_changed := NEW.waitlist_position IS DISTINCT FROM OLD.waitlist_position
+            OR NEW.offer_expires_at IS DISTINCT FROM OLD.offer_expires_at;
+    END IF;
+
+    IF status_changed THEN
+        PERFORM pg_notify('realtime_changes',
+            json_build_object('type', 'event', 'event_id', reg.event_id)::text);
+    END IF;
+
+    IF status_changed OR position_changed THEN
+        PERFORM pg_notify('realtime_changes',
+            json_build_object('type', 'registration', 'event_id', reg.event_id, 'user_id', reg.user_id)::text);
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION notify_event_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('realtime_changes',
+        json_build_object('type', 'event', 'event_id', NEW.id)::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION notify_notification_created()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('realtime_changes',
+        json_build_object('type', 'notification', 'user_id', NEW.user_id, 'notification_id', NEW.id)::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- ============================================
+-- Triggers
+-- ============================================
+CREATE TRIGGER trigger_registrations_notify_change
+    AFTER INSERT OR UPDATE OR DELETE ON registrations
+    FOR EACH ROW
+    EXECUTE FUNCTION notify_registration_change();
+
+CREATE TRIGGER trigger_events_notify_change
+    AFTER UPDATE OF status, capacity ON events
+    FOR EACH ROW
+    WHEN (OLD.status IS DISTINCT FROM NEW.status OR OLD.capacity IS DISTINCT FROM NEW.capacity)
+    EXECUTE FUNCTION notify_event_change();
+
+-- Only notifications shown in the inbox are streamed
+CREATE TRIGGER trigger_notifications_notify_created
+    AFTER INSERT ON notifications
+    FOR EACH ROW
+    WHEN (NEW.in_app)
+    EXECUTE FUNCTION notify_notification_created();
@@ -0,0 +1,5 @@
+-- Pickle Go Check-In Rollback
+-- Version: 000012
+-- Description: Remove attendance check-in
+
+ALTER TABLE registrations DROP COLUMN IF EXISTS checked_in_at;
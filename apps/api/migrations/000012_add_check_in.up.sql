@@ -0,0 +1,8 @@
+-- Pickle Go Check-In Migration
+-- Version: 000012
+-- Description: Record attendance when the host checks players in at the court
+
+-- ============================================
+-- Registrations
+-- ============================================
+ALTER TABLE registrations ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,6 @@
+-- Pickle Go Attendance Rollback
+-- Version: 000013
+-- Description: Remove attendance marking and minimum reliability
+
+ALTER TABLE events DROP COLUMN IF EXISTS min_reliability;
+ALTER TABLE registrations DROP COLUMN IF EXISTS attendance;
@@ -0,0 +1,18 @@
+-- Pickle Go Attendance Migration
+-- Version: 000013
+-- Description: Let hosts mark who attended or did not show up, and require a minimum reliability to register
+
+-- ============================================
+-- Registrations
+-- ============================================
+-- attended or no_show, marked by the host after the event starts.
+-- Players who were checked in count as attended until marked otherwise.
+ALTER TABLE registrations ADD COLUMN IF NOT EXISTS attendance VARCHAR(20)
+    CHECK (attendance IN ('attended', 'no_show'));
+
+-- ============================================
+-- Events
+-- ============================================
+-- Minimum reliability score (0-100) a player needs to register; NULL for none
+ALTER TABLE events ADD COLUMN IF NOT EXISTS min_reliability SMALLINT
+    CHECK (min_reliability BETWEEN 0 AND 100);
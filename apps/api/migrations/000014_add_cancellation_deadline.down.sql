@@ -0,0 +1,7 @@
+-- Pickle Go Cancellation Deadline Rollback
+-- Version: 000014
+-- Description: Remove cancellation deadlines and late cancellation flags
+
+DROP INDEX IF EXISTS idx_registrations_event_late_cancelled;
+ALTER TABLE registrations DROP COLUMN IF EXISTS late_cancelled;
+ALTER TABLE events DROP COLUMN IF EXISTS cancel_deadline_hours;
@@ -0,0 +1,21 @@
+-- Pickle Go Cancellation Deadline Migration
+-- Version: 000014
+-- Description: Let hosts set a cancellation deadline and flag confirmed players who cancel after it
+
+-- ============================================
+-- Events
+-- ============================================
+-- Hours before the start after which cancelling counts as late; NULL for no deadline
+ALTER TABLE events ADD COLUMN IF NOT EXISTS cancel_deadline_hours SMALLINT
+    CHECK (cancel_deadline_hours BETWEEN 1 AND 168);
+
+-- ============================================
+-- Registrations
+-- ============================================
+-- Set when a confirmed player cancels after the event's deadline
+ALTER TABLE registrations ADD COLUMN IF NOT EXISTS late_cancelled BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Late cancellations shown to the host
+CREATE INDEX IF NOT EXISTS idx_registrations_event_late_cancelled
+    ON registrations(event_id)
+    WHERE late_cancelled;
@@ -0,0 +1,6 @@
+-- Pickle Go Spot Transfer Rollback
+-- Version: 000015
+-- Description: Remove spot transfers
+
+DROP TABLE IF EXISTS spot_transfers;
+ALTER TABLE events DROP COLUMN IF EXISTS transfer_requires_approval;
@@ -0,0 +1,42 @@
+-- Pickle Go Spot Transfer Migration
+-- Version: 000015
+-- Description: Let confirmed players hand their spot to someone else through a one-time link
+
+-- ============================================
+-- Events
+-- ============================================
+-- Whether the host must approve a transfer before the recipient takes the spot
+ALTER TABLE events ADD COLUMN IF NOT EXISTS transfer_requires_approval BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- ============================================
+-- Spot Transfers Table
+-- ============================================
+-- One row per transfer link, kept as the history of who handed a spot to whom
+CREATE TABLE IF NOT EXISTS spot_transfers (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id            UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    registration_id     UUID NOT NULL REFERENCES registrations(id) ON DELETE CASCADE,
+    from_user_id        UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_user_id          UUID REFERENCES users(id) ON DELETE SET NULL,
+
+    -- SHA-256 of the link token; the token itself is only shown to the sender
+    token_hash          VARCHAR(64) UNIQUE NOT NULL,
+    status              VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'awaiting_approval', 'completed', 'rejected', 'revoked')),
+
+    -- Waitlisted players the recipient went ahead of when the transfer completed
+    waitlist_bypassed   SMALLINT NOT NULL DEFAULT 0,
+
+    expires_at          TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at          TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    accepted_at         TIMESTAMP WITH TIME ZONE,
+    resolved_at         TIMESTAMP WITH TIME ZONE
+);
+
+-- At most one open transfer per registration
+CREATE UNIQUE INDEX IF NOT EXISTS idx_spot_transfers_open_registration
+    ON spot_transfers(registration_id)
+    WHERE status IN ('pending', 'awaiting_approval');
+
+-- Transfer history shown to the host
+CREATE INDEX IF NOT EXISTS idx_spot_transfers_event ON spot_transfers(event_id, created_at DESC);
@@ -0,0 +1,7 @@
+-- Pickle Go Guest Seats Rollback
+-- Version: 000016
+-- Description: Remove guest seats
+
+DROP TABLE IF EXISTS registration_guests;
+ALTER TABLE registrations DROP COLUMN IF EXISTS guest_count;
+ALTER TABLE events DROP COLUMN IF EXISTS max_guests;
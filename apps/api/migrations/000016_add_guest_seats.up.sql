@@ -0,0 +1,33 @@
+-- Pickle Go Guest Seats Migration
+-- Version: 000016
+-- Description: Let players bring friends who are not on the platform as guests on their registration
+
+-- ============================================
+-- Events
+-- ============================================
+-- How many guests each player may bring (0 disallows guests)
+ALTER TABLE events ADD COLUMN IF NOT EXISTS max_guests SMALLINT NOT NULL DEFAULT 0
+    CHECK (max_guests >= 0);
+
+-- ============================================
+-- Registrations
+-- ============================================
+-- Active guests on the registration; the registration takes 1 + guest_count seats
+ALTER TABLE registrations ADD COLUMN IF NOT EXISTS guest_count SMALLINT NOT NULL DEFAULT 0
+    CHECK (guest_count >= 0);
+
+-- ============================================
+-- Registration Guests Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS registration_guests (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    registration_id     UUID NOT NULL REFERENCES registrations(id) ON DELETE CASCADE,
+    name                VARCHAR(50) NOT NULL,
+    created_at          TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    -- Set when the guest's seat is released on its own or the player re-registers
+    cancelled_at        TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_registration_guests_active
+    ON registration_guests(registration_id, created_at)
+    WHERE cancelled_at IS NULL;
@@ -0,0 +1,6 @@
+-- Pickle Go Registration Groups Rollback
+-- Version: 000017
+-- Description: Remove group registrations
+
+ALTER TABLE registrations DROP COLUMN IF EXISTS group_id;
+DROP TABLE IF EXISTS registration_groups;
@@ -0,0 +1,25 @@
+-- Pickle Go Registration Groups Migration
+-- Version: 000017
+-- Description: Let one player register a pair or a foursome together, all confirmed or all waitlisted
+
+-- ============================================
+-- Registration Groups Table
+-- ============================================
+-- One row per group registration; the members are the registrations pointing at it
+CREATE TABLE IF NOT EXISTS registration_groups (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id            UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    organizer_id        UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at          TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- ============================================
+-- Registrations
+-- ============================================
+-- Waitlisted members of a group share one waitlist position and are promoted together
+ALTER TABLE registrations ADD COLUMN IF NOT EXISTS group_id UUID
+    REFERENCES registration_groups(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_registrations_group
+    ON registrations(group_id)
+    WHERE group_id IS NOT NULL;
@@ -0,0 +1,16 @@
+-- Pickle Go Registration Approval Rollback
+-- Version: 000018
+-- Description: Remove registration approval
+
+-- Requests still waiting for the host, and rejected ones, are dropped
+UPDATE registrations SET status = 'cancelled', cancelled_at = COALESCE(cancelled_at, NOW())
+WHERE status IN ('pending', 'rejected');
+
+DROP INDEX IF EXISTS idx_registrations_pending;
+ALTER TABLE registrations DROP COLUMN IF EXISTS skill_level;
+
+ALTER TABLE registrations DROP CONSTRAINT IF EXISTS registrations_status_check;
+ALTER TABLE registrations ADD CONSTRAINT registrations_status_check
+    CHECK (status IN ('confirmed', 'waitlist', 'offered', 'cancelled'));
+
+ALTER TABLE events DROP COLUMN IF EXISTS requires_approval;
@@ -0,0 +1,30 @@
+-- Pickle Go Registration Approval Migration
+-- Version: 000018
+-- Description: Let hosts vet who joins by approving or rejecting registration requests
+
+-- ============================================
+-- Events
+-- ============================================
+-- Whether registrations wait for the host's approval before taking a seat
+ALTER TABLE events ADD COLUMN IF NOT EXISTS requires_approval BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- ============================================
+-- Registration Status
+-- ============================================
+-- 'pending' requests hold no seat until the host approves them; 'rejected' ones stay
+-- on record so the player cannot simply apply again.
+ALTER TABLE registrations DROP CONSTRAINT IF EXISTS registrations_status_check;
+ALTER TABLE registrations ADD CONSTRAINT registrations_status_check
+    CHECK (status IN ('confirmed', 'waitlist', 'offered', 'pending', 'rejected', 'cancelled'));
+
+-- Skill level the player states when applying, shown to the host
+ALTER TABLE registrations ADD COLUMN IF NOT EXISTS skill_level VARCHAR(20)
+    CHECK (skill_level IN ('beginner', 'intermediate', 'advanced', 'expert'));
+
+-- ============================================
+-- Indexes
+-- ============================================
+-- Used by the host's approval queue
+CREATE INDEX IF NOT EXISTS idx_registrations_pending
+    ON registrations(event_id, registered_at)
+    WHERE status = 'pending';
@@ -0,0 +1,5 @@
+-- Pickle Go Event Visibility Rollback
+-- Version: 000019
+-- Description: Remove event visibility, making every event public again
+
+ALTER TABLE events DROP COLUMN IF EXISTS visibility;
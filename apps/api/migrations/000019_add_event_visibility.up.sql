@@ -0,0 +1,11 @@
+-- Pickle Go Event Visibility Migration
+-- Version: 000019
+-- Description: Let hosts keep events off the map or restrict them to invited players
+
+-- ============================================
+-- Events
+-- ============================================
+-- public events are listed and open to everyone; unlisted events are only reachable
+-- by link; invite_only events are unlisted and also need an invite to register
+ALTER TABLE events ADD COLUMN IF NOT EXISTS visibility VARCHAR(20) NOT NULL DEFAULT 'public'
+    CHECK (visibility IN ('public', 'unlisted', 'invite_only'));
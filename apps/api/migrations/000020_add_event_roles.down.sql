@@ -0,0 +1,5 @@
+-- Pickle Go Event Roles Rollback
+-- Version: 000020
+-- Description: Remove event roles
+
+DROP TABLE IF EXISTS event_roles;
@@ -0,0 +1,26 @@
+-- Pickle Go Event Roles Migration
+-- Version: 000020
+-- Description: Let hosts share an event with co-hosts and check-in helpers
+
+-- ============================================
+-- Event Roles Table
+-- ============================================
+-- The host stays on events.host_id; this table holds everyone else helping run the event.
+-- A role only takes effect once the invited user has accepted it.
+CREATE TABLE IF NOT EXISTS event_roles (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id        UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role            VARCHAR(20) NOT NULL
+        CHECK (role IN ('co_host', 'check_in_helper')),
+    status          VARCHAR(20) NOT NULL DEFAULT 'invited'
+        CHECK (status IN ('invited', 'accepted')),
+    invited_by      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    accepted_at     TIMESTAMP WITH TIME ZONE,
+
+    UNIQUE (event_id, user_id)
+);
+
+-- Roles a user holds across events; lookups by event use the unique constraint
+CREATE INDEX IF NOT EXISTS idx_event_roles_user ON event_roles(user_id);
@@ -0,0 +1,5 @@
+-- Pickle Go Host Blocks Rollback
+-- Version: 000021
+-- Description: Remove host blocks
+
+DROP TABLE IF EXISTS host_blocks;
@@ -0,0 +1,18 @@
+-- Pickle Go Host Blocks Migration
+-- Version: 000021
+-- Description: Let hosts block players from joining their events
+
+-- ============================================
+-- Host Blocks Table
+-- ============================================
+-- A blocked user cannot register for any event hosted by the host who blocked them.
+-- The reason is a private note for the host and is never shown to the blocked user.
+CREATE TABLE IF NOT EXISTS host_blocks (
+    host_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason          VARCHAR(200),
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    PRIMARY KEY (host_id, user_id),
+    CHECK (host_id <> user_id)
+);
@@ -0,0 +1,12 @@
+-- Pickle Go Clubs Rollback
+-- Version: 000022
+-- Description: Remove clubs, making every event open to everyone again
+
+DROP INDEX IF EXISTS idx_events_club;
+ALTER TABLE events DROP COLUMN IF EXISTS member_priority_hours;
+ALTER TABLE events DROP COLUMN IF EXISTS registration_access;
+ALTER TABLE events DROP COLUMN IF EXISTS club_id;
+
+DROP TABLE IF EXISTS club_members;
+DROP TRIGGER IF EXISTS trigger_clubs_updated_at ON clubs;
+DROP TABLE IF EXISTS clubs;
@@ -0,0 +1,57 @@
+-- Pickle Go Clubs Migration
+-- Version: 000022
+-- Description: Let clubs run events together and keep some of them for their members
+
+-- ============================================
+-- Clubs Table
+-- ============================================
+CREATE TABLE IF NOT EXISTS clubs (
+    id              UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name            VARCHAR(100) NOT NULL,
+    description     TEXT,
+    created_by      UUID NOT NULL REFERENCES users(id),
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TRIGGER trigger_clubs_updated_at
+    BEFORE UPDATE ON clubs
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- ============================================
+-- Club Members Table
+-- ============================================
+-- A membership is invited when a club admin asked the user to join and requested
+-- when the user asked to join; it becomes active once the other side agrees.
+CREATE TABLE IF NOT EXISTS club_members (
+    club_id         UUID NOT NULL REFERENCES clubs(id) ON DELETE CASCADE,
+    user_id         UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role            VARCHAR(20) NOT NULL DEFAULT 'member'
+        CHECK (role IN ('owner', 'admin', 'member')),
+    status          VARCHAR(20) NOT NULL
+        CHECK (status IN ('invited', 'requested', 'active')),
+    invited_by      UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    joined_at       TIMESTAMP WITH TIME ZONE,
+
+    PRIMARY KEY (club_id, user_id)
+);
+
+-- Clubs a user belongs to; lookups by club use the primary key
+CREATE INDEX IF NOT EXISTS idx_club_members_user ON club_members(user_id);
+
+-- ============================================
+-- Events
+-- ============================================
+-- Club events are run by the club's owners and admins as well as host_id, the admin
+-- who created them. registration_access keeps an event to club members, or to club
+-- members until member_priority_hours before it starts.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS club_id UUID REFERENCES clubs(id) ON DELETE SET NULL;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS registration_access VARCHAR(20) NOT NULL DEFAULT 'everyone'
+    CHECK (registration_access IN ('everyone', 'members_only', 'members_first'));
+ALTER TABLE events ADD COLUMN IF NOT EXISTS member_priority_hours INTEGER
+    CHECK (member_priority_hours > 0);
+
+-- Upcoming events on a club's page
+CREATE INDEX IF NOT EXISTS idx_events_club ON events(club_id, event_date) WHERE club_id IS NOT NULL;
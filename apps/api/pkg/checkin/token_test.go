@@ -0,0 +1,74 @@
+package checkin
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	signer := NewSigner("test-secret")
+	registrationID := uuid.New()
+
+	token := signer.Sign(registrationID)
+	got, err := signer.Verify(token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != registrationID {
+		t.Errorf("expected %s, got %s", registrationID, got)
+	}
+}
+
+func TestVerify_Rejects(t *testing.T) {
+	signer := NewSigner("test-secret")
+	token := signer.Sign(uuid.New())
+	encodedID, encodedMAC, _ := strings.Cut(token, ".")
+	otherID, _, _ := strings.Cut(signer.Sign(uuid.New()), ".")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"no signature", encodedID},
+		{"other registration", otherID + "." + encodedMAC},
+		{"other secret", NewSigner("other-secret").Sign(uuid.New())},
+		{"truncated signature", encodedID + "." + encodedMAC[:10]},
+		{"not base64", "!!!." + encodedMAC},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := signer.Verify(tt.token); err != ErrInvalidToken {
+				t.Errorf("expected ErrInvalidToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestQRCodes(t *testing.T) {
+	token := NewSigner("test-secret").Sign(uuid.New())
+
+	png, err := QRCodePNG(token, 256)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.HasPrefix(png, []byte("\x89PNG")) {
+		t.Error("expected a PNG image")
+	}
+
+	svg, err := QRCodeSVG(token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var doc struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(svg, &doc); err != nil || doc.XMLName.Local != "svg" {
+		t.Errorf("expected a well-formed SVG document, got %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+// Package cron parses cron-like schedule specs and computes their next run times.
+//
+// Supported specs are the standard five fields (minute hour day-of-month month
+// day-of-week) with "*", lists, ranges and steps, the descriptors @yearly,
+// @monthly, @weekly, @daily and @hourly, and "@every <duration>".
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule errors
+var (
+	ErrInvalidSpec  = errors.New("invalid cron spec")
+	ErrInvalidField = errors.New("invalid cron field")
+)
+
+// Schedule computes when a job should next run
+type Schedule interface {
+	// Next returns the first run time strictly after t, or the zero time if there is none
+	Next(t time.Time) time.Time
+}
+
+// fieldBounds are the allowed ranges of the five spec fields
+var fieldBounds = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 6},
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// maxSearchYears bounds the search for the next run of specs that rarely match (e.g. Feb 30)
+const maxSearchYears = 5
+
+// Parse parses a schedule spec
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	if strings.HasPrefix(spec, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSpec, spec)
+		}
+		return EverySchedule{Interval: d}, nil
+	}
+	if expanded, ok := descriptors[spec]; ok {
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != len(fieldBounds) {
+		return nil, fmt.Errorf("%w: %q must have %d fields", ErrInvalidSpec, spec, len(fieldBounds))
+	}
+
+	var bits [5]uint64
+	for i, field := range fields {
+		b, err := parseField(field, fieldBounds[i].min, fieldBounds[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s %q", ErrInvalidField, fieldBounds[i].name, field)
+		}
+		bits[i] = b
+	}
+
+	return &SpecSchedule{
+		Minute:     bits[0],
+		Hour:       bits[1],
+		DayOfMonth: bits[2],
+		Month:      bits[3],
+		DayOfWeek:  bits[4],
+		domStar:    fields[2] == "*",
+		dowStar:    fields[4] == "*",
+	}, nil
+}
+
+// MustParse is like Parse but panics on error
+func MustParse(spec string) Schedule {
+	s, err := Parse(spec)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// parseField parses a comma-separated list of values, ranges and steps into a bitset
+func parseField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, ErrInvalidField
+			}
+			rangePart, step = part[:i], s
+		}
+
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, ErrInvalidField
+			}
+			if hi, err = strconv.Atoi(bounds[1]); err != nil {
+				return 0, ErrInvalidField
+			}
+		default:
+			v, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, ErrInvalidField
+			}
+			lo = v
+			if step == 1 {
+				hi = v
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return 0, ErrInvalidField
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// SpecSchedule is a schedule parsed from a five-field spec. Times are matched in
+// the location of the time passed to Next.
+type SpecSchedule struct {
+	Minute, Hour, DayOfMonth, Month, DayOfWeek uint64
+
+	domStar, dowStar bool
+}
+
+// Next returns the first matching minute strictly after t
+func (s *SpecSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if s.Month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.Hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.Minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchDay follows cron semantics: when both day fields are restricted, either may match
+func (s *SpecSchedule) matchDay(t time.Time) bool {
+	domMatch := s.DayOfMonth&(1<<uint(t.Day())) != 0
+	dowMatch := s.DayOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// EverySchedule runs at a fixed interval
+type EverySchedule struct {
+	Interval time.Duration
+}
+
+// Next returns t plus the interval
+func (s EverySchedule) Next(t time.Time) time.Time {
+	return t.Add(s.Interval)
+}
@@ -0,0 +1,80 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func mustTime(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr error
+	}{
+		{name: "too few fields", spec: "* * *", wantErr: ErrInvalidSpec},
+		{name: "minute out of range", spec: "60 * * * *", wantErr: ErrInvalidField},
+		{name: "inverted range", spec: "* 5-2 * * *", wantErr: ErrInvalidField},
+		{name: "zero step", spec: "*/0 * * * *", wantErr: ErrInvalidField},
+		{name: "not a number", spec: "* * * jan *", wantErr: ErrInvalidField},
+		{name: "bad interval", spec: "@every soon", wantErr: ErrInvalidSpec},
+		{name: "negative interval", spec: "@every -1m", wantErr: ErrInvalidSpec},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.spec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse(%q) error = %v, want %v", tt.spec, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		from string
+		want string
+	}{
+		{name: "every minute", spec: "* * * * *", from: "2026-03-10 08:15", want: "2026-03-10 08:16"},
+		{name: "step minutes", spec: "*/15 * * * *", from: "2026-03-10 08:15", want: "2026-03-10 08:30"},
+		{name: "daily rolls over", spec: "0 3 * * *", from: "2026-03-10 08:15", want: "2026-03-11 03:00"},
+		{name: "hourly descriptor", spec: "@hourly", from: "2026-03-10 08:15", want: "2026-03-10 09:00"},
+		{name: "list and range", spec: "30 9-10,18 * * *", from: "2026-03-10 10:30", want: "2026-03-10 18:30"},
+		{name: "weekday only", spec: "0 8 * * 1", from: "2026-03-10 08:15", want: "2026-03-16 08:00"},
+		{name: "month rolls over year", spec: "0 0 1 1 *", from: "2026-03-10 08:15", want: "2027-01-01 00:00"},
+		{name: "day of month or day of week", spec: "0 0 15 * 0", from: "2026-03-10 08:15", want: "2026-03-15 00:00"},
+		{name: "leap day", spec: "0 0 29 2 *", from: "2026-03-10 08:15", want: "2028-02-29 00:00"},
+		{name: "every interval", spec: "@every 90s", from: "2026-03-10 08:15", want: "2026-03-10 08:16"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.spec, err)
+			}
+			got := s.Next(mustTime(tt.from)).Truncate(time.Minute)
+			if !got.Equal(mustTime(tt.want)) {
+				t.Errorf("Next() = %v, want %v", got.Format("2006-01-02 15:04"), tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule_NextNeverMatches(t *testing.T) {
+	s := MustParse("0 0 30 2 *")
+	if got := s.Next(mustTime("2026-03-10 08:15")); !got.IsZero() {
+		t.Errorf("Next() = %v, want zero time", got)
+	}
+}
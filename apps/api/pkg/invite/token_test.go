@@ -0,0 +1,46 @@
+package invite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	signer := NewSigner("test-secret")
+	eventID := uuid.New()
+
+	if err := signer.Verify(signer.Sign(eventID), eventID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestVerify_Rejects(t *testing.T) {
+	signer := NewSigner("test-secret")
+	eventID := uuid.New()
+	token := signer.Sign(eventID)
+	encodedID, encodedMAC, _ := strings.Cut(token, ".")
+	otherID, _, _ := strings.Cut(signer.Sign(uuid.New()), ".")
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"no signature", encodedID},
+		{"other event", signer.Sign(uuid.New())},
+		{"swapped event", otherID + "." + encodedMAC},
+		{"other secret", NewSigner("other-secret").Sign(eventID)},
+		{"truncated signature", encodedID + "." + encodedMAC[:10]},
+		{"not base64", "!!!." + encodedMAC},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := signer.Verify(tt.token, eventID); err != ErrInvalidToken {
+				t.Errorf("expected ErrInvalidToken, got %v", err)
+			}
+		})
+	}
+}
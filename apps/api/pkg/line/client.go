@@ -12,8 +12,9 @@ import (
 )
 
 const (
-	tokenEndpoint   = "https://api.line.me/oauth2/v2.1/token"
-	profileEndpoint = "https://api.line.me/v2/profile"
+	tokenEndpoint      = "https://api.line.me/oauth2/v2.1/token"
+	profileEndpoint    = "https://api.line.me/v2/profile"
+	friendshipEndpoint = "https://api.line.me/friendship/v1/status"
 )
 
 // Client is a Line Login API client
@@ -132,6 +133,41 @@ func (c *Client) GetProfile(ctx context.Context, accessToken string) (*Profile,
 	return &profile, nil
 }
 
+// GetFriendshipStatus reports whether the user has added the bot linked to the
+// Line Login channel as a friend
+func (c *Client) GetFriendshipStatus(ctx context.Context, accessToken string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", friendshipEndpoint, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to get friendship status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("failed to get friendship status: %s", string(body))
+	}
+
+	var status struct {
+		FriendFlag bool `json:"friendFlag"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		return false, fmt.Errorf("failed to parse friendship status: %w", err)
+	}
+
+	return status.FriendFlag, nil
+}
+
 // GetAuthorizationURL returns the Line Login authorization URL
 func (c *Client) GetAuthorizationURL(state string) string {
 	params := url.Values{}
@@ -0,0 +1,95 @@
+package line
+
+// EventCard holds what is shown on an event card
+type EventCard struct {
+	// Heading is the notification title shown above the event
+	Heading  string
+	Title    string
+	Date     string
+	Time     string
+	Location string
+	// Message is the notification body
+	Message string
+	// URL is opened by the card's button
+	URL string
+}
+
+// Event card colors
+const (
+	cardAccentColor = "#1DB446"
+	cardMutedColor  = "#888888"
+)
+
+// NewEventCardMessage builds a flex message showing an event card
+func NewEventCardMessage(card EventCard) FlexMessage {
+	labelFlex, valueFlex := 2, 5
+
+	row := func(label, value string) FlexComponent {
+		return &FlexBox{
+			Layout:  "baseline",
+			Spacing: "sm",
+			Contents: []FlexComponent{
+				FlexText{Text: label, Size: "sm", Color: cardMutedColor, Flex: &labelFlex},
+				FlexText{Text: value, Size: "sm", Wrap: true, Flex: &valueFlex},
+			},
+		}
+	}
+
+	body := &FlexBox{
+		Layout:  "vertical",
+		Spacing: "md",
+		Contents: []FlexComponent{
+			FlexText{Text: card.Heading, Size: "sm", Weight: "bold", Color: cardAccentColor, Wrap: true},
+			FlexText{Text: card.Title, Size: "xl", Weight: "bold", Wrap: true},
+			&FlexBox{
+				Layout:  "vertical",
+				Spacing: "sm",
+				Margin:  "lg",
+				Contents: []FlexComponent{
+					row("Date", card.Date),
+					row("Time", card.Time),
+					row("Location", card.Location),
+				},
+			},
+		},
+	}
+	if card.Message != "" {
+		body.Contents = append(body.Contents,
+			FlexSeparator{Margin: "lg"},
+			FlexText{Text: card.Message, Size: "sm", Color: cardMutedColor, Wrap: true, Margin: "lg"},
+		)
+	}
+
+	bubble := FlexBubble{Body: body}
+	if card.URL != "" {
+		bubble.Footer = &FlexBox{
+			Layout: "vertical",
+			Contents: []FlexComponent{
+				FlexButton{
+					Action: URIAction{Label: "View event", URI: card.URL},
+					Style:  "primary",
+					Color:  cardAccentColor,
+					Height: "sm",
+				},
+			},
+		}
+	}
+
+	altText := card.Heading
+	if card.Title != "" {
+		altText += ": " + card.Title
+	}
+	return FlexMessage{AltText: truncate(altText, maxAltTextLength), Contents: bubble}
+}
+
+// maxAltTextLength is the longest alt text Line accepts
+const maxAltTextLength = 400
+
+// truncate shortens s to at most n characters
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
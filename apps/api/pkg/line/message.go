@@ -0,0 +1,169 @@
+package line
+
+import "encoding/json"
+
+// Message is a message that can be pushed with the Messaging API
+type Message interface {
+	json.Marshaler
+	message()
+}
+
+// FlexContainer is the top-level container of a flex message
+type FlexContainer interface {
+	json.Marshaler
+	flexContainer()
+}
+
+// FlexComponent is a component inside a flex box
+type FlexComponent interface {
+	json.Marshaler
+	flexComponent()
+}
+
+// Action is an action attached to a flex component
+type Action interface {
+	json.Marshaler
+	action()
+}
+
+// TextMessage is a plain text message
+type TextMessage struct {
+	Text string `json:"text"`
+}
+
+// FlexMessage is a message with a customizable layout
+type FlexMessage struct {
+	// AltText is shown in notifications and chat lists
+	AltText  string        `json:"altText"`
+	Contents FlexContainer `json:"contents"`
+}
+
+// FlexBubble is a single flex message card
+type FlexBubble struct {
+	Size   string   `json:"size,omitempty"`
+	Header *FlexBox `json:"header,omitempty"`
+	Body   *FlexBox `json:"body,omitempty"`
+	Footer *FlexBox `json:"footer,omitempty"`
+}
+
+// FlexBox lays out its contents horizontally or vertically
+type FlexBox struct {
+	Layout          string          `json:"layout"`
+	Contents        []FlexComponent `json:"contents"`
+	Spacing         string          `json:"spacing,omitempty"`
+	Margin          string          `json:"margin,omitempty"`
+	BackgroundColor string          `json:"backgroundColor,omitempty"`
+}
+
+// FlexText is a text component
+type FlexText struct {
+	Text   string `json:"text"`
+	Size   string `json:"size,omitempty"`
+	Weight string `json:"weight,omitempty"`
+	Color  string `json:"color,omitempty"`
+	Wrap   bool   `json:"wrap,omitempty"`
+	Flex   *int   `json:"flex,omitempty"`
+	Margin string `json:"margin,omitempty"`
+}
+
+// FlexButton is a button component
+type FlexButton struct {
+	Action Action `json:"action"`
+	Style  string `json:"style,omitempty"`
+	Color  string `json:"color,omitempty"`
+	Height string `json:"height,omitempty"`
+}
+
+// FlexSeparator is a horizontal or vertical line
+type FlexSeparator struct {
+	Margin string `json:"margin,omitempty"`
+}
+
+// URIAction opens a URI when tapped
+type URIAction struct {
+	Label string `json:"label"`
+	URI   string `json:"uri"`
+}
+
+// Marker methods restrict what may be nested where. Line tells objects apart
+// by a "type" field, which each MarshalJSON adds.
+func (TextMessage) message()         {}
+func (FlexMessage) message()         {}
+func (FlexBubble) flexContainer()    {}
+func (*FlexBox) flexComponent()      {}
+func (FlexText) flexComponent()      {}
+func (FlexButton) flexComponent()    {}
+func (FlexSeparator) flexComponent() {}
+func (URIAction) action()            {}
+
+// MarshalJSON implements json.Marshaler
+func (m TextMessage) MarshalJSON() ([]byte, error) {
+	type alias TextMessage
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{"text", alias(m)})
+}
+
+// MarshalJSON implements json.Marshaler
+func (m FlexMessage) MarshalJSON() ([]byte, error) {
+	type alias FlexMessage
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{"flex", alias(m)})
+}
+
+// MarshalJSON implements json.Marshaler
+func (b FlexBubble) MarshalJSON() ([]byte, error) {
+	type alias FlexBubble
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{"bubble", alias(b)})
+}
+
+// MarshalJSON implements json.Marshaler
+func (b *FlexBox) MarshalJSON() ([]byte, error) {
+	type alias FlexBox
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{"box", alias(*b)})
+}
+
+// MarshalJSON implements json.Marshaler
+func (t FlexText) MarshalJSON() ([]byte, error) {
+	type alias FlexText
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{"text", alias(t)})
+}
+
+// MarshalJSON implements json.Marshaler
+func (b FlexButton) MarshalJSON() ([]byte, error) {
+	type alias FlexButton
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{"button", alias(b)})
+}
+
+// MarshalJSON implements json.Marshaler
+func (s FlexSeparator) MarshalJSON() ([]byte, error) {
+	type alias FlexSeparator
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{"separator", alias(s)})
+}
+
+// MarshalJSON implements json.Marshaler
+func (a URIAction) MarshalJSON() ([]byte, error) {
+	type alias URIAction
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		alias
+	}{"uri", alias(a)})
+}
@@ -0,0 +1,162 @@
+package line
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultMessagingBaseURL is the base URL of the Line Messaging API
+const DefaultMessagingBaseURL = "https://api.line.me"
+
+// MessagingClient is a Line Messaging API client used to push messages to
+// users who have added the bot as a friend
+type MessagingClient struct {
+	httpClient         *http.Client
+	baseURL            string
+	channelAccessToken string
+	channelSecret      string
+}
+
+// MessagingConfig holds Line Messaging API configuration
+type MessagingConfig struct {
+	ChannelAccessToken string
+	ChannelSecret      string
+	// BaseURL overrides DefaultMessagingBaseURL, e.g. to point at a local stub server
+	BaseURL string
+}
+
+// NewMessagingClient creates a new Line Messaging API client
+func NewMessagingClient(cfg MessagingConfig) *MessagingClient {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultMessagingBaseURL
+	}
+	return &MessagingClient{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		baseURL:            strings.TrimRight(baseURL, "/"),
+		channelAccessToken: cfg.ChannelAccessToken,
+		channelSecret:      cfg.ChannelSecret,
+	}
+}
+
+// CanPush reports whether a channel access token is configured
+func (c *MessagingClient) CanPush() bool {
+	return c.channelAccessToken != ""
+}
+
+// APIError is an error response from the Line Messaging API
+type APIError struct {
+	StatusCode int
+	Message    string `json:"message"`
+	Details    []struct {
+		Message  string `json:"message"`
+		Property string `json:"property"`
+	} `json:"details"`
+}
+
+// Error implements error
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("line api error %d: %s", e.StatusCode, e.Message)
+	for _, d := range e.Details {
+		msg += fmt.Sprintf(" (%s: %s)", d.Property, d.Message)
+	}
+	return msg
+}
+
+// Temporary reports whether the request may succeed if retried
+func (e *APIError) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= 500
+}
+
+type pushRequest struct {
+	To       string    `json:"to"`
+	Messages []Message `json:"messages"`
+}
+
+// Push sends messages to a user. A non-empty retryKey makes the request
+// idempotent: retrying with the same key never delivers the messages twice.
+func (c *MessagingClient) Push(ctx context.Context, to, retryKey string, messages ...Message) error {
+	body, err := json.Marshal(pushRequest{To: to, Messages: messages})
+	if err != nil {
+		return fmt.Errorf("failed to encode messages: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/v2/bot/message/push", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.channelAccessToken)
+	if retryKey != "" {
+		req.Header.Set("X-Line-Retry-Key", retryKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to push message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	// A request with this retry key was already accepted
+	if resp.StatusCode == http.StatusConflict && resp.Header.Get("X-Line-Accepted-Request-Id") != "" {
+		return nil
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	if err := json.Unmarshal(respBody, apiErr); err != nil || apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(respBody))
+	}
+	return apiErr
+}
+
+// VerifySignature reports whether a webhook body was signed with the channel secret
+func (c *MessagingClient) VerifySignature(body []byte, signature string) bool {
+	if c.channelSecret == "" || signature == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(c.channelSecret))
+	mac.Write(body)
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// Webhook event types
+const (
+	WebhookEventFollow   = "follow"
+	WebhookEventUnfollow = "unfollow"
+)
+
+// WebhookRequest is the body Line posts to the bot's webhook URL
+type WebhookRequest struct {
+	Destination string         `json:"destination"`
+	Events      []WebhookEvent `json:"events"`
+}
+
+// WebhookEvent is a single event delivered to the webhook
+type WebhookEvent struct {
+	Type      string `json:"type"`
+	Timestamp int64  `json:"timestamp"`
+	Source    struct {
+		Type   string `json:"type"`
+		UserID string `json:"userId"`
+	} `json:"source"`
+}
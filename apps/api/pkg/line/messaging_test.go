@@ -0,0 +1,135 @@
+package line
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPush(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/bot/message/push" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token" {
+			t.Errorf("unexpected authorization %q", auth)
+		}
+		if key := r.Header.Get("X-Line-Retry-Key"); key != "retry-key" {
+			t.Errorf("unexpected retry key %q", key)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("invalid body: %v", err)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := NewMessagingClient(MessagingConfig{ChannelAccessToken: "token", BaseURL: srv.URL})
+	card := NewEventCardMessage(EventCard{
+		Heading:  "Your game starts in 2 hours",
+		Title:    "Friday Doubles",
+		Date:     "03/14",
+		Time:     "19:00",
+		Location: "Daan Park",
+		URL:      "https://picklego.tw/g/abc123",
+	})
+	err := client.Push(context.Background(), "U123", "retry-key", TextMessage{Text: "hello"}, card)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got["to"] != "U123" {
+		t.Errorf("expected to U123, got %v", got["to"])
+	}
+	messages := got["messages"].([]interface{})
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(messages))
+	}
+	if typ := messages[0].(map[string]interface{})["type"]; typ != "text" {
+		t.Errorf("expected text message, got %v", typ)
+	}
+	flex := messages[1].(map[string]interface{})
+	if flex["type"] != "flex" || flex["altText"] != "Your game starts in 2 hours: Friday Doubles" {
+		t.Errorf("unexpected flex message %v", flex)
+	}
+	if typ := flex["contents"].(map[string]interface{})["type"]; typ != "bubble" {
+		t.Errorf("expected bubble contents, got %v", typ)
+	}
+}
+
+func TestPush_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		header        string
+		body          string
+		wantErr       bool
+		wantTemporary bool
+	}{
+		{name: "bad request", status: http.StatusBadRequest, body: `{"message":"The request body has 1 error(s)","details":[{"message":"invalid","property":"to"}]}`, wantErr: true},
+		{name: "rate limited", status: http.StatusTooManyRequests, body: `{"message":"Too many requests"}`, wantErr: true, wantTemporary: true},
+		{name: "server error", status: http.StatusInternalServerError, body: `oops`, wantErr: true, wantTemporary: true},
+		{name: "retry already accepted", status: http.StatusConflict, header: "req-1", body: `{"message":"The retry key is already accepted"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != "" {
+					w.Header().Set("X-Line-Accepted-Request-Id", tt.header)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			client := NewMessagingClient(MessagingConfig{ChannelAccessToken: "token", BaseURL: srv.URL})
+			err := client.Push(context.Background(), "U123", "key", TextMessage{Text: "hello"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error=%v, got %v", tt.wantErr, err)
+			}
+			if err == nil {
+				return
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *APIError, got %T", err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, apiErr.StatusCode)
+			}
+			if apiErr.Temporary() != tt.wantTemporary {
+				t.Errorf("expected temporary=%v", tt.wantTemporary)
+			}
+		})
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	client := NewMessagingClient(MessagingConfig{ChannelSecret: "secret"})
+	body := []byte(`{"events":[]}`)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	if !client.VerifySignature(body, signature) {
+		t.Error("expected valid signature")
+	}
+	if client.VerifySignature([]byte(`{"events":[{}]}`), signature) {
+		t.Error("expected tampered body to be rejected")
+	}
+	if client.VerifySignature(body, "") {
+		t.Error("expected missing signature to be rejected")
+	}
+}
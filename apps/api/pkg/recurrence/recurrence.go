@@ -0,0 +1,138 @@
+package recurrence
+
+import (
+	"errors"
+	"time"
+)
+
+// Frequency represents how often a recurrence rule repeats
+type Frequency string
+
+const (
+	Weekly   Frequency = "weekly"
+	Biweekly Frequency = "biweekly"
+	Monthly  Frequency = "monthly"
+)
+
+var (
+	// ErrInvalidFrequency is returned when the frequency is not supported
+	ErrInvalidFrequency = errors.New("invalid recurrence frequency")
+	// ErrMissingWeekdays is returned when a weekly rule has no weekdays
+	ErrMissingWeekdays = errors.New("weekly recurrence requires at least one weekday")
+	// ErrInvalidMonthDay is returned when a monthly rule has an out-of-range day
+	ErrInvalidMonthDay = errors.New("month day must be between 1 and 31")
+	// ErrInvalidRange is returned when the rule ends before it starts
+	ErrInvalidRange = errors.New("recurrence end date is before start date")
+)
+
+// Rule is a simplified RRULE supporting weekly, biweekly and monthly repetition
+type Rule struct {
+	Frequency Frequency
+	// Weekdays lists the days of week for weekly and biweekly rules
+	Weekdays []time.Weekday
+	// MonthDay is the day of month for monthly rules (0 uses the start date's day).
+	// Months without that day use their last day instead, so 31 means month end.
+	MonthDay int
+	// Start is the first date the rule may produce; it also anchors biweekly parity
+	Start time.Time
+	// Until is the last date the rule may produce (zero means no end)
+	Until time.Time
+	// Exceptions are dates that are skipped even if the rule matches (EXDATE)
+	Exceptions []time.Time
+}
+
+// Validate checks that the rule is well formed
+func (r Rule) Validate() error {
+	switch r.Frequency {
+	case Weekly, Biweekly:
+		if len(r.Weekdays) == 0 {
+			return ErrMissingWeekdays
+		}
+	case Monthly:
+		if r.MonthDay < 0 || r.MonthDay > 31 {
+			return ErrInvalidMonthDay
+		}
+	default:
+		return ErrInvalidFrequency
+	}
+	if !r.Until.IsZero() && Date(r.Until).Before(Date(r.Start)) {
+		return ErrInvalidRange
+	}
+	return nil
+}
+
+// Between returns all occurrence dates in the inclusive range [from, to]
+func (r Rule) Between(from, to time.Time) []time.Time {
+	from, to = Date(from), Date(to)
+	start := Date(r.Start)
+	if from.Before(start) {
+		from = start
+	}
+	if !r.Until.IsZero() && Date(r.Until).Before(to) {
+		to = Date(r.Until)
+	}
+	if to.Before(from) {
+		return nil
+	}
+
+	excluded := make(map[time.Time]bool, len(r.Exceptions))
+	for _, d := range r.Exceptions {
+		excluded[Date(d)] = true
+	}
+
+	var dates []time.Time
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if r.matches(d, start) && !excluded[d] {
+			dates = append(dates, d)
+		}
+	}
+	return dates
+}
+
+// matches reports whether the given date satisfies the rule
+func (r Rule) matches(d, start time.Time) bool {
+	switch r.Frequency {
+	case Weekly:
+		return r.hasWeekday(d.Weekday())
+	case Biweekly:
+		if !r.hasWeekday(d.Weekday()) {
+			return false
+		}
+		weeks := int(weekStart(d).Sub(weekStart(start)).Hours() / 24 / 7)
+		return weeks%2 == 0
+	case Monthly:
+		day := r.MonthDay
+		if day == 0 {
+			day = start.Day()
+		}
+		if last := daysIn(d); day > last {
+			day = last
+		}
+		return d.Day() == day
+	}
+	return false
+}
+
+func (r Rule) hasWeekday(wd time.Weekday) bool {
+	for _, w := range r.Weekdays {
+		if w == wd {
+			return true
+		}
+	}
+	return false
+}
+
+// Date truncates a time to midnight UTC on the same calendar day
+func Date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// daysIn returns the number of days in the month containing d
+func daysIn(d time.Time) int {
+	return time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// weekStart returns the Sunday that begins the week containing d
+func weekStart(d time.Time) time.Time {
+	return d.AddDate(0, 0, -int(d.Weekday()))
+}
@@ -0,0 +1,166 @@
+package recurrence
+
+import (
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func formatDates(dates []time.Time) []string {
+	out := make([]string, 0, len(dates))
+	for _, d := range dates {
+		out = append(out, d.Format("2006-01-02"))
+	}
+	return out
+}
+
+func TestRule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		wantErr error
+	}{
+		{
+			name:    "valid weekly",
+			rule:    Rule{Frequency: Weekly, Weekdays: []time.Weekday{time.Tuesday}, Start: date("2026-01-06")},
+			wantErr: nil,
+		},
+		{
+			name:    "weekly without weekdays",
+			rule:    Rule{Frequency: Weekly, Start: date("2026-01-06")},
+			wantErr: ErrMissingWeekdays,
+		},
+		{
+			name:    "monthly with invalid day",
+			rule:    Rule{Frequency: Monthly, MonthDay: 32, Start: date("2026-01-06")},
+			wantErr: ErrInvalidMonthDay,
+		},
+		{
+			name:    "unknown frequency",
+			rule:    Rule{Frequency: "daily", Start: date("2026-01-06")},
+			wantErr: ErrInvalidFrequency,
+		},
+		{
+			name:    "until before start",
+			rule:    Rule{Frequency: Monthly, Start: date("2026-02-01"), Until: date("2026-01-01")},
+			wantErr: ErrInvalidRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRule_Between(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+		from string
+		to   string
+		want []string
+	}{
+		{
+			name: "weekly tuesday and thursday",
+			rule: Rule{
+				Frequency: Weekly,
+				Weekdays:  []time.Weekday{time.Tuesday, time.Thursday},
+				Start:     date("2026-01-06"),
+			},
+			from: "2026-01-01",
+			to:   "2026-01-18",
+			want: []string{"2026-01-06", "2026-01-08", "2026-01-13", "2026-01-15"},
+		},
+		{
+			name: "biweekly keeps start week parity",
+			rule: Rule{
+				Frequency: Biweekly,
+				Weekdays:  []time.Weekday{time.Tuesday},
+				Start:     date("2026-01-06"),
+			},
+			from: "2026-01-13",
+			to:   "2026-02-10",
+			want: []string{"2026-01-20", "2026-02-03"},
+		},
+		{
+			name: "monthly falls back to the last day of shorter months",
+			rule: Rule{
+				Frequency: Monthly,
+				MonthDay:  31,
+				Start:     date("2026-01-01"),
+			},
+			from: "2026-01-01",
+			to:   "2026-05-31",
+			want: []string{"2026-01-31", "2026-02-28", "2026-03-31", "2026-04-30", "2026-05-31"},
+		},
+		{
+			name: "monthly start day falls back in a leap february",
+			rule: Rule{
+				Frequency: Monthly,
+				Start:     date("2028-01-30"),
+			},
+			from: "2028-01-01",
+			to:   "2028-03-31",
+			want: []string{"2028-01-30", "2028-02-29", "2028-03-30"},
+		},
+		{
+			name: "monthly defaults to start day",
+			rule: Rule{
+				Frequency: Monthly,
+				Start:     date("2026-01-15"),
+			},
+			from: "2026-01-01",
+			to:   "2026-03-31",
+			want: []string{"2026-01-15", "2026-02-15", "2026-03-15"},
+		},
+		{
+			name: "exceptions and until are honoured",
+			rule: Rule{
+				Frequency:  Weekly,
+				Weekdays:   []time.Weekday{time.Tuesday},
+				Start:      date("2026-01-06"),
+				Until:      date("2026-01-27"),
+				Exceptions: []time.Time{date("2026-01-13")},
+			},
+			from: "2026-01-01",
+			to:   "2026-03-01",
+			want: []string{"2026-01-06", "2026-01-20", "2026-01-27"},
+		},
+		{
+			name: "empty range",
+			rule: Rule{
+				Frequency: Weekly,
+				Weekdays:  []time.Weekday{time.Tuesday},
+				Start:     date("2026-01-06"),
+			},
+			from: "2026-01-10",
+			to:   "2026-01-01",
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatDates(tt.rule.Between(date(tt.from), date(tt.to)))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Between() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Between()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Package transfer creates the one-time tokens in spot transfer links.
+//
+// Tokens are random and only their SHA-256 hash is stored, so a leaked
+// database does not reveal working links. A token is single use because the
+// transfer it belongs to can only be completed once.
+package transfer
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// tokenBytes is how many random bytes a token carries
+const tokenBytes = 24
+
+// NewToken returns a random link token and the hash to store for it
+func NewToken() (token, hash string, err error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, Hash(token), nil
+}
+
+// Hash returns the stored form of a link token
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,27 @@
+package transfer
+
+import "testing"
+
+func TestNewToken(t *testing.T) {
+	token, hash, err := NewToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(token) != 32 {
+		t.Errorf("expected a 32 character token, got %q", token)
+	}
+	if hash != Hash(token) {
+		t.Error("expected the hash to match the token")
+	}
+	if len(hash) != 64 {
+		t.Errorf("expected a 64 character hash, got %q", hash)
+	}
+
+	other, _, err := NewToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other == token {
+		t.Error("expected tokens to differ")
+	}
+}